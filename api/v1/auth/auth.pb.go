@@ -104,8 +104,9 @@ func (x *PingResp) GetPong() string {
 // login request
 type LoginReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"` //deprecated: use identifier
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Identifier    string                 `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"` //email or username
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -154,11 +155,18 @@ func (x *LoginReq) GetPassword() string {
 	return ""
 }
 
+func (x *LoginReq) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
 // login response
 type LoginResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`  //
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	ExpiresIn     int64                  `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` //sec
 	TokenType     string                 `protobuf:"bytes,4,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`  //bearer
 	unknownFields protoimpl.UnknownFields
@@ -371,6 +379,702 @@ func (x *LogoutResp) GetMessage() string {
 	return ""
 }
 
+// passwordless login: mail a one-time code and magic link
+type RequestLoginCodeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoginCodeReq) Reset() {
+	*x = RequestLoginCodeReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoginCodeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginCodeReq) ProtoMessage() {}
+
+func (x *RequestLoginCodeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginCodeReq.ProtoReflect.Descriptor instead.
+func (*RequestLoginCodeReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RequestLoginCodeReq) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestLoginCodeResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`                                //always true for unknown emails, no account enumeration
+	ExpiresIn     int64                  `protobuf:"varint,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` //sec
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestLoginCodeResp) Reset() {
+	*x = RequestLoginCodeResp{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLoginCodeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLoginCodeResp) ProtoMessage() {}
+
+func (x *RequestLoginCodeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLoginCodeResp.ProtoReflect.Descriptor instead.
+func (*RequestLoginCodeResp) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RequestLoginCodeResp) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *RequestLoginCodeResp) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+// either email+code or link_token is required
+type VerifyLoginCodeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	LinkToken     string                 `protobuf:"bytes,3,opt,name=link_token,json=linkToken,proto3" json:"link_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLoginCodeReq) Reset() {
+	*x = VerifyLoginCodeReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginCodeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginCodeReq) ProtoMessage() {}
+
+func (x *VerifyLoginCodeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginCodeReq.ProtoReflect.Descriptor instead.
+func (*VerifyLoginCodeReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyLoginCodeReq) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *VerifyLoginCodeReq) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *VerifyLoginCodeReq) GetLinkToken() string {
+	if x != nil {
+		return x.LinkToken
+	}
+	return ""
+}
+
+// device authorization grant (RFC 8628) for cli/tv clients
+type DeviceAuthorizeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Scope         string                 `protobuf:"bytes,2,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceAuthorizeReq) Reset() {
+	*x = DeviceAuthorizeReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceAuthorizeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorizeReq) ProtoMessage() {}
+
+func (x *DeviceAuthorizeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorizeReq.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorizeReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeviceAuthorizeReq) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizeReq) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+type DeviceAuthorizeResp struct {
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	DeviceCode              string                 `protobuf:"bytes,1,opt,name=device_code,json=deviceCode,proto3" json:"device_code,omitempty"`
+	UserCode                string                 `protobuf:"bytes,2,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	VerificationUri         string                 `protobuf:"bytes,3,opt,name=verification_uri,json=verificationUri,proto3" json:"verification_uri,omitempty"`
+	VerificationUriComplete string                 `protobuf:"bytes,4,opt,name=verification_uri_complete,json=verificationUriComplete,proto3" json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int64                  `protobuf:"varint,5,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` //sec
+	Interval                int64                  `protobuf:"varint,6,opt,name=interval,proto3" json:"interval,omitempty"`                    //sec, minimum polling interval
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *DeviceAuthorizeResp) Reset() {
+	*x = DeviceAuthorizeResp{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceAuthorizeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceAuthorizeResp) ProtoMessage() {}
+
+func (x *DeviceAuthorizeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceAuthorizeResp.ProtoReflect.Descriptor instead.
+func (*DeviceAuthorizeResp) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeviceAuthorizeResp) GetDeviceCode() string {
+	if x != nil {
+		return x.DeviceCode
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizeResp) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizeResp) GetVerificationUri() string {
+	if x != nil {
+		return x.VerificationUri
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizeResp) GetVerificationUriComplete() string {
+	if x != nil {
+		return x.VerificationUriComplete
+	}
+	return ""
+}
+
+func (x *DeviceAuthorizeResp) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *DeviceAuthorizeResp) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+// called by the gateway on behalf of the logged-in user
+type ApproveDeviceReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserCode      string                 `protobuf:"bytes,1,opt,name=user_code,json=userCode,proto3" json:"user_code,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Deny          bool                   `protobuf:"varint,3,opt,name=deny,proto3" json:"deny,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceReq) Reset() {
+	*x = ApproveDeviceReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceReq) ProtoMessage() {}
+
+func (x *ApproveDeviceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceReq.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApproveDeviceReq) GetUserCode() string {
+	if x != nil {
+		return x.UserCode
+	}
+	return ""
+}
+
+func (x *ApproveDeviceReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ApproveDeviceReq) GetDeny() bool {
+	if x != nil {
+		return x.Deny
+	}
+	return false
+}
+
+type ApproveDeviceResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ok            bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Scope         string                 `protobuf:"bytes,3,opt,name=scope,proto3" json:"scope,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveDeviceResp) Reset() {
+	*x = ApproveDeviceResp{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveDeviceResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceResp) ProtoMessage() {}
+
+func (x *ApproveDeviceResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceResp.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceResp) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ApproveDeviceResp) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ApproveDeviceResp) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ApproveDeviceResp) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+// polled by the device until the user approves, status message carries the RFC 8628 error code
+type DeviceTokenReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceCode    string                 `protobuf:"bytes,1,opt,name=device_code,json=deviceCode,proto3" json:"device_code,omitempty"`
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceTokenReq) Reset() {
+	*x = DeviceTokenReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceTokenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceTokenReq) ProtoMessage() {}
+
+func (x *DeviceTokenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceTokenReq.ProtoReflect.Descriptor instead.
+func (*DeviceTokenReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeviceTokenReq) GetDeviceCode() string {
+	if x != nil {
+		return x.DeviceCode
+	}
+	return ""
+}
+
+func (x *DeviceTokenReq) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+// admin impersonation: short-lived access token for target_user_id with an "act" claim naming the admin
+type ImpersonateReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminId       string                 `protobuf:"bytes,1,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"` //authenticated caller, set by gateway
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` //written to the audit log
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateReq) Reset() {
+	*x = ImpersonateReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateReq) ProtoMessage() {}
+
+func (x *ImpersonateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateReq.ProtoReflect.Descriptor instead.
+func (*ImpersonateReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImpersonateReq) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *ImpersonateReq) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+func (x *ImpersonateReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// no refresh token and no session are issued
+type ImpersonateResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresIn     int64                  `protobuf:"varint,2,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` //sec
+	TokenType     string                 `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`  //bearer
+	ActorId       string                 `protobuf:"bytes,4,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,5,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateResp) Reset() {
+	*x = ImpersonateResp{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateResp) ProtoMessage() {}
+
+func (x *ImpersonateResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateResp.ProtoReflect.Descriptor instead.
+func (*ImpersonateResp) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImpersonateResp) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ImpersonateResp) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *ImpersonateResp) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *ImpersonateResp) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ImpersonateResp) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+// role of an auth user, used by the gateway to guard admin routes
+type GetRoleReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleReq) Reset() {
+	*x = GetRoleReq{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleReq) ProtoMessage() {}
+
+func (x *GetRoleReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleReq.ProtoReflect.Descriptor instead.
+func (*GetRoleReq) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetRoleReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetRoleResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoleResp) Reset() {
+	*x = GetRoleResp{}
+	mi := &file_api_v1_auth_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoleResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoleResp) ProtoMessage() {}
+
+func (x *GetRoleResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoleResp.ProtoReflect.Descriptor instead.
+func (*GetRoleResp) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetRoleResp) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 var File_api_v1_auth_auth_proto protoreflect.FileDescriptor
 
 const file_api_v1_auth_auth_proto_rawDesc = "" +
@@ -378,10 +1082,13 @@ const file_api_v1_auth_auth_proto_rawDesc = "" +
 	"\x16api/v1/auth/auth.proto\x12\aauth.v1\"\t\n" +
 	"\aPingReq\"\x1e\n" +
 	"\bPingResp\x12\x12\n" +
-	"\x04pong\x18\x01 \x01(\tR\x04pong\"B\n" +
+	"\x04pong\x18\x01 \x01(\tR\x04pong\"b\n" +
 	"\bLoginReq\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x8b\x01\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x03 \x01(\tR\n" +
+	"identifier\"\x8b\x01\n" +
 	"\tLoginResp\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
 	"\n" +
@@ -401,12 +1108,71 @@ const file_api_v1_auth_auth_proto_rawDesc = "" +
 	"\n" +
 	"LogoutResp\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xd1\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"+\n" +
+	"\x13RequestLoginCodeReq\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"E\n" +
+	"\x14RequestLoginCodeResp\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\x03R\texpiresIn\"]\n" +
+	"\x12VerifyLoginCodeReq\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1d\n" +
+	"\n" +
+	"link_token\x18\x03 \x01(\tR\tlinkToken\"G\n" +
+	"\x12DeviceAuthorizeReq\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12\x14\n" +
+	"\x05scope\x18\x02 \x01(\tR\x05scope\"\xf5\x01\n" +
+	"\x13DeviceAuthorizeResp\x12\x1f\n" +
+	"\vdevice_code\x18\x01 \x01(\tR\n" +
+	"deviceCode\x12\x1b\n" +
+	"\tuser_code\x18\x02 \x01(\tR\buserCode\x12)\n" +
+	"\x10verification_uri\x18\x03 \x01(\tR\x0fverificationUri\x12:\n" +
+	"\x19verification_uri_complete\x18\x04 \x01(\tR\x17verificationUriComplete\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x05 \x01(\x03R\texpiresIn\x12\x1a\n" +
+	"\binterval\x18\x06 \x01(\x03R\binterval\"\\\n" +
+	"\x10ApproveDeviceReq\x12\x1b\n" +
+	"\tuser_code\x18\x01 \x01(\tR\buserCode\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04deny\x18\x03 \x01(\bR\x04deny\"V\n" +
+	"\x11ApproveDeviceResp\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12\x14\n" +
+	"\x05scope\x18\x03 \x01(\tR\x05scope\"N\n" +
+	"\x0eDeviceTokenReq\x12\x1f\n" +
+	"\vdevice_code\x18\x01 \x01(\tR\n" +
+	"deviceCode\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\"i\n" +
+	"\x0eImpersonateReq\x12\x19\n" +
+	"\badmin_id\x18\x01 \x01(\tR\aadminId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xb3\x01\n" +
+	"\x0fImpersonateResp\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x02 \x01(\x03R\texpiresIn\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x03 \x01(\tR\ttokenType\x12\x19\n" +
+	"\bactor_id\x18\x04 \x01(\tR\aactorId\x12$\n" +
+	"\x0etarget_user_id\x18\x05 \x01(\tR\ftargetUserId\"%\n" +
+	"\n" +
+	"GetRoleReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"!\n" +
+	"\vGetRoleResp\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role2\xb0\x05\n" +
 	"\vAuthService\x12+\n" +
 	"\x04Ping\x12\x10.auth.v1.PingReq\x1a\x11.auth.v1.PingResp\x12.\n" +
 	"\x05Login\x12\x11.auth.v1.LoginReq\x1a\x12.auth.v1.LoginResp\x122\n" +
 	"\aRefresh\x12\x13.auth.v1.RefreshReq\x1a\x12.auth.v1.LoginResp\x121\n" +
-	"\x06Logout\x12\x12.auth.v1.LogoutReq\x1a\x13.auth.v1.LogoutRespB.Z,github.com/uwu-octane/antBackend/api/v1/authb\x06proto3"
+	"\x06Logout\x12\x12.auth.v1.LogoutReq\x1a\x13.auth.v1.LogoutResp\x12O\n" +
+	"\x10RequestLoginCode\x12\x1c.auth.v1.RequestLoginCodeReq\x1a\x1d.auth.v1.RequestLoginCodeResp\x12B\n" +
+	"\x0fVerifyLoginCode\x12\x1b.auth.v1.VerifyLoginCodeReq\x1a\x12.auth.v1.LoginResp\x12L\n" +
+	"\x0fDeviceAuthorize\x12\x1b.auth.v1.DeviceAuthorizeReq\x1a\x1c.auth.v1.DeviceAuthorizeResp\x12F\n" +
+	"\rApproveDevice\x12\x19.auth.v1.ApproveDeviceReq\x1a\x1a.auth.v1.ApproveDeviceResp\x12:\n" +
+	"\vDeviceToken\x12\x17.auth.v1.DeviceTokenReq\x1a\x12.auth.v1.LoginResp\x12@\n" +
+	"\vImpersonate\x12\x17.auth.v1.ImpersonateReq\x1a\x18.auth.v1.ImpersonateResp\x124\n" +
+	"\aGetRole\x12\x13.auth.v1.GetRoleReq\x1a\x14.auth.v1.GetRoleRespB.Z,github.com/uwu-octane/antBackend/api/v1/authb\x06proto3"
 
 var (
 	file_api_v1_auth_auth_proto_rawDescOnce sync.Once
@@ -420,30 +1186,56 @@ func file_api_v1_auth_auth_proto_rawDescGZIP() []byte {
 	return file_api_v1_auth_auth_proto_rawDescData
 }
 
-var file_api_v1_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_v1_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_api_v1_auth_auth_proto_goTypes = []any{
-	(*PingReq)(nil),    // 0: auth.v1.PingReq
-	(*PingResp)(nil),   // 1: auth.v1.PingResp
-	(*LoginReq)(nil),   // 2: auth.v1.LoginReq
-	(*LoginResp)(nil),  // 3: auth.v1.LoginResp
-	(*RefreshReq)(nil), // 4: auth.v1.RefreshReq
-	(*LogoutReq)(nil),  // 5: auth.v1.LogoutReq
-	(*LogoutResp)(nil), // 6: auth.v1.LogoutResp
+	(*PingReq)(nil),              // 0: auth.v1.PingReq
+	(*PingResp)(nil),             // 1: auth.v1.PingResp
+	(*LoginReq)(nil),             // 2: auth.v1.LoginReq
+	(*LoginResp)(nil),            // 3: auth.v1.LoginResp
+	(*RefreshReq)(nil),           // 4: auth.v1.RefreshReq
+	(*LogoutReq)(nil),            // 5: auth.v1.LogoutReq
+	(*LogoutResp)(nil),           // 6: auth.v1.LogoutResp
+	(*RequestLoginCodeReq)(nil),  // 7: auth.v1.RequestLoginCodeReq
+	(*RequestLoginCodeResp)(nil), // 8: auth.v1.RequestLoginCodeResp
+	(*VerifyLoginCodeReq)(nil),   // 9: auth.v1.VerifyLoginCodeReq
+	(*DeviceAuthorizeReq)(nil),   // 10: auth.v1.DeviceAuthorizeReq
+	(*DeviceAuthorizeResp)(nil),  // 11: auth.v1.DeviceAuthorizeResp
+	(*ApproveDeviceReq)(nil),     // 12: auth.v1.ApproveDeviceReq
+	(*ApproveDeviceResp)(nil),    // 13: auth.v1.ApproveDeviceResp
+	(*DeviceTokenReq)(nil),       // 14: auth.v1.DeviceTokenReq
+	(*ImpersonateReq)(nil),       // 15: auth.v1.ImpersonateReq
+	(*ImpersonateResp)(nil),      // 16: auth.v1.ImpersonateResp
+	(*GetRoleReq)(nil),           // 17: auth.v1.GetRoleReq
+	(*GetRoleResp)(nil),          // 18: auth.v1.GetRoleResp
 }
 var file_api_v1_auth_auth_proto_depIdxs = []int32{
-	0, // 0: auth.v1.AuthService.Ping:input_type -> auth.v1.PingReq
-	2, // 1: auth.v1.AuthService.Login:input_type -> auth.v1.LoginReq
-	4, // 2: auth.v1.AuthService.Refresh:input_type -> auth.v1.RefreshReq
-	5, // 3: auth.v1.AuthService.Logout:input_type -> auth.v1.LogoutReq
-	1, // 4: auth.v1.AuthService.Ping:output_type -> auth.v1.PingResp
-	3, // 5: auth.v1.AuthService.Login:output_type -> auth.v1.LoginResp
-	3, // 6: auth.v1.AuthService.Refresh:output_type -> auth.v1.LoginResp
-	6, // 7: auth.v1.AuthService.Logout:output_type -> auth.v1.LogoutResp
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: auth.v1.AuthService.Ping:input_type -> auth.v1.PingReq
+	2,  // 1: auth.v1.AuthService.Login:input_type -> auth.v1.LoginReq
+	4,  // 2: auth.v1.AuthService.Refresh:input_type -> auth.v1.RefreshReq
+	5,  // 3: auth.v1.AuthService.Logout:input_type -> auth.v1.LogoutReq
+	7,  // 4: auth.v1.AuthService.RequestLoginCode:input_type -> auth.v1.RequestLoginCodeReq
+	9,  // 5: auth.v1.AuthService.VerifyLoginCode:input_type -> auth.v1.VerifyLoginCodeReq
+	10, // 6: auth.v1.AuthService.DeviceAuthorize:input_type -> auth.v1.DeviceAuthorizeReq
+	12, // 7: auth.v1.AuthService.ApproveDevice:input_type -> auth.v1.ApproveDeviceReq
+	14, // 8: auth.v1.AuthService.DeviceToken:input_type -> auth.v1.DeviceTokenReq
+	15, // 9: auth.v1.AuthService.Impersonate:input_type -> auth.v1.ImpersonateReq
+	17, // 10: auth.v1.AuthService.GetRole:input_type -> auth.v1.GetRoleReq
+	1,  // 11: auth.v1.AuthService.Ping:output_type -> auth.v1.PingResp
+	3,  // 12: auth.v1.AuthService.Login:output_type -> auth.v1.LoginResp
+	3,  // 13: auth.v1.AuthService.Refresh:output_type -> auth.v1.LoginResp
+	6,  // 14: auth.v1.AuthService.Logout:output_type -> auth.v1.LogoutResp
+	8,  // 15: auth.v1.AuthService.RequestLoginCode:output_type -> auth.v1.RequestLoginCodeResp
+	3,  // 16: auth.v1.AuthService.VerifyLoginCode:output_type -> auth.v1.LoginResp
+	11, // 17: auth.v1.AuthService.DeviceAuthorize:output_type -> auth.v1.DeviceAuthorizeResp
+	13, // 18: auth.v1.AuthService.ApproveDevice:output_type -> auth.v1.ApproveDeviceResp
+	3,  // 19: auth.v1.AuthService.DeviceToken:output_type -> auth.v1.LoginResp
+	16, // 20: auth.v1.AuthService.Impersonate:output_type -> auth.v1.ImpersonateResp
+	18, // 21: auth.v1.AuthService.GetRole:output_type -> auth.v1.GetRoleResp
+	11, // [11:22] is the sub-list for method output_type
+	0,  // [0:11] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_auth_auth_proto_init() }
@@ -457,7 +1249,7 @@ func file_api_v1_auth_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_auth_auth_proto_rawDesc), len(file_api_v1_auth_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
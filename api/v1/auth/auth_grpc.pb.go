@@ -19,10 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Ping_FullMethodName    = "/auth.v1.AuthService/Ping"
-	AuthService_Login_FullMethodName   = "/auth.v1.AuthService/Login"
-	AuthService_Refresh_FullMethodName = "/auth.v1.AuthService/Refresh"
-	AuthService_Logout_FullMethodName  = "/auth.v1.AuthService/Logout"
+	AuthService_Ping_FullMethodName             = "/auth.v1.AuthService/Ping"
+	AuthService_Login_FullMethodName            = "/auth.v1.AuthService/Login"
+	AuthService_Refresh_FullMethodName          = "/auth.v1.AuthService/Refresh"
+	AuthService_Logout_FullMethodName           = "/auth.v1.AuthService/Logout"
+	AuthService_RequestLoginCode_FullMethodName = "/auth.v1.AuthService/RequestLoginCode"
+	AuthService_VerifyLoginCode_FullMethodName  = "/auth.v1.AuthService/VerifyLoginCode"
+	AuthService_DeviceAuthorize_FullMethodName  = "/auth.v1.AuthService/DeviceAuthorize"
+	AuthService_ApproveDevice_FullMethodName    = "/auth.v1.AuthService/ApproveDevice"
+	AuthService_DeviceToken_FullMethodName      = "/auth.v1.AuthService/DeviceToken"
+	AuthService_Impersonate_FullMethodName      = "/auth.v1.AuthService/Impersonate"
+	AuthService_GetRole_FullMethodName          = "/auth.v1.AuthService/GetRole"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -33,6 +40,13 @@ type AuthServiceClient interface {
 	Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error)
 	Refresh(ctx context.Context, in *RefreshReq, opts ...grpc.CallOption) (*LoginResp, error)
 	Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutResp, error)
+	RequestLoginCode(ctx context.Context, in *RequestLoginCodeReq, opts ...grpc.CallOption) (*RequestLoginCodeResp, error)
+	VerifyLoginCode(ctx context.Context, in *VerifyLoginCodeReq, opts ...grpc.CallOption) (*LoginResp, error)
+	DeviceAuthorize(ctx context.Context, in *DeviceAuthorizeReq, opts ...grpc.CallOption) (*DeviceAuthorizeResp, error)
+	ApproveDevice(ctx context.Context, in *ApproveDeviceReq, opts ...grpc.CallOption) (*ApproveDeviceResp, error)
+	DeviceToken(ctx context.Context, in *DeviceTokenReq, opts ...grpc.CallOption) (*LoginResp, error)
+	Impersonate(ctx context.Context, in *ImpersonateReq, opts ...grpc.CallOption) (*ImpersonateResp, error)
+	GetRole(ctx context.Context, in *GetRoleReq, opts ...grpc.CallOption) (*GetRoleResp, error)
 }
 
 type authServiceClient struct {
@@ -83,6 +97,76 @@ func (c *authServiceClient) Logout(ctx context.Context, in *LogoutReq, opts ...g
 	return out, nil
 }
 
+func (c *authServiceClient) RequestLoginCode(ctx context.Context, in *RequestLoginCodeReq, opts ...grpc.CallOption) (*RequestLoginCodeResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestLoginCodeResp)
+	err := c.cc.Invoke(ctx, AuthService_RequestLoginCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyLoginCode(ctx context.Context, in *VerifyLoginCodeReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResp)
+	err := c.cc.Invoke(ctx, AuthService_VerifyLoginCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeviceAuthorize(ctx context.Context, in *DeviceAuthorizeReq, opts ...grpc.CallOption) (*DeviceAuthorizeResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeviceAuthorizeResp)
+	err := c.cc.Invoke(ctx, AuthService_DeviceAuthorize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ApproveDevice(ctx context.Context, in *ApproveDeviceReq, opts ...grpc.CallOption) (*ApproveDeviceResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveDeviceResp)
+	err := c.cc.Invoke(ctx, AuthService_ApproveDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeviceToken(ctx context.Context, in *DeviceTokenReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResp)
+	err := c.cc.Invoke(ctx, AuthService_DeviceToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) Impersonate(ctx context.Context, in *ImpersonateReq, opts ...grpc.CallOption) (*ImpersonateResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpersonateResp)
+	err := c.cc.Invoke(ctx, AuthService_Impersonate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetRole(ctx context.Context, in *GetRoleReq, opts ...grpc.CallOption) (*GetRoleResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoleResp)
+	err := c.cc.Invoke(ctx, AuthService_GetRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -91,6 +175,13 @@ type AuthServiceServer interface {
 	Login(context.Context, *LoginReq) (*LoginResp, error)
 	Refresh(context.Context, *RefreshReq) (*LoginResp, error)
 	Logout(context.Context, *LogoutReq) (*LogoutResp, error)
+	RequestLoginCode(context.Context, *RequestLoginCodeReq) (*RequestLoginCodeResp, error)
+	VerifyLoginCode(context.Context, *VerifyLoginCodeReq) (*LoginResp, error)
+	DeviceAuthorize(context.Context, *DeviceAuthorizeReq) (*DeviceAuthorizeResp, error)
+	ApproveDevice(context.Context, *ApproveDeviceReq) (*ApproveDeviceResp, error)
+	DeviceToken(context.Context, *DeviceTokenReq) (*LoginResp, error)
+	Impersonate(context.Context, *ImpersonateReq) (*ImpersonateResp, error)
+	GetRole(context.Context, *GetRoleReq) (*GetRoleResp, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -113,6 +204,27 @@ func (UnimplementedAuthServiceServer) Refresh(context.Context, *RefreshReq) (*Lo
 func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutReq) (*LogoutResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServiceServer) RequestLoginCode(context.Context, *RequestLoginCodeReq) (*RequestLoginCodeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestLoginCode not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyLoginCode(context.Context, *VerifyLoginCodeReq) (*LoginResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyLoginCode not implemented")
+}
+func (UnimplementedAuthServiceServer) DeviceAuthorize(context.Context, *DeviceAuthorizeReq) (*DeviceAuthorizeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceAuthorize not implemented")
+}
+func (UnimplementedAuthServiceServer) ApproveDevice(context.Context, *ApproveDeviceReq) (*ApproveDeviceResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDevice not implemented")
+}
+func (UnimplementedAuthServiceServer) DeviceToken(context.Context, *DeviceTokenReq) (*LoginResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeviceToken not implemented")
+}
+func (UnimplementedAuthServiceServer) Impersonate(context.Context, *ImpersonateReq) (*ImpersonateResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Impersonate not implemented")
+}
+func (UnimplementedAuthServiceServer) GetRole(context.Context, *GetRoleReq) (*GetRoleResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRole not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -206,6 +318,132 @@ func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_RequestLoginCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestLoginCodeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestLoginCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestLoginCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestLoginCode(ctx, req.(*RequestLoginCodeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyLoginCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyLoginCodeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyLoginCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyLoginCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyLoginCode(ctx, req.(*VerifyLoginCodeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeviceAuthorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceAuthorizeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeviceAuthorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeviceAuthorize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeviceAuthorize(ctx, req.(*DeviceAuthorizeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ApproveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ApproveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ApproveDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ApproveDevice(ctx, req.(*ApproveDeviceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeviceToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeviceTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeviceToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeviceToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeviceToken(ctx, req.(*DeviceTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Impersonate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Impersonate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Impersonate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Impersonate(ctx, req.(*ImpersonateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetRole(ctx, req.(*GetRoleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +467,34 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Logout",
 			Handler:    _AuthService_Logout_Handler,
 		},
+		{
+			MethodName: "RequestLoginCode",
+			Handler:    _AuthService_RequestLoginCode_Handler,
+		},
+		{
+			MethodName: "VerifyLoginCode",
+			Handler:    _AuthService_VerifyLoginCode_Handler,
+		},
+		{
+			MethodName: "DeviceAuthorize",
+			Handler:    _AuthService_DeviceAuthorize_Handler,
+		},
+		{
+			MethodName: "ApproveDevice",
+			Handler:    _AuthService_ApproveDevice_Handler,
+		},
+		{
+			MethodName: "DeviceToken",
+			Handler:    _AuthService_DeviceToken_Handler,
+		},
+		{
+			MethodName: "Impersonate",
+			Handler:    _AuthService_Impersonate_Handler,
+		},
+		{
+			MethodName: "GetRole",
+			Handler:    _AuthService_GetRole_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/auth/auth.proto",
@@ -0,0 +1,401 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/v1/event/auth_events.proto
+
+package event
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// auth.login.succeeded v1
+type AuthLoginSucceededEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthLoginSucceededEvent) Reset() {
+	*x = AuthLoginSucceededEvent{}
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthLoginSucceededEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthLoginSucceededEvent) ProtoMessage() {}
+
+func (x *AuthLoginSucceededEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthLoginSucceededEvent.ProtoReflect.Descriptor instead.
+func (*AuthLoginSucceededEvent) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_auth_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthLoginSucceededEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthLoginSucceededEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AuthLoginSucceededEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+// auth.login.failed v1
+type AuthLoginFailedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Identifier    string                 `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthLoginFailedEvent) Reset() {
+	*x = AuthLoginFailedEvent{}
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthLoginFailedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthLoginFailedEvent) ProtoMessage() {}
+
+func (x *AuthLoginFailedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthLoginFailedEvent.ProtoReflect.Descriptor instead.
+func (*AuthLoginFailedEvent) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_auth_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuthLoginFailedEvent) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *AuthLoginFailedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthLoginFailedEvent) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuthLoginFailedEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// auth.session.revoked v1
+type AuthSessionRevokedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthSessionRevokedEvent) Reset() {
+	*x = AuthSessionRevokedEvent{}
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthSessionRevokedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthSessionRevokedEvent) ProtoMessage() {}
+
+func (x *AuthSessionRevokedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthSessionRevokedEvent.ProtoReflect.Descriptor instead.
+func (*AuthSessionRevokedEvent) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_auth_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthSessionRevokedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthSessionRevokedEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AuthSessionRevokedEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// auth.refresh.reused v1
+type AuthRefreshReusedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Jti           string                 `protobuf:"bytes,3,opt,name=jti,proto3" json:"jti,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthRefreshReusedEvent) Reset() {
+	*x = AuthRefreshReusedEvent{}
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRefreshReusedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRefreshReusedEvent) ProtoMessage() {}
+
+func (x *AuthRefreshReusedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRefreshReusedEvent.ProtoReflect.Descriptor instead.
+func (*AuthRefreshReusedEvent) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_auth_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthRefreshReusedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthRefreshReusedEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AuthRefreshReusedEvent) GetJti() string {
+	if x != nil {
+		return x.Jti
+	}
+	return ""
+}
+
+// auth.password.changed v1
+type AuthPasswordChangedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthPasswordChangedEvent) Reset() {
+	*x = AuthPasswordChangedEvent{}
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthPasswordChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthPasswordChangedEvent) ProtoMessage() {}
+
+func (x *AuthPasswordChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_auth_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthPasswordChangedEvent.ProtoReflect.Descriptor instead.
+func (*AuthPasswordChangedEvent) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_auth_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuthPasswordChangedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+var File_api_v1_event_auth_events_proto protoreflect.FileDescriptor
+
+const file_api_v1_event_auth_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/v1/event/auth_events.proto\x12\bevent.v1\"i\n" +
+	"\x17AuthLoginSucceededEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\"\x7f\n" +
+	"\x14AuthLoginFailedEvent\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x01 \x01(\tR\n" +
+	"identifier\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"i\n" +
+	"\x17AuthSessionRevokedEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"b\n" +
+	"\x16AuthRefreshReusedEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x10\n" +
+	"\x03jti\x18\x03 \x01(\tR\x03jti\"3\n" +
+	"\x18AuthPasswordChangedEvent\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userIdB/Z-github.com/uwu-octane/antBackend/api/v1/eventb\x06proto3"
+
+var (
+	file_api_v1_event_auth_events_proto_rawDescOnce sync.Once
+	file_api_v1_event_auth_events_proto_rawDescData []byte
+)
+
+func file_api_v1_event_auth_events_proto_rawDescGZIP() []byte {
+	file_api_v1_event_auth_events_proto_rawDescOnce.Do(func() {
+		file_api_v1_event_auth_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_event_auth_events_proto_rawDesc), len(file_api_v1_event_auth_events_proto_rawDesc)))
+	})
+	return file_api_v1_event_auth_events_proto_rawDescData
+}
+
+var file_api_v1_event_auth_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_v1_event_auth_events_proto_goTypes = []any{
+	(*AuthLoginSucceededEvent)(nil),  // 0: event.v1.AuthLoginSucceededEvent
+	(*AuthLoginFailedEvent)(nil),     // 1: event.v1.AuthLoginFailedEvent
+	(*AuthSessionRevokedEvent)(nil),  // 2: event.v1.AuthSessionRevokedEvent
+	(*AuthRefreshReusedEvent)(nil),   // 3: event.v1.AuthRefreshReusedEvent
+	(*AuthPasswordChangedEvent)(nil), // 4: event.v1.AuthPasswordChangedEvent
+}
+var file_api_v1_event_auth_events_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_event_auth_events_proto_init() }
+func file_api_v1_event_auth_events_proto_init() {
+	if File_api_v1_event_auth_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_event_auth_events_proto_rawDesc), len(file_api_v1_event_auth_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_v1_event_auth_events_proto_goTypes,
+		DependencyIndexes: file_api_v1_event_auth_events_proto_depIdxs,
+		MessageInfos:      file_api_v1_event_auth_events_proto_msgTypes,
+	}.Build()
+	File_api_v1_event_auth_events_proto = out.File
+	file_api_v1_event_auth_events_proto_goTypes = nil
+	file_api_v1_event_auth_events_proto_depIdxs = nil
+}
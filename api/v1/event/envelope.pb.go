@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/v1/event/envelope.proto
+
+package event
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope mirrors common/eventbus/event.Envelope for the application/x-protobuf codec
+type Envelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventType     string                 `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventVersion  int32                  `protobuf:"varint,2,opt,name=event_version,json=eventVersion,proto3" json:"event_version,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Producer      string                 `protobuf:"bytes,5,opt,name=producer,proto3" json:"producer,omitempty"`
+	TraceId       string                 `protobuf:"bytes,6,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,7,opt,name=data,proto3" json:"data,omitempty"`                //payload encoded with the message registered for (event_type, event_version)
+	KeyId         string                 `protobuf:"bytes,8,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"` //key used for pii:"encrypt" payload fields, empty when the payload is plaintext
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_api_v1_event_envelope_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_event_envelope_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_api_v1_event_envelope_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Envelope) GetEventVersion() int32 {
+	if x != nil {
+		return x.EventVersion
+	}
+	return 0
+}
+
+func (x *Envelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetProducer() string {
+	if x != nil {
+		return x.Producer
+	}
+	return ""
+}
+
+func (x *Envelope) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *Envelope) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Envelope) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+var File_api_v1_event_envelope_proto protoreflect.FileDescriptor
+
+const file_api_v1_event_envelope_proto_rawDesc = "" +
+	"\n" +
+	"\x1bapi/v1/event/envelope.proto\x12\bevent.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x02\n" +
+	"\bEnvelope\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12#\n" +
+	"\revent_version\x18\x02 \x01(\x05R\feventVersion\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x1a\n" +
+	"\bproducer\x18\x05 \x01(\tR\bproducer\x12\x19\n" +
+	"\btrace_id\x18\x06 \x01(\tR\atraceId\x12\x12\n" +
+	"\x04data\x18\a \x01(\fR\x04data\x12\x15\n" +
+	"\x06key_id\x18\b \x01(\tR\x05keyIdB/Z-github.com/uwu-octane/antBackend/api/v1/eventb\x06proto3"
+
+var (
+	file_api_v1_event_envelope_proto_rawDescOnce sync.Once
+	file_api_v1_event_envelope_proto_rawDescData []byte
+)
+
+func file_api_v1_event_envelope_proto_rawDescGZIP() []byte {
+	file_api_v1_event_envelope_proto_rawDescOnce.Do(func() {
+		file_api_v1_event_envelope_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_event_envelope_proto_rawDesc), len(file_api_v1_event_envelope_proto_rawDesc)))
+	})
+	return file_api_v1_event_envelope_proto_rawDescData
+}
+
+var file_api_v1_event_envelope_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_v1_event_envelope_proto_goTypes = []any{
+	(*Envelope)(nil),              // 0: event.v1.Envelope
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_api_v1_event_envelope_proto_depIdxs = []int32{
+	1, // 0: event.v1.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_event_envelope_proto_init() }
+func file_api_v1_event_envelope_proto_init() {
+	if File_api_v1_event_envelope_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_event_envelope_proto_rawDesc), len(file_api_v1_event_envelope_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_v1_event_envelope_proto_goTypes,
+		DependencyIndexes: file_api_v1_event_envelope_proto_depIdxs,
+		MessageInfos:      file_api_v1_event_envelope_proto_msgTypes,
+	}.Build()
+	File_api_v1_event_envelope_proto = out.File
+	file_api_v1_event_envelope_proto_goTypes = nil
+	file_api_v1_event_envelope_proto_depIdxs = nil
+}
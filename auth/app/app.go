@@ -8,6 +8,7 @@ import (
 	"github.com/uwu-octane/antBackend/auth/internal/config"
 	"github.com/uwu-octane/antBackend/auth/internal/server"
 	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/common/requestid"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -36,6 +37,7 @@ func BuildAuthRpcServer(configFile string) (service.Service, func(), error) {
 			reflection.Register(grpcServer)
 		}
 	})
+	s.AddUnaryInterceptors(requestid.UnaryServerInterceptor)
 
 	if err := consul.RegisterService(c.ListenOn, c.Consul); err != nil {
 		log.Printf("consul register failed: %v", err)
@@ -43,6 +45,10 @@ func BuildAuthRpcServer(configFile string) (service.Service, func(), error) {
 	}
 
 	cleanup := func() {
+		// drains queued auth events, an async producer flushes before closing
+		if ctx.Events != nil {
+			_ = ctx.Events.Close()
+		}
 	}
 
 	return s, cleanup, nil
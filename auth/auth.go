@@ -10,6 +10,7 @@ import (
 	"github.com/uwu-octane/antBackend/auth/internal/server"
 	"github.com/uwu-octane/antBackend/auth/internal/svc"
 	"github.com/uwu-octane/antBackend/common/envloader"
+	"github.com/uwu-octane/antBackend/common/requestid"
 	"github.com/zeromicro/go-zero/core/conf"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -36,11 +37,15 @@ func main() {
 			reflection.Register(grpcServer)
 		}
 	})
+	s.AddUnaryInterceptors(requestid.UnaryServerInterceptor)
 
 	if err := consul.RegisterService(c.ListenOn, c.Consul); err != nil {
 		log.Fatal(err)
 	}
 	defer s.Stop()
+	if ctx.Events != nil {
+		defer ctx.Events.Close()
+	}
 
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
 	s.Start()
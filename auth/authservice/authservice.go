@@ -14,19 +14,38 @@ import (
 )
 
 type (
-	LoginReq   = auth.LoginReq
-	LoginResp  = auth.LoginResp
-	LogoutReq  = auth.LogoutReq
-	LogoutResp = auth.LogoutResp
-	PingReq    = auth.PingReq
-	PingResp   = auth.PingResp
-	RefreshReq = auth.RefreshReq
+	ApproveDeviceReq     = auth.ApproveDeviceReq
+	ApproveDeviceResp    = auth.ApproveDeviceResp
+	DeviceAuthorizeReq   = auth.DeviceAuthorizeReq
+	DeviceAuthorizeResp  = auth.DeviceAuthorizeResp
+	DeviceTokenReq       = auth.DeviceTokenReq
+	GetRoleReq           = auth.GetRoleReq
+	GetRoleResp          = auth.GetRoleResp
+	ImpersonateReq       = auth.ImpersonateReq
+	ImpersonateResp      = auth.ImpersonateResp
+	LoginReq             = auth.LoginReq
+	LoginResp            = auth.LoginResp
+	LogoutReq            = auth.LogoutReq
+	LogoutResp           = auth.LogoutResp
+	PingReq              = auth.PingReq
+	PingResp             = auth.PingResp
+	RefreshReq           = auth.RefreshReq
+	RequestLoginCodeReq  = auth.RequestLoginCodeReq
+	RequestLoginCodeResp = auth.RequestLoginCodeResp
+	VerifyLoginCodeReq   = auth.VerifyLoginCodeReq
 
 	AuthService interface {
 		Ping(ctx context.Context, in *PingReq, opts ...grpc.CallOption) (*PingResp, error)
 		Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error)
 		Refresh(ctx context.Context, in *RefreshReq, opts ...grpc.CallOption) (*LoginResp, error)
 		Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*LogoutResp, error)
+		RequestLoginCode(ctx context.Context, in *RequestLoginCodeReq, opts ...grpc.CallOption) (*RequestLoginCodeResp, error)
+		VerifyLoginCode(ctx context.Context, in *VerifyLoginCodeReq, opts ...grpc.CallOption) (*LoginResp, error)
+		DeviceAuthorize(ctx context.Context, in *DeviceAuthorizeReq, opts ...grpc.CallOption) (*DeviceAuthorizeResp, error)
+		ApproveDevice(ctx context.Context, in *ApproveDeviceReq, opts ...grpc.CallOption) (*ApproveDeviceResp, error)
+		DeviceToken(ctx context.Context, in *DeviceTokenReq, opts ...grpc.CallOption) (*LoginResp, error)
+		Impersonate(ctx context.Context, in *ImpersonateReq, opts ...grpc.CallOption) (*ImpersonateResp, error)
+		GetRole(ctx context.Context, in *GetRoleReq, opts ...grpc.CallOption) (*GetRoleResp, error)
 	}
 
 	defaultAuthService struct {
@@ -59,3 +78,38 @@ func (m *defaultAuthService) Logout(ctx context.Context, in *LogoutReq, opts ...
 	client := auth.NewAuthServiceClient(m.cli.Conn())
 	return client.Logout(ctx, in, opts...)
 }
+
+func (m *defaultAuthService) RequestLoginCode(ctx context.Context, in *RequestLoginCodeReq, opts ...grpc.CallOption) (*RequestLoginCodeResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.RequestLoginCode(ctx, in, opts...)
+}
+
+func (m *defaultAuthService) VerifyLoginCode(ctx context.Context, in *VerifyLoginCodeReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.VerifyLoginCode(ctx, in, opts...)
+}
+
+func (m *defaultAuthService) DeviceAuthorize(ctx context.Context, in *DeviceAuthorizeReq, opts ...grpc.CallOption) (*DeviceAuthorizeResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.DeviceAuthorize(ctx, in, opts...)
+}
+
+func (m *defaultAuthService) ApproveDevice(ctx context.Context, in *ApproveDeviceReq, opts ...grpc.CallOption) (*ApproveDeviceResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.ApproveDevice(ctx, in, opts...)
+}
+
+func (m *defaultAuthService) DeviceToken(ctx context.Context, in *DeviceTokenReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.DeviceToken(ctx, in, opts...)
+}
+
+func (m *defaultAuthService) Impersonate(ctx context.Context, in *ImpersonateReq, opts ...grpc.CallOption) (*ImpersonateResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.Impersonate(ctx, in, opts...)
+}
+
+func (m *defaultAuthService) GetRole(ctx context.Context, in *GetRoleReq, opts ...grpc.CallOption) (*GetRoleResp, error) {
+	client := auth.NewAuthServiceClient(m.cli.Conn())
+	return client.GetRole(ctx, in, opts...)
+}
@@ -19,6 +19,15 @@ type Config struct {
 	AuthRedis        redis.RedisKeyConf
 	AuthDatabase     AuthDatabase
 	AuthReadStrategy AuthReadStrategy
+	Mailer           MailerConfig        `json:",optional"`
+	LoginCode        LoginCodeConfig     `json:",optional"`
+	DeviceAuth       DeviceAuthConfig    `json:",optional"`
+	Impersonation    ImpersonationConfig `json:",optional"`
+	// security events are published only when Kafka.Brokers is set
+	Kafka             KafkaConf         `json:",optional"`
+	KafkaAuthProducer KafkaProducerConf `json:",optional"`
+	AuthEvents        AuthEventsConfig  `json:",optional"`
+	EventPII          EventPIIConfig    `json:",optional"`
 }
 
 type AuthDatabase struct {
@@ -31,3 +40,86 @@ type AuthReadStrategy struct {
 	FromReplica                 bool
 	FallbackToMasterOnReadError bool
 }
+
+type MailerConfig struct {
+	Driver string `json:",default=file,options=file|smtp"`
+	From   string `json:",default=no-reply@localhost"`
+	// Dir is where the file driver drops .eml files
+	Dir  string     `json:",default=./tmp/mail"`
+	SMTP SMTPConfig `json:",optional"`
+}
+
+type SMTPConfig struct {
+	Host     string `json:",optional"`
+	Port     int    `json:",default=587"`
+	Username string `json:",optional"`
+	Password string `json:",optional"`
+}
+
+// LoginCodeConfig controls passwordless login (email code + magic link)
+type LoginCodeConfig struct {
+	CodeTTLSeconds        int64 `json:",default=600"`
+	ResendIntervalSeconds int   `json:",default=60"`
+	MaxAttempts           int   `json:",default=5"`
+	CodeLength            int   `json:",default=6"`
+	// LinkURL is the frontend page the magic link points to, the token is appended as ?token=
+	LinkURL string `json:",optional"`
+}
+
+// DeviceAuthConfig controls the RFC 8628 device authorization grant
+type DeviceAuthConfig struct {
+	ExpiresInSeconds int64 `json:",default=600"`
+	IntervalSeconds  int64 `json:",default=5"`
+	// VerificationURI is the page where the user enters the user_code
+	VerificationURI string `json:",optional"`
+}
+
+// ImpersonationConfig controls admin impersonation tokens
+type ImpersonationConfig struct {
+	// TTLSeconds is the lifetime of an impersonation access token, there is no refresh
+	TTLSeconds int64  `json:",default=900"`
+	AdminRole  string `json:",default=admin"`
+}
+
+type KafkaConf struct {
+	Env     string   `json:",default=dev"`
+	Brokers []string `json:",optional"`
+}
+
+type KafkaProducerSASL struct {
+	Enable    bool   `json:",optional"`
+	Mechanism string `json:",default=plain"` // plain / scram-sha256 / scram-sha512
+	Username  string `json:",optional"`
+	Password  string `json:",optional"`
+}
+
+type KafkaProducerTLS struct {
+	Enable bool `json:",optional"`
+}
+
+type KafkaProducerConf struct {
+	Acks             string            `json:",default=all"` // all/local
+	Idempotent       bool              `json:",optional"`
+	RetryMax         int               `json:",default=3"`
+	Compression      string            `json:",default=none"` // none/snappy/lz4/zstd/gzip
+	FlushBytes       int               `json:",optional"`
+	FlushMessages    int               `json:",optional"`
+	FlushFrequencyMs int               `json:",optional"`
+	MaxMessageBytes  int               `json:",default=1048576"`
+	Async            bool              `json:",optional"` // sarama AsyncProducer batching by the Flush* settings, failures are only logged
+	SASL             KafkaProducerSASL `json:",optional"`
+	TLS              KafkaProducerTLS  `json:",optional"`
+}
+
+// EventPIIConfig points to the key file used to encrypt pii:"encrypt" event fields (see codec.NewFileKeyProvider),
+// fields are published in plaintext when KeyFile is empty
+type EventPIIConfig struct {
+	KeyFile string `json:",optional"`
+}
+
+// AuthEventsConfig controls the in-process queue in front of the auth events publisher
+type AuthEventsConfig struct {
+	// Buffer is how many events may wait for Kafka, further events are dropped
+	Buffer           int `json:",default=1024"`
+	PublishTimeoutMs int `json:",default=2000"`
+}
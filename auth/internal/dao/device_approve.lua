@@ -0,0 +1,19 @@
+-- KEYS[1] = deviceKey (auth:device:<sha256(device_code)>, HASH)
+-- ARGV[1] = status to set ("approved" | "denied")
+-- ARGV[2] = uid of the approving user
+
+-- return:
+-- 0: device request not exists (expired)
+-- 2: already approved or denied
+-- 1: status updated
+
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    return 0
+end
+
+if redis.call("HGET", KEYS[1], "status") ~= "pending" then
+    return 2
+end
+
+redis.call("HSET", KEYS[1], "status", ARGV[1], "uid", ARGV[2])
+return 1
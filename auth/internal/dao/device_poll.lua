@@ -0,0 +1,37 @@
+-- KEYS[1] = deviceKey (auth:device:<sha256(device_code)>, HASH)
+-- KEYS[2] = userCodeKey (auth:device_user_code:<user_code>)
+-- ARGV[1] = now (unix seconds)
+-- ARGV[2] = slow down step in seconds
+
+-- return {code, uid}:
+-- 0: expired_token
+-- 3: slow_down, interval increased
+-- 4: authorization_pending
+-- 2: access_denied, request removed
+-- 1: approved, request removed (device_code is single use)
+
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    return {0, ""}
+end
+
+local now = tonumber(ARGV[1])
+local interval = tonumber(redis.call("HGET", KEYS[1], "interval"))
+local last = redis.call("HGET", KEYS[1], "last_poll")
+redis.call("HSET", KEYS[1], "last_poll", now)
+
+if last and now - tonumber(last) < interval then
+    redis.call("HSET", KEYS[1], "interval", interval + tonumber(ARGV[2]))
+    return {3, ""}
+end
+
+local status = redis.call("HGET", KEYS[1], "status")
+if status == "pending" then
+    return {4, ""}
+end
+
+local uid = redis.call("HGET", KEYS[1], "uid") or ""
+redis.call("DEL", KEYS[1], KEYS[2])
+if status == "approved" then
+    return {1, uid}
+end
+return {2, ""}
@@ -0,0 +1,25 @@
+-- KEYS[1] = userCodeKey (auth:device_user_code:<user_code>)
+-- KEYS[2] = deviceKey (auth:device:<sha256(device_code)>, HASH)
+-- ARGV[1] = device hash
+-- ARGV[2] = user_code
+-- ARGV[3] = client_id
+-- ARGV[4] = scope
+-- ARGV[5] = polling interval in seconds
+-- ARGV[6] = ttl in seconds
+
+-- return:
+-- 0: user_code already taken, nothing written
+-- 1: request saved
+
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "EX", ARGV[6]) == false then
+    return 0
+end
+
+redis.call("HSET", KEYS[2],
+    "user_code", ARGV[2],
+    "client_id", ARGV[3],
+    "scope", ARGV[4],
+    "status", "pending",
+    "interval", ARGV[5])
+redis.call("EXPIRE", KEYS[2], ARGV[6])
+return 1
@@ -0,0 +1,164 @@
+package dao
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+//go:embed device_approve.lua
+var luaDeviceApprove string
+
+//go:embed device_poll.lua
+var luaDevicePoll string
+
+//go:embed device_save.lua
+var luaDeviceSave string
+
+const (
+	DeviceStatusPending  = "pending"
+	DeviceStatusApproved = "approved"
+	DeviceStatusDenied   = "denied"
+
+	// deviceSlowDownStep is added to the interval on every slow_down (RFC 8628 section 3.5)
+	deviceSlowDownStep = 5
+)
+
+type DeviceApproveCode int
+
+const (
+	DeviceApproveNotFound DeviceApproveCode = 0
+	DeviceApproveDecided  DeviceApproveCode = 2
+	DeviceApproveOK       DeviceApproveCode = 1
+)
+
+type DevicePollCode int
+
+const (
+	DevicePollExpired  DevicePollCode = 0
+	DevicePollSlowDown DevicePollCode = 3
+	DevicePollPending  DevicePollCode = 4
+	DevicePollDenied   DevicePollCode = 2
+	DevicePollApproved DevicePollCode = 1
+)
+
+type DevicePollResult struct {
+	Code DevicePollCode
+	Uid  string
+}
+
+type DeviceRequest struct {
+	DeviceHash string
+	ClientID   string
+	Scope      string
+	Status     string
+}
+
+// SaveDeviceRequest stores a pending device authorization under both the device_code hash and the user_code.
+// It returns false when the user_code is already taken so the caller can draw a new one.
+func SaveDeviceRequest(
+	ctx context.Context,
+	r *redis.Redis,
+	keyPrefix string,
+	deviceHash string,
+	userCode string,
+	clientID string,
+	scope string,
+	interval int64,
+	ttlSeconds int,
+) (bool, error) {
+	userCodeKey := util.RedisKey(keyPrefix, util.RedisKeyTypeDeviceUserCode, userCode)
+	deviceKey := util.RedisKey(keyPrefix, util.RedisKeyTypeDevice, deviceHash)
+
+	// both keys are written in one script so a user_code never points at a missing or non-expiring request
+	reply, err := r.EvalCtx(ctx, luaDeviceSave, []string{userCodeKey, deviceKey}, []any{
+		deviceHash, userCode, clientID, scope, strconv.FormatInt(interval, 10), strconv.Itoa(ttlSeconds),
+	})
+	if err != nil {
+		logx.Errorf("device save failed: %v", err)
+		return false, fmt.Errorf("device: save failed: %w", err)
+	}
+	code, ok := reply.(int64)
+	if !ok {
+		return false, fmt.Errorf("device save: non-integer reply from lua: %v", reply)
+	}
+	return code == 1, nil
+}
+
+// FindDeviceByUserCode resolves the pending request a user_code points to, nil when expired
+func FindDeviceByUserCode(ctx context.Context, r *redis.Redis, keyPrefix string, userCode string) (*DeviceRequest, error) {
+	deviceHash, err := r.GetCtx(ctx, util.RedisKey(keyPrefix, util.RedisKeyTypeDeviceUserCode, userCode))
+	if err != nil {
+		return nil, err
+	}
+	if deviceHash == "" {
+		return nil, nil
+	}
+	fields, err := r.HgetallCtx(ctx, util.RedisKey(keyPrefix, util.RedisKeyTypeDevice, deviceHash))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return &DeviceRequest{
+		DeviceHash: deviceHash,
+		ClientID:   fields["client_id"],
+		Scope:      fields["scope"],
+		Status:     fields["status"],
+	}, nil
+}
+
+// DecideDevice approves or denies a pending request, only the first decision wins
+func DecideDevice(ctx context.Context, r *redis.Redis, keyPrefix string, deviceHash string, status string, uid string) (DeviceApproveCode, error) {
+	deviceKey := util.RedisKey(keyPrefix, util.RedisKeyTypeDevice, deviceHash)
+	reply, err := r.EvalCtx(ctx, luaDeviceApprove, []string{deviceKey}, []any{status, uid})
+	if err != nil {
+		logx.Errorf("device approve failed: %v", err)
+		return 0, err
+	}
+	code, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("device approve: non-integer reply from lua: %v", reply)
+	}
+	return DeviceApproveCode(code), nil
+}
+
+// PollDevice is called by the device with its device_code, it enforces the polling interval
+// and consumes the request once it has been approved or denied.
+func PollDevice(ctx context.Context, r *redis.Redis, keyPrefix string, deviceHash string, userCode string) (DevicePollResult, error) {
+	deviceKey := util.RedisKey(keyPrefix, util.RedisKeyTypeDevice, deviceHash)
+	userCodeKey := util.RedisKey(keyPrefix, util.RedisKeyTypeDeviceUserCode, userCode)
+
+	reply, err := r.EvalCtx(ctx, luaDevicePoll, []string{deviceKey, userCodeKey},
+		[]any{strconv.FormatInt(time.Now().Unix(), 10), strconv.Itoa(deviceSlowDownStep)})
+	if err != nil {
+		logx.Errorf("device poll failed: %v", err)
+		return DevicePollResult{}, err
+	}
+	vals, ok := reply.([]any)
+	if !ok || len(vals) != 2 {
+		return DevicePollResult{}, fmt.Errorf("device poll: unexpected reply from lua: %v", reply)
+	}
+	code, ok := vals[0].(int64)
+	if !ok {
+		return DevicePollResult{}, fmt.Errorf("device poll: non-integer reply from lua: %v", vals[0])
+	}
+	uid, _ := vals[1].(string)
+	return DevicePollResult{Code: DevicePollCode(code), Uid: uid}, nil
+}
+
+// DeviceUserCode reads the user_code stored for a device_code hash
+func DeviceUserCode(ctx context.Context, r *redis.Redis, keyPrefix string, deviceHash string) (string, string, error) {
+	fields, err := r.HgetallCtx(ctx, util.RedisKey(keyPrefix, util.RedisKeyTypeDevice, deviceHash))
+	if err != nil {
+		return "", "", err
+	}
+	return fields["user_code"], fields["client_id"], nil
+}
@@ -0,0 +1,18 @@
+-- KEYS[1] = codeKey (auth:login_code:<email>, HASH code_hash/link_hash/uid)
+-- KEYS[2] = attemptsKey (auth:login_code_attempts:<email>)
+-- KEYS[3] = linkKey (auth:login_link:<sha256(link token)>)
+-- ARGV[1] = code hash
+-- ARGV[2] = link hash
+-- ARGV[3] = uid
+-- ARGV[4] = email
+-- ARGV[5] = ttl in seconds
+
+-- a new code replaces the previous one and resets the attempt budget
+redis.call("DEL", KEYS[1], KEYS[2])
+redis.call("HSET", KEYS[1],
+    "code_hash", ARGV[1],
+    "link_hash", ARGV[2],
+    "uid", ARGV[3])
+redis.call("EXPIRE", KEYS[1], ARGV[5])
+redis.call("SET", KEYS[3], ARGV[4], "EX", ARGV[5])
+return 1
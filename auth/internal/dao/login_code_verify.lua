@@ -0,0 +1,34 @@
+-- KEYS[1] = codeKey (auth:login_code:<email>, HASH code_hash/link_hash/uid)
+-- KEYS[2] = attemptsKey (auth:login_code_attempts:<email>)
+-- ARGV[1] = field to compare ("code_hash" | "link_hash")
+-- ARGV[2] = presented hash
+-- ARGV[3] = maxAttempts (number)
+-- ARGV[4] = ttlSeconds for the attempts counter (number)
+
+-- return {code, uid}:
+-- 0: no pending code (expired or already used)
+-- -1: hash mismatch
+-- 2: too many attempts, the pending code is burned
+-- 1: verified, the pending code is consumed (single use)
+
+local stored = redis.call("HGET", KEYS[1], ARGV[1])
+if not stored then
+    return {0, ""}
+end
+
+local attempts = redis.call("INCR", KEYS[2])
+if attempts == 1 then
+    redis.call("EXPIRE", KEYS[2], tonumber(ARGV[4]))
+end
+
+if stored ~= ARGV[2] then
+    if attempts >= tonumber(ARGV[3]) then
+        redis.call("DEL", KEYS[1], KEYS[2])
+        return {2, ""}
+    end
+    return {-1, ""}
+end
+
+local uid = redis.call("HGET", KEYS[1], "uid")
+redis.call("DEL", KEYS[1], KEYS[2])
+return {1, uid}
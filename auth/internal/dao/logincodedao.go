@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+//go:embed login_code_save.lua
+var luaLoginCodeSave string
+
+//go:embed login_code_verify.lua
+var luaLoginCodeVerify string
+
+type LoginCodeField string
+
+const (
+	LoginCodeFieldCode LoginCodeField = "code_hash"
+	LoginCodeFieldLink LoginCodeField = "link_hash"
+)
+
+type LoginCodeVerifyCode int
+
+const (
+	LoginCodeNotFound        LoginCodeVerifyCode = 0
+	LoginCodeMismatch        LoginCodeVerifyCode = -1
+	LoginCodeTooManyAttempts LoginCodeVerifyCode = 2
+	LoginCodeOK              LoginCodeVerifyCode = 1
+)
+
+type LoginCodeVerifyResult struct {
+	Code LoginCodeVerifyCode
+	Uid  string
+}
+
+// SaveLoginCode stores the pending code/link hashes for an email, replacing any previous one
+func SaveLoginCode(
+	ctx context.Context,
+	r *redis.Redis,
+	keyPrefix string,
+	email string,
+	uid string,
+	codeHash string,
+	linkHash string,
+	ttlSeconds int,
+) error {
+	codeKey := util.RedisKey(keyPrefix, util.RedisKeyTypeLoginCode, email)
+	attemptsKey := util.RedisKey(keyPrefix, util.RedisKeyTypeLoginCodeAttempts, email)
+	linkKey := util.RedisKey(keyPrefix, util.RedisKeyTypeLoginLink, linkHash)
+
+	// one script, so a failed save never leaves a code without a ttl or a link pointing at an old code.
+	//* the link key maps link hash -> email, so a magic link alone can locate the pending code
+	if _, err := r.EvalCtx(ctx, luaLoginCodeSave, []string{codeKey, attemptsKey, linkKey},
+		[]any{codeHash, linkHash, uid, email, strconv.Itoa(ttlSeconds)}); err != nil {
+		return fmt.Errorf("login code: save failed: %w", err)
+	}
+	return nil
+}
+
+// ResolveLoginLink maps a magic link hash back to its email, consuming the link index
+func ResolveLoginLink(ctx context.Context, r *redis.Redis, keyPrefix string, linkHash string) (string, error) {
+	return r.GetDelCtx(ctx, util.RedisKey(keyPrefix, util.RedisKeyTypeLoginLink, linkHash))
+}
+
+// VerifyLoginCode atomically checks and consumes a pending login code
+func VerifyLoginCode(
+	ctx context.Context,
+	r *redis.Redis,
+	keyPrefix string,
+	email string,
+	field LoginCodeField,
+	hash string,
+	maxAttempts int,
+	ttlSeconds int,
+) (LoginCodeVerifyResult, error) {
+	codeKey := util.RedisKey(keyPrefix, util.RedisKeyTypeLoginCode, email)
+	attemptsKey := util.RedisKey(keyPrefix, util.RedisKeyTypeLoginCodeAttempts, email)
+
+	reply, err := r.EvalCtx(ctx, luaLoginCodeVerify, []string{codeKey, attemptsKey},
+		[]any{string(field), hash, fmt.Sprintf("%d", maxAttempts), fmt.Sprintf("%d", ttlSeconds)})
+	if err != nil {
+		logx.Errorf("login code verify failed: %v", err)
+		return LoginCodeVerifyResult{}, err
+	}
+
+	vals, ok := reply.([]any)
+	if !ok || len(vals) != 2 {
+		return LoginCodeVerifyResult{}, fmt.Errorf("login code verify: unexpected reply from lua: %v", reply)
+	}
+	code, ok := vals[0].(int64)
+	if !ok {
+		return LoginCodeVerifyResult{}, fmt.Errorf("login code verify: non-integer reply from lua: %v", vals[0])
+	}
+	uid, _ := vals[1].(string)
+	return LoginCodeVerifyResult{Code: LoginCodeVerifyCode(code), Uid: uid}, nil
+}
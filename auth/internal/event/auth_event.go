@@ -0,0 +1,85 @@
+package event
+
+import (
+	"context"
+
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/zeromicro/go-zero/core/trace"
+)
+
+func NewLoginSucceededEvent(ctx context.Context, userID, sessionID, method string) *eventbus.Envelope[eventbus.AuthLoginSucceededEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeAuthLoginSucceeded,
+		1,
+		Producer,
+		trace.TraceIDFromContext(ctx),
+		eventbus.AuthLoginSucceededEvent{
+			UserID:    userID,
+			SessionID: sessionID,
+			Method:    method,
+		},
+	)
+}
+
+func NewLoginFailedEvent(ctx context.Context, identifier, userID, method, reason string) *eventbus.Envelope[eventbus.AuthLoginFailedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeAuthLoginFailed,
+		1,
+		Producer,
+		trace.TraceIDFromContext(ctx),
+		eventbus.AuthLoginFailedEvent{
+			Identifier: identifier,
+			UserID:     userID,
+			Method:     method,
+			Reason:     reason,
+		},
+	)
+}
+
+func NewSessionRevokedEvent(ctx context.Context, userID, sessionID, reason string) *eventbus.Envelope[eventbus.AuthSessionRevokedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeAuthSessionRevoked,
+		1,
+		Producer,
+		trace.TraceIDFromContext(ctx),
+		eventbus.AuthSessionRevokedEvent{
+			UserID:    userID,
+			SessionID: sessionID,
+			Reason:    reason,
+		},
+	)
+}
+
+func NewRefreshReusedEvent(ctx context.Context, userID, sessionID, jti string) *eventbus.Envelope[eventbus.AuthRefreshReusedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeAuthRefreshReused,
+		1,
+		Producer,
+		trace.TraceIDFromContext(ctx),
+		eventbus.AuthRefreshReusedEvent{
+			UserID:    userID,
+			SessionID: sessionID,
+			Jti:       jti,
+		},
+	)
+}
+
+// NewPasswordChangedEvent builds auth.password.changed. The type is part of the published catalog,
+// but auth has no password change flow yet, so nothing sends it.
+func NewPasswordChangedEvent(ctx context.Context, userID string) *eventbus.Envelope[eventbus.AuthPasswordChangedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeAuthPasswordChanged,
+		1,
+		Producer,
+		trace.TraceIDFromContext(ctx),
+		eventbus.AuthPasswordChangedEvent{UserID: userID},
+	)
+}
+
+// KeyFor picks the partition key, events of one user stay ordered
+func KeyFor(userID, identifier string) string {
+	if userID != "" {
+		return userID
+	}
+	return identifier
+}
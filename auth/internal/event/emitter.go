@@ -0,0 +1,112 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const Producer = "auth.rpc"
+
+type message struct {
+	eventType string
+	key       []byte
+	body      []byte
+	headers   map[string]string
+}
+
+// Emitter publishes auth events from a background goroutine.
+// Emit never blocks and never fails the caller: when the queue is full the event is dropped and logged.
+type Emitter struct {
+	pub     publisher.Publisher
+	topic   string
+	timeout time.Duration
+	queue   chan message
+	dropped atomic.Int64
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func NewEmitter(pub publisher.Publisher, topic string, buffer int, timeout time.Duration) *Emitter {
+	if buffer <= 0 {
+		buffer = 1
+	}
+	e := &Emitter{
+		pub:     pub,
+		topic:   topic,
+		timeout: timeout,
+		queue:   make(chan message, buffer),
+		done:    make(chan struct{}),
+	}
+	go e.loop()
+	return e
+}
+
+// Emit queues an envelope for the auth events topic, a nil Emitter is a no-op
+func Emit[T any](ctx context.Context, e *Emitter, env *eventbus.Envelope[T], key string) {
+	if e == nil || env == nil {
+		return
+	}
+	body, err := codec.MarshalEnvelope(env)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("auth event: marshal %s failed: %v", env.EventType, err)
+		return
+	}
+	select {
+	// trace context is captured here, the worker publishes outside the request
+	case e.queue <- message{eventType: env.EventType, key: []byte(key), body: body, headers: tracing.Headers(ctx)}:
+	default:
+		n := e.dropped.Add(1)
+		logx.WithContext(ctx).Errorf("auth event: queue full, dropped %s (dropped total=%d)", env.EventType, n)
+	}
+}
+
+// Dropped returns how many events were discarded because the queue was full
+func (e *Emitter) Dropped() int64 {
+	return e.dropped.Load()
+}
+
+func (e *Emitter) loop() {
+	defer close(e.done)
+	async, _ := e.pub.(publisher.AsyncPublisher)
+	for msg := range e.queue {
+		opts := &publisher.PublishOptions{PartitionKey: msg.key, Headers: msg.headers}
+		ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+		var err error
+		if async != nil {
+			// the async producer logs delivery failures itself, only a rejected enqueue is reported here
+			d := async.PublishAsync(ctx, e.topic, msg.body, opts)
+			select {
+			case <-d.Done():
+				err = d.Err()
+			default:
+			}
+		} else {
+			err = e.pub.Publish(ctx, e.topic, msg.body, opts)
+		}
+		cancel()
+		if err != nil {
+			logx.Errorw("auth event: publish failed",
+				logx.Field("event_type", msg.eventType), logx.Field("topic", e.topic), logx.Field("error", err))
+		}
+	}
+}
+
+// Close drains the queue and closes the underlying publisher
+func (e *Emitter) Close() error {
+	var err error
+	e.closeOnce.Do(func() {
+		close(e.queue)
+		<-e.done
+		err = e.pub.Close()
+	})
+	return err
+}
@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ApproveDeviceLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewApproveDeviceLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ApproveDeviceLogic {
+	return &ApproveDeviceLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ApproveDevice is called by a signed-in user who typed the user_code shown on the device.
+// The caller (gateway) is responsible for authenticating user_id.
+func (l *ApproveDeviceLogic) ApproveDevice(in *auth.ApproveDeviceReq) (*auth.ApproveDeviceResp, error) {
+	userCode := util.NormalizeUserCode(in.GetUserCode())
+	if userCode == "" || in.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_code and user_id are required")
+	}
+
+	req, err := dao.FindDeviceByUserCode(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, userCode)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, status.Error(codes.NotFound, "invalid or expired user code")
+	}
+
+	decision := dao.DeviceStatusApproved
+	if in.GetDeny() {
+		decision = dao.DeviceStatusDenied
+	}
+	code, err := dao.DecideDevice(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, req.DeviceHash, decision, in.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	switch code {
+	case dao.DeviceApproveOK:
+	case dao.DeviceApproveNotFound:
+		return nil, status.Error(codes.NotFound, "invalid or expired user code")
+	case dao.DeviceApproveDecided:
+		return nil, status.Error(codes.FailedPrecondition, "device request already handled")
+	default:
+		return nil, status.Error(codes.Internal, "unknown device approve state")
+	}
+
+	l.Infof("device request %s uid=%s client_id=%s", decision, in.GetUserId(), req.ClientID)
+	return &auth.ApproveDeviceResp{Ok: true, ClientId: req.ClientID, Scope: req.Scope}, nil
+}
@@ -0,0 +1,82 @@
+package logic
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deviceUserCodeRetries bounds how often a colliding user_code is redrawn
+const deviceUserCodeRetries = 3
+
+type DeviceAuthorizeLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeviceAuthorizeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeviceAuthorizeLogic {
+	return &DeviceAuthorizeLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// DeviceAuthorize starts the device authorization grant (RFC 8628 section 3.1).
+// Only the sha256 of the device_code is stored, the code itself is returned once to the device.
+func (l *DeviceAuthorizeLogic) DeviceAuthorize(in *auth.DeviceAuthorizeReq) (*auth.DeviceAuthorizeResp, error) {
+	clientID := strings.TrimSpace(in.GetClientId())
+	if clientID == "" {
+		return nil, status.Error(codes.InvalidArgument, "client_id is required")
+	}
+
+	cfg := l.svcCtx.Config.DeviceAuth
+	deviceCode, err := util.RandomToken()
+	if err != nil {
+		return nil, err
+	}
+	deviceHash := util.HashSecret(deviceCode)
+
+	var userCode string
+	for i := 0; i < deviceUserCodeRetries; i++ {
+		code, err := util.RandomUserCode()
+		if err != nil {
+			return nil, err
+		}
+		ok, err := dao.SaveDeviceRequest(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, deviceHash, code,
+			clientID, strings.TrimSpace(in.GetScope()), cfg.IntervalSeconds, int(cfg.ExpiresInSeconds))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			userCode = code
+			break
+		}
+	}
+	if userCode == "" {
+		l.Errorf("device authorize: no free user_code after %d tries", deviceUserCodeRetries)
+		return nil, status.Error(codes.Unavailable, "try again later")
+	}
+
+	resp := &auth.DeviceAuthorizeResp{
+		DeviceCode:      deviceCode,
+		UserCode:        userCode,
+		VerificationUri: cfg.VerificationURI,
+		ExpiresIn:       cfg.ExpiresInSeconds,
+		Interval:        cfg.IntervalSeconds,
+	}
+	if cfg.VerificationURI != "" {
+		resp.VerificationUriComplete = cfg.VerificationURI + "?user_code=" + url.QueryEscape(userCode)
+	}
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// status messages follow the error codes of RFC 8628 section 3.5 so clients can switch on them
+const (
+	DeviceErrAuthorizationPending = "authorization_pending"
+	DeviceErrSlowDown             = "slow_down"
+	DeviceErrAccessDenied         = "access_denied"
+	DeviceErrExpiredToken         = "expired_token"
+)
+
+type DeviceTokenLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeviceTokenLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeviceTokenLogic {
+	return &DeviceTokenLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// DeviceToken is polled by the device until the user approved or denied the request
+func (l *DeviceTokenLogic) DeviceToken(in *auth.DeviceTokenReq) (*auth.LoginResp, error) {
+	deviceCode := strings.TrimSpace(in.GetDeviceCode())
+	if deviceCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "device_code is required")
+	}
+	deviceHash := util.HashSecret(deviceCode)
+
+	userCode, clientID, err := dao.DeviceUserCode(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, deviceHash)
+	if err != nil {
+		return nil, err
+	}
+	if userCode == "" {
+		return nil, status.Error(codes.NotFound, DeviceErrExpiredToken)
+	}
+	if in.GetClientId() != "" && in.GetClientId() != clientID {
+		return nil, status.Error(codes.InvalidArgument, "client_id does not match device_code")
+	}
+
+	res, err := dao.PollDevice(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, deviceHash, userCode)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.Code {
+	case dao.DevicePollApproved:
+	case dao.DevicePollPending:
+		return nil, status.Error(codes.FailedPrecondition, DeviceErrAuthorizationPending)
+	case dao.DevicePollSlowDown:
+		return nil, status.Error(codes.ResourceExhausted, DeviceErrSlowDown)
+	case dao.DevicePollDenied:
+		return nil, status.Error(codes.PermissionDenied, DeviceErrAccessDenied)
+	case dao.DevicePollExpired:
+		return nil, status.Error(codes.NotFound, DeviceErrExpiredToken)
+	default:
+		return nil, status.Error(codes.Internal, "unknown device poll state")
+	}
+
+	if res.Uid == "" {
+		return nil, status.Error(codes.Internal, "approved device request without user")
+	}
+
+	l.Infof("device token issued uid=%s client_id=%s", res.Uid, clientID)
+	return issueSessionFor(l.ctx, l.svcCtx, res.Uid, eventbus.AuthMethodDevice)
+}
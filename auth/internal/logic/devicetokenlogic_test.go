@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/config"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeviceToken_ApproveFlow(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	// interval 0 so consecutive polls in the test are never slowed down
+	svcCtx.Config.DeviceAuth = config.DeviceAuthConfig{ExpiresInSeconds: 600, IntervalSeconds: 0, VerificationURI: "https://example.com/device"}
+	ctx := context.Background()
+
+	authz, err := NewDeviceAuthorizeLogic(ctx, svcCtx).DeviceAuthorize(&auth.DeviceAuthorizeReq{ClientId: "cli"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, authz.DeviceCode)
+	assert.Len(t, authz.UserCode, 9)
+	assert.Equal(t, "https://example.com/device?user_code="+authz.UserCode, authz.VerificationUriComplete)
+
+	_, err = NewDeviceTokenLogic(ctx, svcCtx).DeviceToken(&auth.DeviceTokenReq{DeviceCode: authz.DeviceCode})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, DeviceErrAuthorizationPending, status.Convert(err).Message())
+
+	// user input is normalized before lookup
+	approved, err := NewApproveDeviceLogic(ctx, svcCtx).ApproveDevice(&auth.ApproveDeviceReq{
+		UserCode: " " + authz.UserCode[:4] + authz.UserCode[5:] + " ",
+		UserId:   "user-123",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cli", approved.ClientId)
+
+	tokenCtx, stream := loginCodeTestCtx()
+	resp, err := NewDeviceTokenLogic(tokenCtx, svcCtx).DeviceToken(&auth.DeviceTokenReq{DeviceCode: authz.DeviceCode, ClientId: "cli"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, stream.header.Get("x-refresh-token"))
+
+	// device_code is single use
+	_, err = NewDeviceTokenLogic(ctx, svcCtx).DeviceToken(&auth.DeviceTokenReq{DeviceCode: authz.DeviceCode})
+	assert.Equal(t, DeviceErrExpiredToken, status.Convert(err).Message())
+}
+
+func TestDeviceToken_DenyAndSlowDown(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.DeviceAuth = config.DeviceAuthConfig{ExpiresInSeconds: 600, IntervalSeconds: 5}
+	ctx := context.Background()
+
+	authz, err := NewDeviceAuthorizeLogic(ctx, svcCtx).DeviceAuthorize(&auth.DeviceAuthorizeReq{ClientId: "cli"})
+	require.NoError(t, err)
+
+	_, err = NewDeviceTokenLogic(ctx, svcCtx).DeviceToken(&auth.DeviceTokenReq{DeviceCode: authz.DeviceCode})
+	assert.Equal(t, DeviceErrAuthorizationPending, status.Convert(err).Message())
+	_, err = NewDeviceTokenLogic(ctx, svcCtx).DeviceToken(&auth.DeviceTokenReq{DeviceCode: authz.DeviceCode})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, DeviceErrSlowDown, status.Convert(err).Message())
+
+	_, err = NewApproveDeviceLogic(ctx, svcCtx).ApproveDevice(&auth.ApproveDeviceReq{UserCode: authz.UserCode, UserId: "user-123", Deny: true})
+	require.NoError(t, err)
+
+	// a second decision on the same request is rejected
+	_, err = NewApproveDeviceLogic(ctx, svcCtx).ApproveDevice(&auth.ApproveDeviceReq{UserCode: authz.UserCode, UserId: "user-123"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestDeviceAuthorize_SaveIsAtomic(t *testing.T) {
+	svcCtx, mr := createTestServiceContext(t)
+	ctx := context.Background()
+
+	ok, err := dao.SaveDeviceRequest(ctx, svcCtx.Redis, svcCtx.Key, "hash-1", "ABCDEFGH", "cli", "", 5, 600)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	userCodeKey := util.RedisKey(svcCtx.Key, util.RedisKeyTypeDeviceUserCode, "ABCDEFGH")
+	deviceKey := util.RedisKey(svcCtx.Key, util.RedisKeyTypeDevice, "hash-1")
+	assert.Equal(t, 600*time.Second, mr.TTL(userCodeKey))
+	assert.Equal(t, 600*time.Second, mr.TTL(deviceKey))
+	assert.Equal(t, dao.DeviceStatusPending, mr.HGet(deviceKey, "status"))
+	assert.Equal(t, "5", mr.HGet(deviceKey, "interval"))
+
+	// a taken user_code writes nothing for the second device
+	ok, err = dao.SaveDeviceRequest(ctx, svcCtx.Redis, svcCtx.Key, "hash-2", "ABCDEFGH", "cli", "", 5, 600)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, mr.Exists(util.RedisKey(svcCtx.Key, util.RedisKeyTypeDevice, "hash-2")))
+	got, _ := mr.Get(userCodeKey)
+	assert.Equal(t, "hash-1", got)
+}
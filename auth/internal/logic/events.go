@@ -0,0 +1,34 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/event"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+)
+
+// security events are queued on svcCtx.Events, they never block or fail the rpc
+
+// issueSessionFor opens a session and reports the successful login
+func issueSessionFor(ctx context.Context, svcCtx *svc.ServiceContext, userID, method string) (*auth.LoginResp, error) {
+	resp, err := issueSession(ctx, svcCtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	event.Emit(ctx, svcCtx.Events, event.NewLoginSucceededEvent(ctx, userID, resp.SessionId, method), userID)
+	return resp, nil
+}
+
+func emitLoginFailed(ctx context.Context, svcCtx *svc.ServiceContext, identifier, userID, method, reason string) {
+	event.Emit(ctx, svcCtx.Events, event.NewLoginFailedEvent(ctx, identifier, userID, method, reason),
+		event.KeyFor(userID, identifier))
+}
+
+func emitSessionRevoked(ctx context.Context, svcCtx *svc.ServiceContext, userID, sessionID, reason string) {
+	event.Emit(ctx, svcCtx.Events, event.NewSessionRevokedEvent(ctx, userID, sessionID, reason), userID)
+}
+
+func emitRefreshReused(ctx context.Context, svcCtx *svc.ServiceContext, userID, sessionID, jti string) {
+	event.Emit(ctx, svcCtx.Events, event.NewRefreshReusedEvent(ctx, userID, sessionID, jti), userID)
+}
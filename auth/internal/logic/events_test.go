@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/config"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/event"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+)
+
+// recordingPublisher keeps every published body, block makes Publish hang until released
+type recordingPublisher struct {
+	mu     sync.Mutex
+	bodies [][]byte
+	block  chan struct{}
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) error {
+	if p.block != nil {
+		<-p.block
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bodies = append(p.bodies, data)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func (p *recordingPublisher) eventTypes(t *testing.T) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var types []string
+	for _, b := range p.bodies {
+		var env eventbus.Envelope[json.RawMessage]
+		require.NoError(t, json.Unmarshal(b, &env))
+		types = append(types, env.EventType)
+	}
+	return types
+}
+
+func TestAuthEvents_LoginCode(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.LoginCode = config.LoginCodeConfig{CodeTTLSeconds: 600, MaxAttempts: 5, CodeLength: 6}
+	pub := &recordingPublisher{}
+	svcCtx.Events = event.NewEmitter(pub, "test.auth.service.auth-events", 16, time.Second)
+
+	require.NoError(t, dao.SaveLoginCode(context.Background(), svcCtx.Redis, svcCtx.Key, "dave@example.com", "user-1",
+		util.HashSecret("123456"), util.HashSecret("link-token"), 600))
+
+	ctx, _ := loginCodeTestCtx()
+	_, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{Email: "dave@example.com", Code: "000000"})
+	require.Error(t, err)
+	ctx, _ = loginCodeTestCtx()
+	resp, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{Email: "dave@example.com", Code: "123456"})
+	require.NoError(t, err)
+
+	_, err = NewLogoutLogic(context.Background(), svcCtx).Logout(&auth.LogoutReq{SessionId: resp.SessionId})
+	require.NoError(t, err)
+
+	require.NoError(t, svcCtx.Events.Close())
+	assert.Equal(t, []string{
+		eventbus.EventTypeAuthLoginFailed,
+		eventbus.EventTypeAuthLoginSucceeded,
+		eventbus.EventTypeAuthSessionRevoked,
+	}, pub.eventTypes(t))
+}
+
+func TestAuthEvents_LogoutAllSkipsEmptySessions(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.LoginCode = config.LoginCodeConfig{CodeTTLSeconds: 600, MaxAttempts: 5, CodeLength: 6}
+	pub := &recordingPublisher{}
+	svcCtx.Events = event.NewEmitter(pub, "test.auth.service.auth-events", 16, time.Second)
+
+	var sids []string
+	for i := 0; i < 2; i++ {
+		require.NoError(t, dao.SaveLoginCode(context.Background(), svcCtx.Redis, svcCtx.Key, "dave@example.com", "user-1",
+			util.HashSecret("123456"), util.HashSecret("link-token"), 600))
+		ctx, _ := loginCodeTestCtx()
+		resp, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{Email: "dave@example.com", Code: "123456"})
+		require.NoError(t, err)
+		sids = append(sids, resp.SessionId)
+	}
+	// a session whose refresh tokens are already gone is still listed for the user
+	_, err := svcCtx.Redis.Sadd(util.UserSidsKey(svcCtx.Key, "user-1"), "sid-expired")
+	require.NoError(t, err)
+
+	_, err = NewLogoutLogic(context.Background(), svcCtx).Logout(&auth.LogoutReq{SessionId: sids[0], All: true})
+	require.NoError(t, err)
+
+	require.NoError(t, svcCtx.Events.Close())
+	revoked := map[string]int{}
+	pub.mu.Lock()
+	for _, b := range pub.bodies {
+		var env eventbus.Envelope[eventbus.AuthSessionRevokedEvent]
+		require.NoError(t, json.Unmarshal(b, &env))
+		if env.EventType == eventbus.EventTypeAuthSessionRevoked {
+			revoked[env.Data.SessionID]++
+		}
+	}
+	pub.mu.Unlock()
+	assert.Equal(t, map[string]int{sids[0]: 1, sids[1]: 1}, revoked)
+}
+
+func TestAuthEvents_NeverBlock(t *testing.T) {
+	pub := &recordingPublisher{block: make(chan struct{})}
+	emitter := event.NewEmitter(pub, "test.auth.service.auth-events", 1, time.Second)
+
+	// the worker holds one event inside Publish, the queue holds one more, the rest are dropped
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			event.Emit(context.Background(), emitter, event.NewSessionRevokedEvent(context.Background(), "user-1", "sid", "logout"), "user-1")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit blocked on a stuck publisher")
+	}
+	assert.GreaterOrEqual(t, emitter.Dropped(), int64(8))
+
+	close(pub.block)
+	require.NoError(t, emitter.Close())
+}
@@ -0,0 +1,88 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ImpersonateLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewImpersonateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ImpersonateLogic {
+	return &ImpersonateLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// Impersonate lets an admin act as another user. The token carries an "act" claim naming the admin,
+// lives for Impersonation.TTLSeconds and comes without refresh token or session, so it simply runs out.
+func (l *ImpersonateLogic) Impersonate(in *auth.ImpersonateReq) (*auth.ImpersonateResp, error) {
+	adminID := strings.TrimSpace(in.GetAdminId())
+	targetID := strings.TrimSpace(in.GetTargetUserId())
+	if adminID == "" || targetID == "" {
+		return nil, status.Error(codes.InvalidArgument, "admin_id and target_user_id are required")
+	}
+	if adminID == targetID {
+		return nil, status.Error(codes.InvalidArgument, "cannot impersonate yourself")
+	}
+
+	cfg := l.svcCtx.Config.Impersonation
+	admin, err := l.svcCtx.AuthUsers.FindOneByIDWithCallBack(l.ctx, adminID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.PermissionDenied, "admin role required")
+		}
+		return nil, err
+	}
+	if admin.Role != cfg.AdminRole {
+		l.Infow("impersonation denied, caller is not an admin",
+			logx.Field("audit", "impersonation"), logx.Field("actor", adminID), logx.Field("target", targetID))
+		return nil, status.Error(codes.PermissionDenied, "admin role required")
+	}
+
+	if _, err := l.svcCtx.AuthUsers.FindOneByIDWithCallBack(l.ctx, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "target user not found")
+		}
+		return nil, err
+	}
+
+	jti := uuid.NewString()
+	token, expiresIn, err := l.svcCtx.TokenHelper.SignImpersonation(targetID, adminID, jti,
+		time.Duration(cfg.TTLSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Infow("impersonation token issued",
+		logx.Field("audit", "impersonation"),
+		logx.Field("actor", adminID),
+		logx.Field("target", targetID),
+		logx.Field("jti", jti),
+		logx.Field("reason", in.GetReason()),
+		logx.Field("expires_in", expiresIn))
+
+	return &auth.ImpersonateResp{
+		AccessToken:  token,
+		ExpiresIn:    expiresIn,
+		TokenType:    "Bearer",
+		ActorId:      adminID,
+		TargetUserId: targetID,
+	}, nil
+}
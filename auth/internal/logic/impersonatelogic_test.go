@@ -0,0 +1,80 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/config"
+	"github.com/uwu-octane/antBackend/auth/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthUsers is an in-memory AuthUsersModel keyed by id
+type fakeAuthUsers map[string]*model.AuthUsers
+
+func (f fakeAuthUsers) FindByEmail(ctx context.Context, email string) (*model.AuthUsers, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (f fakeAuthUsers) FindByUsername(ctx context.Context, username string) (*model.AuthUsers, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (f fakeAuthUsers) FindOneByIDWithCallBack(ctx context.Context, id string) (*model.AuthUsers, error) {
+	if u, ok := f[id]; ok {
+		return u, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestImpersonate(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.Impersonation = config.ImpersonationConfig{TTLSeconds: 300, AdminRole: "admin"}
+	svcCtx.AuthUsers = fakeAuthUsers{
+		"admin-1": {Id: "admin-1", Role: "admin"},
+		"user-1":  {Id: "user-1", Role: "user"},
+	}
+	ctx, stream := loginCodeTestCtx()
+
+	resp, err := NewImpersonateLogic(ctx, svcCtx).Impersonate(&auth.ImpersonateReq{
+		AdminId:      "admin-1",
+		TargetUserId: "user-1",
+		Reason:       "ticket 42",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), resp.ExpiresIn)
+	// no refresh token is handed out
+	assert.Empty(t, stream.header.Get("x-refresh-token"))
+
+	claims, err := svcCtx.TokenHelper.Parse(resp.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "access", claims.TokenType)
+	require.NotNil(t, claims.Act)
+	assert.Equal(t, "admin-1", claims.Act.Sub)
+
+	_, err = NewImpersonateLogic(ctx, svcCtx).Impersonate(&auth.ImpersonateReq{AdminId: "user-1", TargetUserId: "admin-1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = NewImpersonateLogic(ctx, svcCtx).Impersonate(&auth.ImpersonateReq{AdminId: "admin-1", TargetUserId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetRole(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.AuthUsers = fakeAuthUsers{"admin-1": {Id: "admin-1", Role: "admin"}}
+
+	resp, err := NewGetRoleLogic(context.Background(), svcCtx).GetRole(&auth.GetRoleReq{UserId: " admin-1 "})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", resp.Role)
+
+	_, err = NewGetRoleLogic(context.Background(), svcCtx).GetRole(&auth.GetRoleReq{UserId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = NewGetRoleLogic(context.Background(), svcCtx).GetRole(&auth.GetRoleReq{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -3,16 +3,15 @@ package logic
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/model"
 	"github.com/uwu-octane/antBackend/auth/internal/svc"
 	"github.com/uwu-octane/antBackend/auth/internal/util"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/zeromicro/go-zero/core/logx"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -32,12 +31,18 @@ func NewLoginLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LoginLogic
 
 func (l *LoginLogic) Login(in *auth.LoginReq) (*auth.LoginResp, error) {
 
-	if in.GetUsername() == "" || in.GetPassword() == "" {
-		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	identifier := util.NormalizeIdentifier(in.GetIdentifier())
+	if identifier == "" {
+		// fall back to the deprecated username field for older clients
+		identifier = util.NormalizeIdentifier(in.GetUsername())
+	}
+	if identifier == "" || in.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "identifier and password are required")
 	}
 
-	user, err := l.svcCtx.AuthUsers.FindByUsername(l.ctx, in.GetUsername())
+	user, err := l.findUser(identifier)
 	if err != nil {
+		emitLoginFailed(l.ctx, l.svcCtx, identifier, "", eventbus.AuthMethodPassword, "unknown_identifier")
 		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 	}
 
@@ -50,63 +55,22 @@ func (l *LoginLogic) Login(in *auth.LoginReq) (*auth.LoginResp, error) {
 	case "bcrypt":
 		err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(in.GetPassword()))
 		if err != nil {
+			emitLoginFailed(l.ctx, l.svcCtx, identifier, user.Id, eventbus.AuthMethodPassword, "invalid_password")
 			return nil, status.Error(codes.Unauthenticated, "invalid credentials")
 		}
 	default:
 		return nil, status.Error(codes.Internal, "invalid password algorithm")
 	}
 
-	userID := user.Id
-
-	//* call token helper to sign tokens
-	refreshJti := uuid.NewString()
-	accessJti := uuid.NewString()
-
-	accessToken, accessExpireSeconds, err := l.svcCtx.TokenHelper.SignAccess(userID, accessJti)
-	if err != nil {
-		return nil, err
-	}
-
-	refreshToken, refreshExpireSeconds, err := l.svcCtx.TokenHelper.SignRefresh(userID, refreshJti)
-	if err != nil {
-		return nil, err
-	}
-	if err := grpc.SetHeader(l.ctx, metadata.Pairs("x-refresh-token", refreshToken)); err != nil {
-		return nil, err
-	}
-
-	sid := uuid.NewString()
-	//* user->sid
-	if _, err := l.svcCtx.Redis.Sadd(util.UserSidsKey(l.svcCtx.Key, userID), sid); err != nil {
-		return nil, err
-	}
-	if err := l.svcCtx.Redis.Expire(util.UserSidsKey(l.svcCtx.Key, userID), int(refreshExpireSeconds)); err != nil {
-		return nil, err
-	}
-
-	//* sid->jit put the first refresh jti in to the sid collection
-	if _, err := l.svcCtx.Redis.Sadd(util.SidSetKey(l.svcCtx.Key, sid), refreshJti); err != nil {
-		return nil, err
-	}
-	if err := l.svcCtx.Redis.Expire(util.SidSetKey(l.svcCtx.Key, sid), int(refreshExpireSeconds)); err != nil {
-		return nil, err
-	}
-
-	//* jti ->sid (index of jti to sid)
-	if err := l.svcCtx.Redis.Setex(util.JtiSidKey(l.svcCtx.Key, refreshJti), sid, int(refreshExpireSeconds)); err != nil {
-		return nil, err
-	}
+	return issueSessionFor(l.ctx, l.svcCtx, user.Id, eventbus.AuthMethodPassword)
+}
 
-	//* Redis：auth:refresh:<jti> = userID（Or JSON），TTL=RefreshExpireSeconds
-	key := util.RedisKey(l.svcCtx.Key, util.RedisKeyTypeRefresh, refreshJti)
-	if err := l.svcCtx.Redis.Setex(key, userID, int(refreshExpireSeconds)); err != nil {
-		return nil, err
+// findUser looks the account up by email or username depending on the shape of the identifier
+func (l *LoginLogic) findUser(identifier string) (*model.AuthUsers, error) {
+	switch util.DetectIdentifier(identifier) {
+	case util.IdentifierEmail:
+		return l.svcCtx.AuthUsers.FindByEmail(l.ctx, identifier)
+	default:
+		return l.svcCtx.AuthUsers.FindByUsername(l.ctx, identifier)
 	}
-
-	return &auth.LoginResp{
-		AccessToken: accessToken,
-		SessionId:   sid,
-		ExpiresIn:   accessExpireSeconds,
-		TokenType:   "Bearer",
-	}, nil
 }
@@ -36,6 +36,13 @@ func (l *LogoutLogic) Logout(in *auth.LogoutReq) (*auth.LogoutResp, error) {
 	if err != nil {
 		return nil, fmt.Errorf("logout: failed to revoke sid: %s, %v", sid, err)
 	}
+	reason := "logout"
+	if in.GetAll() {
+		reason = "logout_all"
+	}
+	if uid != "" {
+		emitSessionRevoked(l.ctx, l.svcCtx, uid, sid, reason)
+	}
 
 	if in.GetAll() && uid != "" {
 		//get all sids of the user
@@ -43,8 +50,14 @@ func (l *LogoutLogic) Logout(in *auth.LogoutReq) (*auth.LogoutResp, error) {
 		allSids, _ := l.svcCtx.Redis.Smembers(userSidsKey)
 		//revoke all sids of the user
 		for _, s := range allSids {
-			if _, err := l.revokeOneSid(l.svcCtx.Key, s); err != nil {
+			owner, err := l.revokeOneSid(l.svcCtx.Key, s)
+			if err != nil {
 				logx.WithContext(l.ctx).Errorf("logout: revoke sid in all failed uid=%s sid=%s err=%v", uid, s, err)
+				continue
+			}
+			// an empty owner means the sid had no refresh tokens left (already revoked or expired)
+			if owner != "" {
+				emitSessionRevoked(l.ctx, l.svcCtx, uid, s, reason)
 			}
 		}
 		//delete user sids set
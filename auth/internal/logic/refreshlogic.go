@@ -130,6 +130,9 @@ func (l *RefreshLogic) Refresh(in *auth.RefreshReq) (*auth.LoginResp, error) {
 	})
 	if runErr != nil {
 		l.handleRefreshError(uid, jti, runErr)
+		if errors.Is(runErr, ErrRefreshReused) {
+			emitRefreshReused(l.ctx, l.svcCtx, uid, sid, jti)
+		}
 		return nil, runErr
 	}
 
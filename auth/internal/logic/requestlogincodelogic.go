@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/mailer"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RequestLoginCodeLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewRequestLoginCodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RequestLoginCodeLogic {
+	return &RequestLoginCodeLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// RequestLoginCode mails a one-time code and a magic link to the account's email.
+// Unknown emails and failed deliveries get the same response, so the RPC cannot be used to enumerate accounts.
+func (l *RequestLoginCodeLogic) RequestLoginCode(in *auth.RequestLoginCodeReq) (*auth.RequestLoginCodeResp, error) {
+	email := util.NormalizeIdentifier(in.GetEmail())
+	if email == "" || util.DetectIdentifier(email) != util.IdentifierEmail {
+		return nil, status.Error(codes.InvalidArgument, "a valid email is required")
+	}
+	if l.svcCtx.Mailer == nil {
+		return nil, status.Error(codes.Unavailable, "passwordless login is not available")
+	}
+
+	cfg := l.svcCtx.Config.LoginCode
+	resp := &auth.RequestLoginCodeResp{Ok: true, ExpiresIn: cfg.CodeTTLSeconds}
+
+	//* one mail per email per interval, claimed before the lookup so it behaves the same for unknown emails.
+	// The claim is released when no mail goes out for a known account, so a failed delivery can be retried at once.
+	cooldownKey := util.RedisKey(l.svcCtx.Key, util.RedisKeyTypeLoginCodeCooldown, email)
+	ok, err := l.svcCtx.Redis.SetnxExCtx(l.ctx, cooldownKey, "1", cfg.ResendIntervalSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("request login code: cooldown check failed: %w", err)
+	}
+	if !ok {
+		return nil, status.Error(codes.ResourceExhausted, "login code recently sent, try again later")
+	}
+
+	user, err := l.svcCtx.AuthUsers.FindByEmail(l.ctx, email)
+	if err != nil {
+		l.Infof("request login code: no account for email, skip sending")
+		return resp, nil
+	}
+
+	if err := l.sendLoginCode(email, user.Id); err != nil {
+		l.Errorf("request login code: no mail sent uid=%s err=%v", user.Id, err)
+		if _, err := l.svcCtx.Redis.DelCtx(context.WithoutCancel(l.ctx), cooldownKey); err != nil {
+			l.Errorf("request login code: release cooldown failed uid=%s err=%v", user.Id, err)
+		}
+	}
+	return resp, nil
+}
+
+// sendLoginCode stores a fresh code and link for the account and mails them
+func (l *RequestLoginCodeLogic) sendLoginCode(email, uid string) error {
+	cfg := l.svcCtx.Config.LoginCode
+	code, err := util.RandomDigits(cfg.CodeLength)
+	if err != nil {
+		return err
+	}
+	linkToken, err := util.RandomToken()
+	if err != nil {
+		return err
+	}
+
+	if err := dao.SaveLoginCode(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, email, uid,
+		util.HashSecret(code), util.HashSecret(linkToken), int(cfg.CodeTTLSeconds)); err != nil {
+		return err
+	}
+	if err := l.svcCtx.Mailer.Send(l.ctx, loginCodeMessage(email, code, linkToken, cfg.LinkURL, cfg.CodeTTLSeconds)); err != nil {
+		return fmt.Errorf("send mail: %w", err)
+	}
+	return nil
+}
+
+func loginCodeMessage(email, code, linkToken, linkURL string, ttlSeconds int64) mailer.Message {
+	body := fmt.Sprintf("Your login code is %s\n\nIt expires in %d minutes and can only be used once.\n", code, ttlSeconds/60)
+	if linkURL != "" {
+		body += fmt.Sprintf("\nOr sign in directly with this link:\n%s?token=%s\n", linkURL, url.QueryEscape(linkToken))
+	}
+	body += "\nIf you did not request this email you can ignore it.\n"
+	return mailer.Message{
+		To:      email,
+		Subject: "Your login code",
+		Body:    body,
+	}
+}
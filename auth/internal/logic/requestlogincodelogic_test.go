@@ -0,0 +1,106 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/config"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/mailer"
+	"github.com/uwu-octane/antBackend/auth/internal/model"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// emailUsers is an AuthUsersModel stub that knows a fixed set of emails
+type emailUsers struct {
+	model.AuthUsersModel
+	ids map[string]string
+}
+
+func (m emailUsers) FindByEmail(_ context.Context, email string) (*model.AuthUsers, error) {
+	id, ok := m.ids[email]
+	if !ok {
+		return nil, sqlx.ErrNotFound
+	}
+	return &model.AuthUsers{Id: id, Email: email}, nil
+}
+
+// failingMailer records every message and fails while fail is set
+type failingMailer struct {
+	fail bool
+	sent []mailer.Message
+}
+
+func (m *failingMailer) Send(_ context.Context, msg mailer.Message) error {
+	if m.fail {
+		return errors.New("smtp unavailable")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestSaveLoginCode_IsAtomic(t *testing.T) {
+	svcCtx, mr := createTestServiceContext(t)
+	ctx := context.Background()
+	codeKey := util.RedisKey(svcCtx.Key, util.RedisKeyTypeLoginCode, "erin@example.com")
+	attemptsKey := util.RedisKey(svcCtx.Key, util.RedisKeyTypeLoginCodeAttempts, "erin@example.com")
+	linkKey := util.RedisKey(svcCtx.Key, util.RedisKeyTypeLoginLink, "link-hash")
+	require.NoError(t, mr.Set(attemptsKey, "3"))
+
+	require.NoError(t, dao.SaveLoginCode(ctx, svcCtx.Redis, svcCtx.Key, "erin@example.com", "user-1", "code-hash", "link-hash", 600))
+
+	assert.Equal(t, "code-hash", mr.HGet(codeKey, string(dao.LoginCodeFieldCode)))
+	assert.Equal(t, "link-hash", mr.HGet(codeKey, string(dao.LoginCodeFieldLink)))
+	assert.Equal(t, "user-1", mr.HGet(codeKey, "uid"))
+	assert.Equal(t, 600*time.Second, mr.TTL(codeKey))
+	assert.False(t, mr.Exists(attemptsKey), "a new code resets the attempt budget")
+	email, _ := mr.Get(linkKey)
+	assert.Equal(t, "erin@example.com", email)
+	assert.Equal(t, 600*time.Second, mr.TTL(linkKey))
+}
+
+func TestRequestLoginCode_SameResponseAndCooldown(t *testing.T) {
+	tests := []struct {
+		name         string
+		email        string
+		mailFails    bool
+		wantSent     int
+		wantCooldown bool
+	}{
+		{name: "known account gets a mail", email: "frank@example.com", wantSent: 1, wantCooldown: true},
+		{name: "unknown email sends nothing", email: "nobody@example.com", wantCooldown: true},
+		{name: "failed delivery leaves no cooldown", email: "frank@example.com", mailFails: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svcCtx, mr := createTestServiceContext(t)
+			svcCtx.Config.LoginCode = config.LoginCodeConfig{CodeTTLSeconds: 600, ResendIntervalSeconds: 60, MaxAttempts: 5, CodeLength: 6}
+			svcCtx.AuthUsers = emailUsers{ids: map[string]string{"frank@example.com": "user-1"}}
+			mail := &failingMailer{fail: tt.mailFails}
+			svcCtx.Mailer = mail
+
+			resp, err := NewRequestLoginCodeLogic(context.Background(), svcCtx).RequestLoginCode(&auth.RequestLoginCodeReq{Email: tt.email})
+			require.NoError(t, err)
+			assert.Equal(t, &auth.RequestLoginCodeResp{Ok: true, ExpiresIn: 600}, resp)
+			assert.Len(t, mail.sent, tt.wantSent)
+			assert.Equal(t, tt.wantCooldown, mr.Exists(util.RedisKey(svcCtx.Key, util.RedisKeyTypeLoginCodeCooldown, tt.email)))
+
+			mail.fail = false
+			_, err = NewRequestLoginCodeLogic(context.Background(), svcCtx).RequestLoginCode(&auth.RequestLoginCodeReq{Email: tt.email})
+			if tt.wantCooldown {
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, mail.sent, 1, "the retry after a failed delivery sends the mail")
+			}
+		})
+	}
+}
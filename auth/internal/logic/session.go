@@ -0,0 +1,69 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// issueSession signs a fresh access/refresh pair for an authenticated user,
+// opens a new sid and writes the session indexes to Redis.
+// The refresh token is returned through the x-refresh-token grpc header.
+func issueSession(ctx context.Context, svcCtx *svc.ServiceContext, userID string) (*auth.LoginResp, error) {
+	//* call token helper to sign tokens
+	refreshJti := uuid.NewString()
+	accessJti := uuid.NewString()
+
+	accessToken, accessExpireSeconds, err := svcCtx.TokenHelper.SignAccess(userID, accessJti)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpireSeconds, err := svcCtx.TokenHelper.SignRefresh(userID, refreshJti)
+	if err != nil {
+		return nil, err
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs("x-refresh-token", refreshToken)); err != nil {
+		return nil, err
+	}
+
+	sid := uuid.NewString()
+	//* user->sid
+	if _, err := svcCtx.Redis.Sadd(util.UserSidsKey(svcCtx.Key, userID), sid); err != nil {
+		return nil, err
+	}
+	if err := svcCtx.Redis.Expire(util.UserSidsKey(svcCtx.Key, userID), int(refreshExpireSeconds)); err != nil {
+		return nil, err
+	}
+
+	//* sid->jit put the first refresh jti in to the sid collection
+	if _, err := svcCtx.Redis.Sadd(util.SidSetKey(svcCtx.Key, sid), refreshJti); err != nil {
+		return nil, err
+	}
+	if err := svcCtx.Redis.Expire(util.SidSetKey(svcCtx.Key, sid), int(refreshExpireSeconds)); err != nil {
+		return nil, err
+	}
+
+	//* jti ->sid (index of jti to sid)
+	if err := svcCtx.Redis.Setex(util.JtiSidKey(svcCtx.Key, refreshJti), sid, int(refreshExpireSeconds)); err != nil {
+		return nil, err
+	}
+
+	//* Redis：auth:refresh:<jti> = userID（Or JSON），TTL=RefreshExpireSeconds
+	key := util.RedisKey(svcCtx.Key, util.RedisKeyTypeRefresh, refreshJti)
+	if err := svcCtx.Redis.Setex(key, userID, int(refreshExpireSeconds)); err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginResp{
+		AccessToken: accessToken,
+		SessionId:   sid,
+		ExpiresIn:   accessExpireSeconds,
+		TokenType:   "Bearer",
+	}, nil
+}
@@ -0,0 +1,86 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/svc"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type VerifyLoginCodeLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewVerifyLoginCodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *VerifyLoginCodeLogic {
+	return &VerifyLoginCodeLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// VerifyLoginCode exchanges an email code or a magic link token for a session,
+// exactly like a password login does.
+func (l *VerifyLoginCodeLogic) VerifyLoginCode(in *auth.VerifyLoginCodeReq) (*auth.LoginResp, error) {
+	email := util.NormalizeIdentifier(in.GetEmail())
+	code := strings.TrimSpace(in.GetCode())
+	linkToken := strings.TrimSpace(in.GetLinkToken())
+
+	var field dao.LoginCodeField
+	var hash string
+	method := eventbus.AuthMethodLoginCode
+	switch {
+	case linkToken != "":
+		hash = util.HashSecret(linkToken)
+		//* the link index is consumed here, a replayed link never reaches the script
+		linkEmail, err := dao.ResolveLoginLink(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, hash)
+		if err != nil {
+			return nil, err
+		}
+		if linkEmail == "" || (email != "" && email != linkEmail) {
+			emitLoginFailed(l.ctx, l.svcCtx, email, "", eventbus.AuthMethodMagicLink, "invalid_link")
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired login code")
+		}
+		email, field, method = linkEmail, dao.LoginCodeFieldLink, eventbus.AuthMethodMagicLink
+	case email != "" && code != "":
+		hash, field = util.HashSecret(code), dao.LoginCodeFieldCode
+	default:
+		return nil, status.Error(codes.InvalidArgument, "email and code, or link token, are required")
+	}
+
+	cfg := l.svcCtx.Config.LoginCode
+	res, err := dao.VerifyLoginCode(l.ctx, l.svcCtx.Redis, l.svcCtx.Key, email, field, hash,
+		cfg.MaxAttempts, int(cfg.CodeTTLSeconds))
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.Code {
+	case dao.LoginCodeOK:
+	case dao.LoginCodeTooManyAttempts:
+		l.Infof("verify login code: too many attempts, code burned")
+		emitLoginFailed(l.ctx, l.svcCtx, email, res.Uid, method, "too_many_attempts")
+		return nil, status.Error(codes.ResourceExhausted, "too many attempts, request a new login code")
+	case dao.LoginCodeNotFound, dao.LoginCodeMismatch:
+		emitLoginFailed(l.ctx, l.svcCtx, email, res.Uid, method, "invalid_code")
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired login code")
+	default:
+		return nil, status.Error(codes.Internal, "unknown login code state")
+	}
+
+	if res.Uid == "" {
+		return nil, status.Error(codes.Internal, "login code without user")
+	}
+
+	return issueSessionFor(l.ctx, l.svcCtx, res.Uid, method)
+}
@@ -0,0 +1,105 @@
+package logic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/auth"
+	"github.com/uwu-octane/antBackend/auth/internal/config"
+	"github.com/uwu-octane/antBackend/auth/internal/dao"
+	"github.com/uwu-octane/antBackend/auth/internal/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream lets grpc.SetHeader work outside a real server
+type fakeServerStream struct {
+	header metadata.MD
+}
+
+func (s *fakeServerStream) Method() string { return "/auth.v1.AuthService/VerifyLoginCode" }
+func (s *fakeServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+func (s *fakeServerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+func (s *fakeServerStream) SetTrailer(md metadata.MD) error { return nil }
+
+func loginCodeTestCtx() (context.Context, *fakeServerStream) {
+	stream := &fakeServerStream{}
+	return grpc.NewContextWithServerTransportStream(context.Background(), stream), stream
+}
+
+func TestVerifyLoginCode_SingleUse(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.LoginCode = config.LoginCodeConfig{CodeTTLSeconds: 600, MaxAttempts: 5, CodeLength: 6}
+	email, uid := "alice@example.com", "user-123"
+
+	require.NoError(t, dao.SaveLoginCode(context.Background(), svcCtx.Redis, svcCtx.Key, email, uid,
+		util.HashSecret("123456"), util.HashSecret("link-token"), 600))
+
+	ctx, stream := loginCodeTestCtx()
+	resp, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{
+		Email: "Alice@Example.com",
+		Code:  "123456",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.SessionId)
+	assert.NotEmpty(t, stream.header.Get("x-refresh-token"))
+
+	sids, err := svcCtx.Redis.Smembers(util.UserSidsKey(svcCtx.Key, uid))
+	require.NoError(t, err)
+	assert.Contains(t, sids, resp.SessionId)
+
+	// replaying the same code must fail
+	ctx, _ = loginCodeTestCtx()
+	_, err = NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{Email: email, Code: "123456"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the link sent in the same mail is burned together with the code
+	ctx, _ = loginCodeTestCtx()
+	_, err = NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{LinkToken: "link-token"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestVerifyLoginCode_AttemptLimit(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.LoginCode = config.LoginCodeConfig{CodeTTLSeconds: 600, MaxAttempts: 3, CodeLength: 6}
+	email := "bob@example.com"
+
+	require.NoError(t, dao.SaveLoginCode(context.Background(), svcCtx.Redis, svcCtx.Key, email, "user-456",
+		util.HashSecret("654321"), util.HashSecret("link-token"), 600))
+
+	for i, want := range []codes.Code{codes.Unauthenticated, codes.Unauthenticated, codes.ResourceExhausted} {
+		ctx, _ := loginCodeTestCtx()
+		_, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{Email: email, Code: "000000"})
+		assert.Equal(t, want, status.Code(err), "attempt %d", i+1)
+	}
+
+	// the correct code no longer works once the attempt budget is spent
+	ctx, _ := loginCodeTestCtx()
+	_, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{Email: email, Code: "654321"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestVerifyLoginCode_MagicLink(t *testing.T) {
+	svcCtx, _ := createTestServiceContext(t)
+	svcCtx.Config.LoginCode = config.LoginCodeConfig{CodeTTLSeconds: 600, MaxAttempts: 5, CodeLength: 6}
+
+	require.NoError(t, dao.SaveLoginCode(context.Background(), svcCtx.Redis, svcCtx.Key, "carol@example.com", "user-789",
+		util.HashSecret("111111"), util.HashSecret("link-token"), 600))
+
+	ctx, _ := loginCodeTestCtx()
+	resp, err := NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{LinkToken: "link-token"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+
+	ctx, _ = loginCodeTestCtx()
+	_, err = NewVerifyLoginCodeLogic(ctx, svcCtx).VerifyLoginCode(&auth.VerifyLoginCodeReq{LinkToken: "link-token"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/auth/internal/config"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers outgoing emails (login codes, magic links)
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func New(c config.MailerConfig) (Mailer, error) {
+	switch strings.ToLower(c.Driver) {
+	case "", "file":
+		return NewFileMailer(c.Dir, c.From), nil
+	case "smtp":
+		if c.SMTP.Host == "" {
+			return nil, fmt.Errorf("mailer: smtp host is required")
+		}
+		return NewSMTPMailer(c.SMTP, c.From), nil
+	default:
+		return nil, fmt.Errorf("mailer: unsupported driver: %s", c.Driver)
+	}
+}
+
+func render(from string, msg Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	return []byte(b.String())
+}
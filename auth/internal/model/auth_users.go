@@ -15,13 +15,16 @@ type AuthUsers struct {
 	Email        string         `db:"email"`
 	PasswordHash string         `db:"password_hash"`
 	PasswordAlgo sql.NullString `db:"password_algo"`
+	Role         string         `db:"role"`
 	CreatedAt    string         `db:"created_at"`
 	UpdatedAt    string         `db:"updated_at"`
 }
 
 type AuthUsersModel interface {
+	// lookups are case-insensitive and backed by the lower(email)/lower(username) indexes
 	FindByEmail(ctx context.Context, email string) (*AuthUsers, error)
 	FindByUsername(ctx context.Context, username string) (*AuthUsers, error)
+	FindOneByIDWithCallBack(ctx context.Context, id string) (*AuthUsers, error)
 }
 
 type defaultAuthUsersModel struct {
@@ -38,11 +41,11 @@ func NewAuthUsersModel(replica sqlx.SqlConn, master sqlx.SqlConn, selector *comm
 	}
 }
 
-const authUsersFields = "id, username, email, password_hash, password_algo, created_at, updated_at"
+const authUsersFields = "id, username, email, password_hash, password_algo, role, created_at, updated_at"
 
 func (m *defaultAuthUsersModel) FindByEmail(ctx context.Context, email string) (*AuthUsers, error) {
 	var user AuthUsers
-	query := "SELECT " + authUsersFields + " FROM auth_users WHERE email = $1 LIMIT 1"
+	query := "SELECT " + authUsersFields + " FROM auth_users WHERE lower(email) = lower($1) LIMIT 1"
 	if err := m.replica.QueryRowCtx(ctx, &user, query, email); err != nil {
 		if err == sql.ErrNoRows {
 			logx.WithContext(ctx).Errorf("auth user not found: %s", email)
@@ -55,7 +58,7 @@ func (m *defaultAuthUsersModel) FindByEmail(ctx context.Context, email string) (
 
 func (m *defaultAuthUsersModel) FindByUsername(ctx context.Context, username string) (*AuthUsers, error) {
 	var user AuthUsers
-	query := "SELECT " + authUsersFields + " FROM auth_users WHERE username = $1 LIMIT 1"
+	query := "SELECT " + authUsersFields + " FROM auth_users WHERE lower(username) = lower($1) LIMIT 1"
 	if err := m.replica.QueryRowCtx(ctx, &user, query, username); err != nil {
 		if err == sql.ErrNoRows {
 			logx.WithContext(ctx).Errorf("auth user not found: %s", username)
@@ -42,3 +42,38 @@ func (s *AuthServiceServer) Logout(ctx context.Context, in *auth.LogoutReq) (*au
 	l := logic.NewLogoutLogic(ctx, s.svcCtx)
 	return l.Logout(in)
 }
+
+func (s *AuthServiceServer) RequestLoginCode(ctx context.Context, in *auth.RequestLoginCodeReq) (*auth.RequestLoginCodeResp, error) {
+	l := logic.NewRequestLoginCodeLogic(ctx, s.svcCtx)
+	return l.RequestLoginCode(in)
+}
+
+func (s *AuthServiceServer) VerifyLoginCode(ctx context.Context, in *auth.VerifyLoginCodeReq) (*auth.LoginResp, error) {
+	l := logic.NewVerifyLoginCodeLogic(ctx, s.svcCtx)
+	return l.VerifyLoginCode(in)
+}
+
+func (s *AuthServiceServer) DeviceAuthorize(ctx context.Context, in *auth.DeviceAuthorizeReq) (*auth.DeviceAuthorizeResp, error) {
+	l := logic.NewDeviceAuthorizeLogic(ctx, s.svcCtx)
+	return l.DeviceAuthorize(in)
+}
+
+func (s *AuthServiceServer) ApproveDevice(ctx context.Context, in *auth.ApproveDeviceReq) (*auth.ApproveDeviceResp, error) {
+	l := logic.NewApproveDeviceLogic(ctx, s.svcCtx)
+	return l.ApproveDevice(in)
+}
+
+func (s *AuthServiceServer) DeviceToken(ctx context.Context, in *auth.DeviceTokenReq) (*auth.LoginResp, error) {
+	l := logic.NewDeviceTokenLogic(ctx, s.svcCtx)
+	return l.DeviceToken(in)
+}
+
+func (s *AuthServiceServer) Impersonate(ctx context.Context, in *auth.ImpersonateReq) (*auth.ImpersonateResp, error) {
+	l := logic.NewImpersonateLogic(ctx, s.svcCtx)
+	return l.Impersonate(in)
+}
+
+func (s *AuthServiceServer) GetRole(ctx context.Context, in *auth.GetRoleReq) (*auth.GetRoleResp, error) {
+	l := logic.NewGetRoleLogic(ctx, s.svcCtx)
+	return l.GetRole(in)
+}
@@ -1,10 +1,19 @@
 package svc
 
 import (
+	"time"
+
 	"github.com/uwu-octane/antBackend/auth/internal/config"
+	"github.com/uwu-octane/antBackend/auth/internal/event"
+	"github.com/uwu-octane/antBackend/auth/internal/mailer"
 	"github.com/uwu-octane/antBackend/auth/internal/model"
 	"github.com/uwu-octane/antBackend/auth/internal/util"
 	dbutil "github.com/uwu-octane/antBackend/common/db/util"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	kpub "github.com/uwu-octane/antBackend/common/eventbus/publisher/kafka"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 	"golang.org/x/sync/singleflight"
@@ -21,6 +30,9 @@ type ServiceContext struct {
 	TokenHelper *util.TokenHelper
 
 	AuthUsers model.AuthUsersModel
+	Mailer    mailer.Mailer
+	// Events publishes security events, nil when Kafka is not configured
+	Events *event.Emitter
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -28,6 +40,10 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	master := sqlx.NewSqlConn(c.AuthDatabase.Driver, c.AuthDatabase.MasterDSN)
 	replica := sqlx.NewSqlConn(c.AuthDatabase.Driver, c.AuthDatabase.ReplicaDSN)
 	selector := dbutil.NewSelector(replica, master, c.AuthReadStrategy.FromReplica, c.AuthReadStrategy.FallbackToMasterOnReadError, nil)
+	m, err := mailer.New(c.Mailer)
+	if err != nil {
+		logx.Errorw("create mailer failed, passwordless login disabled", logx.Field("error", err))
+	}
 	return &ServiceContext{
 		Config:      c,
 		Redis:       redis,
@@ -37,5 +53,54 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		RfGroup:     &singleflight.Group{},
 		TokenHelper: util.CreateTokenHelper(c.JwtAuth),
 		AuthUsers:   model.NewAuthUsersModel(replica, master, selector),
+		Mailer:      m,
+		Events:      kafkaAuthEventsEmitter(c),
+	}
+}
+
+func kafkaAuthEventsEmitter(c config.Config) *event.Emitter {
+	if len(c.Kafka.Brokers) == 0 {
+		logx.Info("kafka brokers not set, auth events disabled")
+		return nil
+	}
+	// never fall back to plaintext when a key file is configured but unusable
+	if c.EventPII.KeyFile != "" {
+		keys, err := codec.NewFileKeyProvider(c.EventPII.KeyFile)
+		if err != nil {
+			logx.Errorw("load event pii keys failed, auth events disabled", logx.Field("error", err))
+			return nil
+		}
+		codec.SetKeyProvider(keys)
+	}
+	topics := eventbus.BuildTopics(eventbus.Env(c.Kafka.Env))
+	opts := kpub.ProducerOptions{
+		Brokers:         c.Kafka.Brokers,
+		Acks:            c.KafkaAuthProducer.Acks,
+		Idempotent:      c.KafkaAuthProducer.Idempotent,
+		RetryMax:        c.KafkaAuthProducer.RetryMax,
+		Compression:     c.KafkaAuthProducer.Compression,
+		FlushBytes:      c.KafkaAuthProducer.FlushBytes,
+		FlushMessages:   c.KafkaAuthProducer.FlushMessages,
+		FlushFrequency:  time.Duration(c.KafkaAuthProducer.FlushFrequencyMs) * time.Millisecond,
+		MaxMessageBytes: c.KafkaAuthProducer.MaxMessageBytes,
+		EnableSASL:      c.KafkaAuthProducer.SASL.Enable,
+		SASLMechanism:   c.KafkaAuthProducer.SASL.Mechanism,
+		SASLUsername:    c.KafkaAuthProducer.SASL.Username,
+		SASLPassword:    c.KafkaAuthProducer.SASL.Password,
+		EnableTLS:       c.KafkaAuthProducer.TLS.Enable,
+		ClientID:        event.Producer,
+	}
+	var pub publisher.Publisher
+	var err error
+	if c.KafkaAuthProducer.Async {
+		pub, err = kpub.NewAsyncSaramaPublisher(&opts, nil)
+	} else {
+		pub, err = kpub.NewSaramaPublisher(&opts)
+	}
+	if err != nil {
+		logx.Errorw("create kafka auth events publisher failed, auth events disabled", logx.Field("error", err))
+		return nil
 	}
+	return event.NewEmitter(pub, topics.AuthEvents(), c.AuthEvents.Buffer,
+		time.Duration(c.AuthEvents.PublishTimeoutMs)*time.Millisecond)
 }
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"strings"
+)
+
+type IdentifierKind int
+
+const (
+	IdentifierUsername IdentifierKind = iota
+	IdentifierEmail
+)
+
+// NormalizeIdentifier trims and lower-cases a login identifier so that
+// lookups and rate-limit keys are case-insensitive.
+func NormalizeIdentifier(identifier string) string {
+	return strings.ToLower(strings.TrimSpace(identifier))
+}
+
+// DetectIdentifier reports whether the identifier is an email or a username.
+// Usernames never contain '@', so its presence is enough to tell them apart.
+func DetectIdentifier(identifier string) IdentifierKind {
+	if strings.Contains(identifier, "@") {
+		return IdentifierEmail
+	}
+	return IdentifierUsername
+}
@@ -0,0 +1,69 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+// RandomDigits returns an n digit numeric code for email OTP
+func RandomDigits(n int) (string, error) {
+	var b strings.Builder
+	ten := big.NewInt(10)
+	for i := 0; i < n; i++ {
+		d, err := rand.Int(rand.Reader, ten)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + d.Int64()))
+	}
+	return b.String(), nil
+}
+
+// RandomToken returns a url-safe 256 bit token for magic links
+func RandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// userCodeAlphabet drops vowels and look-alike characters (RFC 8628 section 6.1)
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// RandomUserCode returns a device flow user code formatted as XXXX-XXXX
+func RandomUserCode() (string, error) {
+	n := big.NewInt(int64(len(userCodeAlphabet)))
+	var b strings.Builder
+	for i := 0; i < 8; i++ {
+		if i == 4 {
+			b.WriteByte('-')
+		}
+		d, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(userCodeAlphabet[d.Int64()])
+	}
+	return b.String(), nil
+}
+
+// NormalizeUserCode accepts user input like "bcdf ghjk" or "BCDF-GHJK"
+func NormalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// HashSecret hashes a code or link token before it is stored in Redis
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
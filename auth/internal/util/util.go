@@ -14,6 +14,14 @@ const (
 	RedisKeyTypeUserSids RedisKeyType = "user" // user:<uid>:sids
 	RedisKeyTypeSidSet   RedisKeyType = "sid"  //  sid:<sid>
 	RedisKeyTypeJtiSid   RedisKeyType = "jti_sid"
+
+	RedisKeyTypeLoginCode         RedisKeyType = "login_code"          // login_code:<email>
+	RedisKeyTypeLoginCodeAttempts RedisKeyType = "login_code_attempts" // login_code_attempts:<email>
+	RedisKeyTypeLoginCodeCooldown RedisKeyType = "login_code_cooldown" // login_code_cooldown:<email>
+	RedisKeyTypeLoginLink         RedisKeyType = "login_link"          // login_link:<sha256(token)>
+
+	RedisKeyTypeDevice         RedisKeyType = "device"           // device:<sha256(device_code)>
+	RedisKeyTypeDeviceUserCode RedisKeyType = "device_user_code" // device_user_code:<user_code>
 )
 
 func NormalizePrefix(p string) string {
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/retry"
+)
+
+const headerReplayedAt = "x-replayed-at"
+
+var dlqCommands = map[string]command{
+	"inspect": {usage: "list messages in <topic>.dlq with their failure headers", run: dlqInspect},
+	"replay":  {usage: "republish dlq messages to their original topic", run: dlqReplay},
+	"purge":   {usage: "delete dlq messages up to an offset", run: dlqPurge},
+}
+
+type kafkaFlags struct {
+	brokers string
+	env     string
+	stream  string
+	topic   string
+}
+
+func (f *kafkaFlags) register(fs *flag.FlagSet) {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		brokers = "localhost:9092"
+	}
+	fs.StringVar(&f.brokers, "brokers", brokers, "comma separated kafka brokers (env KAFKA_BROKERS)")
+	fs.StringVar(&f.env, "env", string(event.EnvDev), "topic env prefix, dev or prod")
+	fs.StringVar(&f.stream, "stream", string(event.StreamUserEvents), "event stream, e.g. "+string(event.StreamAuthEvents))
+	fs.StringVar(&f.topic, "topic", "", "main topic, overrides -env/-stream")
+}
+
+// baseTopic 与服务端一致通过 event.BuildTopics 得到主 topic
+func (f *kafkaFlags) baseTopic() string {
+	if f.topic != "" {
+		return f.topic
+	}
+	return event.BuildTopics(event.Env(f.env)).Topic(event.Stream(f.stream))
+}
+
+func (f *kafkaFlags) client() (sarama.Client, error) {
+	cfg := sarama.NewConfig()
+	cfg.Version = sarama.V2_1_0_0
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.Return.Successes = true
+	cfg.Net.DialTimeout = 10 * time.Second
+	return sarama.NewClient(strings.Split(f.brokers, ","), cfg)
+}
+
+// scan 依次读取 topic 各分区 [from, 当前高水位) 的消息；fn 返回 false 时停止
+func scan(client sarama.Client, topic string, partition int32, from int64, fn func(m *sarama.ConsumerMessage) (bool, error)) error {
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return err
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	for _, p := range partitions {
+		if partition >= 0 && p != partition {
+			continue
+		}
+		oldest, err := client.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return err
+		}
+		newest, err := client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		start := max(oldest, from)
+		if start >= newest {
+			continue
+		}
+		more, err := scanPartition(consumer, topic, p, start, newest, fn)
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+func scanPartition(consumer sarama.Consumer, topic string, partition int32, start, end int64, fn func(m *sarama.ConsumerMessage) (bool, error)) (bool, error) {
+	pc, err := consumer.ConsumePartition(topic, partition, start)
+	if err != nil {
+		return false, err
+	}
+	defer pc.Close()
+
+	for {
+		select {
+		case m := <-pc.Messages():
+			more, err := fn(m)
+			if err != nil || !more {
+				return more, err
+			}
+			if m.Offset >= end-1 {
+				return true, nil
+			}
+		case err := <-pc.Errors():
+			return false, err
+		case <-time.After(10 * time.Second):
+			// 高水位之前的 offset 可能因事务标记/压缩而不存在
+			return true, nil
+		}
+	}
+}
+
+func headerMap(m *sarama.ConsumerMessage) map[string]string {
+	h := make(map[string]string, len(m.Headers))
+	for _, rh := range m.Headers {
+		if rh != nil {
+			h[string(rh.Key)] = string(rh.Value)
+		}
+	}
+	return h
+}
+
+// peekHead 按 content-type header 选择编码解析 Envelope 元数据
+func peekHead(value []byte, h map[string]string) (*codec.EnvelopeHead, error) {
+	c, err := codec.FromHeaders(h)
+	if err != nil {
+		return nil, err
+	}
+	return c.PeekHead(value)
+}
+
+// redactedValue 返回脱敏后的 Envelope JSON；无法解码的消息不输出原文，只输出错误
+func redactedValue(value []byte, h map[string]string) string {
+	env, err := decodeRedacted(value, h)
+	if err != nil {
+		return fmt.Sprintf("<undecodable: %v>", err)
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Sprintf("<undecodable: %v>", err)
+	}
+	return string(b)
+}
+
+func dlqInspect(args []string) error {
+	var kf kafkaFlags
+	fs := flag.NewFlagSet("dlq inspect", flag.ExitOnError)
+	kf.register(fs)
+	partition := fs.Int("partition", -1, "only this partition, -1 for all")
+	from := fs.Int64("offset", 0, "start offset")
+	limit := fs.Int("n", 50, "max messages to print")
+	showValue := fs.Bool("value", false, "print the message value as JSON, pii fields redacted")
+	_ = fs.Parse(args)
+
+	client, err := kf.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	topic := event.DLQTopic(kf.baseTopic())
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "PARTITION\tOFFSET\tTIME\tKEY\tEVENT_TYPE\tEVENT_ID\tATTEMPTS\tORIGIN\tERROR")
+	printed := 0
+	err = scan(client, topic, int32(*partition), *from, func(m *sarama.ConsumerMessage) (bool, error) {
+		h := headerMap(m)
+		var eventType, eventID string
+		if head, err := peekHead(m.Value, h); err == nil {
+			eventType, eventID = head.EventType, head.EventID
+		}
+		origin := fmt.Sprintf("%s/%s@%s", h[retry.HeaderOriginalTopic], h[retry.HeaderOriginalPartition], h[retry.HeaderOriginalOffset])
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.Partition, m.Offset, m.Timestamp.UTC().Format(time.RFC3339), m.Key, eventType, eventID,
+			h[retry.HeaderAttempts], origin, h[retry.HeaderError])
+		if *showValue {
+			fmt.Fprintf(tw, "\t\t%s\n", redactedValue(m.Value, h))
+		}
+		printed++
+		return printed < *limit, nil
+	})
+	_ = tw.Flush()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d message(s) from %s\n", printed, topic)
+	return nil
+}
+
+func dlqReplay(args []string) error {
+	var kf kafkaFlags
+	fs := flag.NewFlagSet("dlq replay", flag.ExitOnError)
+	kf.register(fs)
+	partition := fs.Int("partition", -1, "only this partition, -1 for all")
+	from := fs.Int64("offset", 0, "start offset")
+	single := fs.Bool("single", false, "replay only the message at -partition/-offset")
+	eventID := fs.String("event-id", "", "only replay the message with this event id")
+	dryRun := fs.Bool("dry-run", false, "print what would be replayed")
+	_ = fs.Parse(args)
+
+	if *single && *partition < 0 {
+		return errors.New("-single requires -partition and -offset")
+	}
+
+	client, err := kf.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer producer.Close()
+
+	base := kf.baseTopic()
+	topic := event.DLQTopic(base)
+	replayed := 0
+	err = scan(client, topic, int32(*partition), *from, func(m *sarama.ConsumerMessage) (bool, error) {
+		if *single && m.Offset != *from {
+			return false, nil
+		}
+		if *eventID != "" {
+			head, err := peekHead(m.Value, headerMap(m))
+			if err != nil || head.EventID != *eventID {
+				return true, nil
+			}
+		}
+
+		h := headerMap(m)
+		target := h[retry.HeaderOriginalTopic]
+		if target == "" {
+			target = base
+		}
+		fmt.Printf("replay %s/%d@%d -> %s\n", topic, m.Partition, m.Offset, target)
+		if !*dryRun {
+			if _, _, err := producer.SendMessage(replayMessage(target, m, h)); err != nil {
+				return false, err
+			}
+		}
+		replayed++
+		return !*single, nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d message(s) replayed from %s\n", replayed, topic)
+	return nil
+}
+
+// replayMessage 保留原始位置与追踪 headers，清空重试计数让消息重新走完整的重试层级
+func replayMessage(target string, m *sarama.ConsumerMessage, h map[string]string) *sarama.ProducerMessage {
+	delete(h, retry.HeaderAttempts)
+	delete(h, retry.HeaderNotBefore)
+	delete(h, retry.HeaderError)
+	h[headerReplayedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	msg := &sarama.ProducerMessage{Topic: target, Value: sarama.ByteEncoder(m.Value)}
+	if len(m.Key) > 0 {
+		msg.Key = sarama.ByteEncoder(m.Key)
+	}
+	for k, v := range h {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+	return msg
+}
+
+func dlqPurge(args []string) error {
+	var kf kafkaFlags
+	fs := flag.NewFlagSet("dlq purge", flag.ExitOnError)
+	kf.register(fs)
+	partition := fs.Int("partition", -1, "only this partition, -1 for all")
+	before := fs.Int64("before", -1, "delete messages with offset < before, -1 for everything currently in the dlq")
+	yes := fs.Bool("yes", false, "confirm deletion")
+	_ = fs.Parse(args)
+
+	client, err := kf.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	topic := event.DLQTopic(kf.baseTopic())
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return err
+	}
+	offsets := make(map[int32]int64)
+	for _, p := range partitions {
+		if *partition >= 0 && p != int32(*partition) {
+			continue
+		}
+		newest, err := client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		offsets[p] = newest
+		if *before >= 0 && *before < newest {
+			offsets[p] = *before
+		}
+		fmt.Printf("purge %s/%d below offset %d\n", topic, p, offsets[p])
+	}
+	if len(offsets) == 0 {
+		return fmt.Errorf("no partition matched in %s", topic)
+	}
+	if !*yes {
+		fmt.Fprintln(os.Stderr, "dry run, pass -yes to delete")
+		return nil
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(client)
+	if err != nil {
+		return err
+	}
+	return admin.DeleteRecords(topic, offsets)
+}
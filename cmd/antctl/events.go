@@ -0,0 +1,518 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+
+	// 登记用户事件的 schema，protobuf / avro 编码的 payload 才能解码
+	_ "github.com/uwu-octane/antBackend/user/event"
+)
+
+const headerReplayedFrom = "x-replayed-from"
+
+var eventsCommands = map[string]command{
+	"tail":   {usage: "print (and -f follow) events of a topic, decoded by content-type", run: eventsTail},
+	"export": {usage: "write selected events as NDJSON", run: eventsExport},
+	"replay": {usage: "republish selected events to a topic or rewind a consumer group", run: eventsReplay},
+}
+
+// selection 选择 topic 中的一段消息：分区/起始 offset/时间窗口决定读取范围，事件类型与 key 过滤结果
+type selection struct {
+	kf        kafkaFlags
+	partition int
+	offset    int64
+	types     string
+	key       string
+	since     string
+	until     string
+
+	typeSet     map[string]bool
+	sinceTime   time.Time
+	untilTime   time.Time
+	idleTimeout time.Duration
+}
+
+func (s *selection) register(fs *flag.FlagSet) {
+	s.kf.register(fs)
+	fs.IntVar(&s.partition, "partition", -1, "only this partition, -1 for all")
+	fs.Int64Var(&s.offset, "offset", 0, "start offset")
+	fs.StringVar(&s.types, "type", "", "comma separated event types, e.g. user.updated,user.deleted")
+	fs.StringVar(&s.key, "key", "", "only messages with this partition key (user id for user events)")
+	fs.StringVar(&s.since, "since", "", "start time, RFC3339 or a duration before now such as 2h")
+	fs.StringVar(&s.until, "until", "", "end time, RFC3339 or a duration before now")
+}
+
+// parse 在 fs.Parse 之后校验并展开过滤条件
+func (s *selection) parse(now time.Time) error {
+	var err error
+	if s.sinceTime, err = parseTime(s.since, now); err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+	if s.untilTime, err = parseTime(s.until, now); err != nil {
+		return fmt.Errorf("-until: %w", err)
+	}
+	if !s.sinceTime.IsZero() && !s.untilTime.IsZero() && !s.untilTime.After(s.sinceTime) {
+		return errors.New("-until must be after -since")
+	}
+	s.typeSet = nil
+	for _, t := range strings.Split(s.types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			if s.typeSet == nil {
+				s.typeSet = make(map[string]bool)
+			}
+			s.typeSet[t] = true
+		}
+	}
+	s.idleTimeout = 10 * time.Second
+	return nil
+}
+
+// parseTime 接受 RFC3339 时间或相对 now 的时长，空字符串为零值
+func parseTime(v string, now time.Time) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q, want RFC3339 or a duration such as 30m", v)
+	}
+	return now.Add(-d), nil
+}
+
+// match 按 key、时间窗口与事件类型过滤，rec 已解码
+func (s *selection) match(rec *eventRecord) bool {
+	if s.key != "" && rec.Key != s.key {
+		return false
+	}
+	if !s.sinceTime.IsZero() && rec.Timestamp.Before(s.sinceTime) {
+		return false
+	}
+	if !s.untilTime.IsZero() && !rec.Timestamp.Before(s.untilTime) {
+		return false
+	}
+	if s.typeSet != nil && (rec.Envelope == nil || !s.typeSet[rec.Envelope.EventType]) {
+		return false
+	}
+	return true
+}
+
+// partitionRange 是一个分区待读取的 [start, end)，end < 0 表示持续读取
+type partitionRange struct {
+	partition  int32
+	start, end int64
+}
+
+// ranges 计算各分区的读取范围：-since 通过 broker 的时间索引定位起始 offset
+func (s *selection) ranges(client sarama.Client, topic string, follow bool) ([]partitionRange, error) {
+	partitions, err := client.Partitions(topic)
+	if err != nil {
+		return nil, err
+	}
+	var out []partitionRange
+	for _, p := range partitions {
+		if s.partition >= 0 && p != int32(s.partition) {
+			continue
+		}
+		oldest, err := client.GetOffset(topic, p, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+		newest, err := client.GetOffset(topic, p, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+		start := max(oldest, s.offset)
+		if !s.sinceTime.IsZero() {
+			at, err := client.GetOffset(topic, p, s.sinceTime.UnixMilli())
+			if err != nil {
+				return nil, err
+			}
+			// -1 表示该时间之后分区内没有消息
+			if at < 0 {
+				at = newest
+			}
+			start = max(start, at)
+		}
+		r := partitionRange{partition: p, start: start, end: newest}
+		if follow {
+			r.end = -1
+		} else if start >= newest {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// scan 并发读取各分区，在当前 goroutine 中按分区内顺序把匹配的消息交给 fn；fn 返回 false 时停止
+func (s *selection) scan(ctx context.Context, client sarama.Client, topic string, follow bool, fn func(rec *eventRecord, m *sarama.ConsumerMessage) (bool, error)) error {
+	ranges, err := s.ranges(client, topic, follow)
+	if err != nil {
+		return err
+	}
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		return err
+	}
+	defer consumer.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	msgs := make(chan *sarama.ConsumerMessage)
+	errs := make(chan error, len(ranges))
+	var wg sync.WaitGroup
+	for _, r := range ranges {
+		pc, err := consumer.ConsumePartition(topic, r.partition, r.start)
+		if err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func(r partitionRange) {
+			defer wg.Done()
+			defer pc.Close()
+			if err := s.readPartition(ctx, pc, r, msgs); err != nil {
+				errs <- err
+			}
+		}(r)
+	}
+	go func() {
+		wg.Wait()
+		close(msgs)
+	}()
+
+	for m := range msgs {
+		rec := decodeRecord(m)
+		if !s.match(rec) {
+			continue
+		}
+		more, err := fn(rec, m)
+		if err != nil {
+			return err
+		}
+		if !more {
+			cancel()
+			break
+		}
+	}
+	// 排空，确保读取 goroutine 都已退出
+	for range msgs {
+	}
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (s *selection) readPartition(ctx context.Context, pc sarama.PartitionConsumer, r partitionRange, out chan<- *sarama.ConsumerMessage) error {
+	var idle <-chan time.Time
+	for {
+		if r.end >= 0 {
+			// 高水位之前的 offset 可能因事务标记/压缩而不存在
+			idle = time.After(s.idleTimeout)
+		}
+		select {
+		case m := <-pc.Messages():
+			if !s.untilTime.IsZero() && !m.Timestamp.Before(s.untilTime) {
+				return nil
+			}
+			select {
+			case out <- m:
+			case <-ctx.Done():
+				return nil
+			}
+			if r.end >= 0 && m.Offset >= r.end-1 {
+				return nil
+			}
+		case err := <-pc.Errors():
+			return err
+		case <-idle:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// eventRecord 是 export 输出的一行；解码失败时 Envelope 为空并记录 DecodeError
+type eventRecord struct {
+	Topic       string               `json:"topic"`
+	Partition   int32                `json:"partition"`
+	Offset      int64                `json:"offset"`
+	Timestamp   time.Time            `json:"timestamp"`
+	Key         string               `json:"key,omitempty"`
+	Headers     map[string]string    `json:"headers,omitempty"`
+	Envelope    *event.Envelope[any] `json:"envelope,omitempty"`
+	DecodeError string               `json:"decode_error,omitempty"`
+}
+
+// decodeRecord 按 content-type 选择编码解码 Envelope，payload 按写入时的版本输出（不 upcast、不解密），
+// 带 pii tag 的字段经 codec.Redact 脱敏
+func decodeRecord(m *sarama.ConsumerMessage) *eventRecord {
+	h := headerMap(m)
+	rec := &eventRecord{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Timestamp: m.Timestamp,
+		Key:       string(m.Key),
+		Headers:   h,
+	}
+	env, err := decodeRedacted(m.Value, h)
+	if err != nil {
+		rec.DecodeError = err.Error()
+	}
+	rec.Envelope = env
+	return rec
+}
+
+// decodeRedacted 解码消息并脱敏，运维工具不输出 PII 明文
+func decodeRedacted(value []byte, h map[string]string) (*event.Envelope[any], error) {
+	c, err := codec.FromHeaders(h)
+	if err != nil {
+		return nil, err
+	}
+	env, err := c.Decode(value)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Redact(env)
+}
+
+// signalContext 在 SIGINT / SIGTERM 时取消
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func eventsTail(args []string) error {
+	var sel selection
+	fs := flag.NewFlagSet("events tail", flag.ExitOnError)
+	sel.register(fs)
+	follow := fs.Bool("f", false, "keep following new messages until interrupted")
+	limit := fs.Int("n", 50, "max messages to print, 0 for no limit")
+	asJSON := fs.Bool("json", false, "print each event as a JSON line")
+	_ = fs.Parse(args)
+	if err := sel.parse(time.Now()); err != nil {
+		return err
+	}
+
+	client, err := sel.kf.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, stop := signalContext()
+	defer stop()
+
+	topic := sel.kf.baseTopic()
+	enc := json.NewEncoder(os.Stdout)
+	printed := 0
+	err = sel.scan(ctx, client, topic, *follow, func(rec *eventRecord, _ *sarama.ConsumerMessage) (bool, error) {
+		if *asJSON {
+			if err := enc.Encode(rec); err != nil {
+				return false, err
+			}
+		} else {
+			printSummary(os.Stdout, rec)
+		}
+		printed++
+		return *limit <= 0 || printed < *limit, nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d event(s) from %s\n", printed, topic)
+	return nil
+}
+
+func printSummary(w io.Writer, rec *eventRecord) {
+	var eventType, eventID string
+	if rec.Envelope != nil {
+		eventType, eventID = rec.Envelope.EventType, rec.Envelope.EventID
+	} else {
+		eventType = "!" + rec.DecodeError
+	}
+	fmt.Fprintf(w, "%s  %d@%d  key=%s  %s  %s\n",
+		rec.Timestamp.UTC().Format(time.RFC3339), rec.Partition, rec.Offset, rec.Key, eventType, eventID)
+}
+
+func eventsExport(args []string) error {
+	var sel selection
+	fs := flag.NewFlagSet("events export", flag.ExitOnError)
+	sel.register(fs)
+	output := fs.String("o", "-", "output file, - for stdout")
+	limit := fs.Int("n", 0, "max events to export, 0 for no limit")
+	_ = fs.Parse(args)
+	if err := sel.parse(time.Now()); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	client, err := sel.kf.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	ctx, stop := signalContext()
+	defer stop()
+
+	topic := sel.kf.baseTopic()
+	enc := json.NewEncoder(bw)
+	exported := 0
+	err = sel.scan(ctx, client, topic, false, func(rec *eventRecord, _ *sarama.ConsumerMessage) (bool, error) {
+		if err := enc.Encode(rec); err != nil {
+			return false, err
+		}
+		exported++
+		return *limit <= 0 || exported < *limit, nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d event(s) exported from %s\n", exported, topic)
+	return nil
+}
+
+func eventsReplay(args []string) error {
+	var sel selection
+	fs := flag.NewFlagSet("events replay", flag.ExitOnError)
+	sel.register(fs)
+	target := fs.String("target", "", "topic to republish to, defaults to the source topic")
+	group := fs.String("group", "", "rewind this consumer group to the selected range instead of republishing")
+	limit := fs.Int("n", 0, "max events to replay, 0 for no limit")
+	yes := fs.Bool("yes", false, "actually publish / commit, otherwise only print the plan")
+	_ = fs.Parse(args)
+	if err := sel.parse(time.Now()); err != nil {
+		return err
+	}
+
+	client, err := sel.kf.client()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	topic := sel.kf.baseTopic()
+	if *group != "" {
+		if sel.typeSet != nil || sel.key != "" || !sel.untilTime.IsZero() {
+			return errors.New("-group rewinds whole partitions, -type/-key/-until are not supported")
+		}
+		return rewindGroup(client, &sel, topic, *group, *yes)
+	}
+
+	if *target == "" {
+		*target = topic
+	}
+	var producer sarama.SyncProducer
+	if *yes {
+		if producer, err = sarama.NewSyncProducerFromClient(client); err != nil {
+			return err
+		}
+		defer producer.Close()
+	}
+	ctx, stop := signalContext()
+	defer stop()
+
+	replayed := 0
+	err = sel.scan(ctx, client, topic, false, func(rec *eventRecord, m *sarama.ConsumerMessage) (bool, error) {
+		fmt.Printf("replay %s/%d@%d -> %s\n", topic, m.Partition, m.Offset, *target)
+		if producer != nil {
+			if _, _, err := producer.SendMessage(republishMessage(*target, m, rec.Headers)); err != nil {
+				return false, err
+			}
+		}
+		replayed++
+		return *limit <= 0 || replayed < *limit, nil
+	})
+	if err != nil {
+		return err
+	}
+	if producer == nil {
+		fmt.Fprintf(os.Stderr, "dry run, %d event(s) selected, pass -yes to publish\n", replayed)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "%d event(s) replayed from %s to %s\n", replayed, topic, *target)
+	return nil
+}
+
+// republishMessage 原样保留 value（因此 EventID 不变，消费侧去重仍然生效）、key 与 headers，并记录来源位置
+func republishMessage(target string, m *sarama.ConsumerMessage, h map[string]string) *sarama.ProducerMessage {
+	h[headerReplayedAt] = time.Now().UTC().Format(time.RFC3339)
+	h[headerReplayedFrom] = fmt.Sprintf("%s/%d@%d", m.Topic, m.Partition, m.Offset)
+
+	msg := &sarama.ProducerMessage{Topic: target, Value: sarama.ByteEncoder(m.Value)}
+	if len(m.Key) > 0 {
+		msg.Key = sarama.ByteEncoder(m.Key)
+	}
+	for k, v := range h {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+	return msg
+}
+
+// rewindGroup 把消费组在各分区的提交位置移到选择范围的起点；消费组需处于停止状态，否则会被在线成员覆盖
+func rewindGroup(client sarama.Client, sel *selection, topic, group string, yes bool) error {
+	ranges, err := sel.ranges(client, topic, true)
+	if err != nil {
+		return err
+	}
+	if len(ranges) == 0 {
+		return fmt.Errorf("no partition matched in %s", topic)
+	}
+	for _, r := range ranges {
+		fmt.Printf("rewind %s %s/%d to offset %d\n", group, topic, r.partition, r.start)
+	}
+	if !yes {
+		fmt.Fprintln(os.Stderr, "dry run, pass -yes to commit")
+		return nil
+	}
+
+	om, err := sarama.NewOffsetManagerFromClient(group, client)
+	if err != nil {
+		return err
+	}
+	for _, r := range ranges {
+		pom, err := om.ManagePartition(topic, r.partition)
+		if err != nil {
+			_ = om.Close()
+			return err
+		}
+		pom.ResetOffset(r.start, "rewound by antctl")
+		if err := pom.Close(); err != nil {
+			_ = om.Close()
+			return err
+		}
+	}
+	om.Commit()
+	return om.Close()
+}
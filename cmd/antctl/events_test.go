@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseTime("2h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-2*time.Hour), got)
+
+	got, err = parseTime("2025-05-31T08:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 5, 31, 8, 0, 0, 0, time.UTC), got)
+
+	got, err = parseTime("", now)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	_, err = parseTime("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestSelection_Match(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	sel := selection{types: "order.created, auth.login.failed", key: "u-1", since: "1h"}
+	require.NoError(t, sel.parse(now))
+
+	body, err := codec.MarshalEnvelope(&event.Envelope[event.OrderCreatedEvent]{
+		EventType: event.EventTypeOrderCreated, EventVersion: 1, EventID: "evt-1", Data: event.OrderCreatedEvent{OrderID: "o-1"},
+	})
+	require.NoError(t, err)
+	rec := decodeRecord(&sarama.ConsumerMessage{Topic: "dev.order.service.order-events", Key: []byte("u-1"), Value: body, Timestamp: now.Add(-time.Minute)})
+	require.NotNil(t, rec.Envelope)
+	assert.Equal(t, "evt-1", rec.Envelope.EventID)
+	assert.True(t, sel.match(rec))
+
+	other := *rec
+	other.Key = "u-2"
+	assert.False(t, sel.match(&other), "key")
+	other = *rec
+	other.Timestamp = now.Add(-2 * time.Hour)
+	assert.False(t, sel.match(&other), "since")
+
+	broken := decodeRecord(&sarama.ConsumerMessage{Key: []byte("u-1"), Value: []byte("not json"), Timestamp: now})
+	assert.NotEmpty(t, broken.DecodeError)
+	assert.False(t, sel.match(broken), "type filter needs a decoded envelope")
+}
+
+func TestDecodeRecord_Redacts(t *testing.T) {
+	body, err := codec.MarshalEnvelope(event.NewEnvelope(event.EventTypeAuthLoginFailed, 1, "auth.rpc", "", event.AuthLoginFailedEvent{
+		Identifier: "dave@example.com", Method: event.AuthMethodPassword, Reason: "bad_password",
+	}))
+	require.NoError(t, err)
+
+	rec := decodeRecord(&sarama.ConsumerMessage{Value: body})
+	require.Empty(t, rec.DecodeError)
+	assert.Equal(t, &event.AuthLoginFailedEvent{Identifier: codec.Redacted, Method: event.AuthMethodPassword, Reason: "bad_password"}, rec.Envelope.Data)
+	assert.NotContains(t, redactedValue(body, nil), "dave@example.com")
+}
+
+func TestSelection_RejectsInvertedWindow(t *testing.T) {
+	sel := selection{since: "1h", until: "2h"}
+	assert.Error(t, sel.parse(time.Now()))
+}
+
+func TestRepublishMessage_KeepsValue(t *testing.T) {
+	m := &sarama.ConsumerMessage{Topic: "dev.user.service.user-events", Partition: 2, Offset: 9, Key: []byte("u-1"), Value: []byte(`{"event_id":"evt-1"}`)}
+	msg := republishMessage("dev.user.service.user-events.replay", m, map[string]string{"traceparent": "tp"})
+
+	value, err := msg.Value.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, m.Value, value)
+	headers := make(map[string]string)
+	for _, h := range msg.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	assert.Equal(t, "tp", headers["traceparent"])
+	assert.Equal(t, "dev.user.service.user-events/2@9", headers[headerReplayedFrom])
+}
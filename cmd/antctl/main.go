@@ -0,0 +1,68 @@
+// cmd/antctl/main.go
+// antctl 运维命令行：antctl <group> <command> [flags]
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/common/envloader"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+// groups 命令分组，例如 dlq inspect / dlq replay / dlq purge、events tail / events replay
+var groups = map[string]map[string]command{
+	"dlq":    dlqCommands,
+	"events": eventsCommands,
+}
+
+func main() {
+	envloader.Load()
+
+	if len(os.Args) < 3 {
+		usage()
+		os.Exit(2)
+	}
+	cmds, ok := groups[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := cmds[os.Args[2]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[3:]); err != nil {
+		fmt.Fprintf(os.Stderr, "antctl %s %s: %v\n", os.Args[1], os.Args[2], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	var b strings.Builder
+	b.WriteString("usage: antctl <group> <command> [flags]\n\n")
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmds := make([]string, 0, len(groups[name]))
+		for c := range groups[name] {
+			cmds = append(cmds, c)
+		}
+		sort.Strings(cmds)
+		for _, c := range cmds {
+			fmt.Fprintf(&b, "  %-16s %s\n", name+" "+c, groups[name][c].usage)
+		}
+	}
+	b.WriteString("\nrun antctl <group> <command> -h for flags\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
@@ -0,0 +1,65 @@
+// cmd/asyncapi/main.go
+// 生成事件目录 docs/openapi/asyncapi.json（gateway 在 /schema 下与 openapi.json 一起提供）。
+// 在仓库根目录运行：go run ./cmd/asyncapi；CI 中使用 -check，生成物与已提交文件不一致时失败。
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/asyncapi"
+
+	// 登记 user 服务的事件 schema 与路由
+	_ "github.com/uwu-octane/antBackend/user/event"
+)
+
+func main() {
+	out := flag.String("o", "docs/openapi/asyncapi.json", "output file")
+	check := flag.Bool("check", false, "fail if the output file is stale instead of writing it")
+	src := flag.String("src", "common/eventbus/event,user/event", "comma separated source dirs to read type and field comments from")
+	flag.Parse()
+
+	if err := run(*out, *check, strings.Split(*src, ",")); err != nil {
+		fmt.Fprintf(os.Stderr, "asyncapi: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(out string, check bool, dirs []string) error {
+	docs, err := asyncapi.ParseDocs(dirs...)
+	if err != nil {
+		return err
+	}
+	doc, err := asyncapi.Build(asyncapi.Options{
+		Title:       "antBackend events",
+		Description: "Catalog of every event type published on the event bus, generated from the schema registry by cmd/asyncapi.",
+		Docs:        docs,
+		Avro:        true,
+	})
+	if err != nil {
+		return err
+	}
+	b, err := doc.JSON()
+	if err != nil {
+		return err
+	}
+
+	if check {
+		cur, err := os.ReadFile(out)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(cur, b) {
+			return fmt.Errorf("%s is stale, run `go run ./cmd/asyncapi` and commit the result", out)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(out, b, 0o644)
+}
@@ -12,9 +12,10 @@ import (
 
 	"github.com/uwu-octane/antBackend/common/envloader"
 
-	// 导入三个 BuildXxxServer
+	// 导入各服务的 BuildXxxServer
 	antAuth "github.com/uwu-octane/antBackend/auth/app"
 	antGateway "github.com/uwu-octane/antBackend/gateway/app" // BuildGatewayServer 在 package main 时可用别名导入
+	antOrder "github.com/uwu-octane/antBackend/order/app"
 	antUser "github.com/uwu-octane/antBackend/user/app"
 )
 
@@ -22,6 +23,7 @@ var (
 	gatewayConf = flag.String("gateway", "gateway/etc/gateway-api.yaml", "gateway config file")
 	authConf    = flag.String("auth", "auth/etc/auth.yaml", "auth rpc config file")
 	userConf    = flag.String("user", "user/etc/user.yaml", "user rpc config file")
+	orderConf   = flag.String("order", "order/etc/order.yaml", "order rpc config file")
 )
 
 func mustFile(path string) string {
@@ -47,10 +49,12 @@ func main() {
 	gatewayCfg := mustFile(*gatewayConf)
 	authCfg := mustFile(*authConf)
 	userCfg := mustFile(*userConf)
+	orderCfg := mustFile(*orderConf)
 
 	configs["gateway"] = gatewayCfg
 	configs["auth"] = authCfg
 	configs["user"] = userCfg
+	configs["order"] = orderCfg
 	group := service.NewServiceGroup()
 
 	// 1) gateway
@@ -77,6 +81,14 @@ func main() {
 	defer safeCleanup("user", userCleanup)
 	group.Add(userSrv)
 
+	// 4) order rpc
+	orderSrv, orderCleanup, err := antOrder.BuildOrderRpcServer(orderCfg)
+	if err != nil {
+		log.Fatalf("build order rpc: %v", err)
+	}
+	defer safeCleanup("order", orderCleanup)
+	group.Add(orderSrv)
+
 	//start and stop
 	printServerInfo(configs)
 	defer group.Stop()
@@ -3,9 +3,12 @@ module github.com/uwu-octane/antBackend/cmd
 go 1.25.2
 
 require (
+	github.com/Shopify/sarama v1.37.2
+	github.com/stretchr/testify v1.11.1
 	github.com/uwu-octane/antBackend/auth v0.0.0
 	github.com/uwu-octane/antBackend/common v0.0.0
 	github.com/uwu-octane/antBackend/gateway v0.0.0
+	github.com/uwu-octane/antBackend/order v0.0.0
 	github.com/uwu-octane/antBackend/user v0.0.0
 	github.com/zeromicro/go-zero v1.9.1
 )
@@ -124,6 +127,8 @@ replace github.com/uwu-octane/antBackend/common => ../common
 
 replace github.com/uwu-octane/antBackend/gateway => ../gateway
 
+replace github.com/uwu-octane/antBackend/order => ../order
+
 replace github.com/uwu-octane/antBackend/user => ../user
 
 replace github.com/uwu-octane/antBackend/api => ../api
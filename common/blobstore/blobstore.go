@@ -0,0 +1,62 @@
+// Package blobstore 保存上传的二进制对象（如头像），对外只暴露对象 key 与公开访问 URL。
+// 提供本地文件系统与 S3 兼容（AWS S3、MinIO 等）两种实现，由 Conf.Type 选择
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	TypeLocal = "local"
+	TypeS3    = "s3"
+)
+
+// ErrInvalidKey key 为空、以 / 开头或包含 .. 等路径片段
+var ErrInvalidKey = errors.New("blobstore: invalid key")
+
+// Store 按 key 保存对象；key 使用 / 分隔，例如 avatars/<uid>/<id>/256.jpg
+type Store interface {
+	// Put 写入（覆盖）对象
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete 删除对象，对象不存在时返回 nil
+	Delete(ctx context.Context, key string) error
+	// URL 返回对象的公开访问地址
+	URL(key string) string
+}
+
+// Conf 选择并配置 Store
+type Conf struct {
+	Type  string    `json:",default=local,options=local|s3"`
+	Local LocalConf `json:",optional"`
+	S3    S3Conf    `json:",optional"`
+}
+
+// New 按 c.Type 创建 Store
+func New(c Conf) (Store, error) {
+	switch c.Type {
+	case TypeLocal, "":
+		return NewLocal(c.Local)
+	case TypeS3:
+		return NewS3(c.S3)
+	default:
+		return nil, fmt.Errorf("blobstore: unknown type %q", c.Type)
+	}
+}
+
+// checkKey 拒绝会逃出存储根目录或 bucket 前缀的 key
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == "." ||
+		strings.HasPrefix(key, "../") || key == ".." {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// joinURL 拼接 base 与 key，base 末尾的 / 可有可无
+func joinURL(base, key string) string {
+	return strings.TrimSuffix(base, "/") + "/" + key
+}
@@ -0,0 +1,69 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalConf 本地文件系统存储：对象写到 Dir/<key>，BaseURL 为对外提供 Dir 的静态文件地址
+type LocalConf struct {
+	Dir     string `json:",default=./data/blobs"`
+	BaseURL string `json:",default=/blobs"`
+}
+
+type localStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocal(c LocalConf) (Store, error) {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &localStore{dir: c.Dir, baseURL: c.BaseURL}, nil
+}
+
+// Put 先写临时文件再 rename，读者不会看到写了一半的对象
+func (s *localStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *localStore) Delete(_ context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *localStore) URL(key string) string {
+	return joinURL(s.baseURL, key)
+}
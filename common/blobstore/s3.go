@@ -0,0 +1,220 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Conf S3 兼容存储（AWS S3、MinIO 等），请求使用 AWS Signature V4 签名。
+// PathStyle 为 true 时请求 <Endpoint>/<Bucket>/<key>（MinIO 的默认方式），否则 <scheme>://<Bucket>.<host>/<key>；
+// PublicURL 为对外访问地址（如 CDN），为空时使用对象的请求地址
+type S3Conf struct {
+	Endpoint  string `json:",optional"`
+	Region    string `json:",default=us-east-1"`
+	Bucket    string `json:",optional"`
+	AccessKey string `json:",optional"`
+	SecretKey string `json:",optional"`
+	PathStyle bool   `json:",default=true"`
+	PublicURL string `json:",optional"`
+	TimeoutMs int    `json:",default=10000"`
+}
+
+type s3Store struct {
+	conf   S3Conf
+	base   *url.URL // 对象 key 拼接在 base.Path 之后
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3(c S3Conf) (Store, error) {
+	if c.Endpoint == "" || c.Bucket == "" {
+		return nil, errors.New("blobstore: s3 Endpoint and Bucket are required")
+	}
+	base, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("blobstore: invalid s3 Endpoint: %w", err)
+	}
+	if c.PathStyle {
+		base.Path = strings.TrimSuffix(base.Path, "/") + "/" + c.Bucket
+	} else {
+		base.Host = c.Bucket + "." + base.Host
+	}
+	return &s3Store{
+		conf:   c,
+		base:   base,
+		client: &http.Client{Timeout: time.Duration(c.TimeoutMs) * time.Millisecond},
+		now:    time.Now,
+	}, nil
+}
+
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error("put", key, resp)
+	}
+	return nil
+}
+
+func (s *s3Store) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return s3Error("delete", key, resp)
+	}
+}
+
+func (s *s3Store) URL(key string) string {
+	if s.conf.PublicURL != "" {
+		return joinURL(s.conf.PublicURL, key)
+	}
+	return s.objectURL(key)
+}
+
+func (s *s3Store) objectURL(key string) string {
+	u := *s.base
+	u.Path = u.Path + "/" + key
+	u.RawPath = escapePath(u.Path)
+	return u.String()
+}
+
+func (s *s3Store) do(req *http.Request, body []byte) (*http.Response, error) {
+	sum := sha256.Sum256(body)
+	signV4(req, hex.EncodeToString(sum[:]), s.conf.AccessKey, s.conf.SecretKey, s.conf.Region, s.now())
+	return s.client.Do(req)
+}
+
+// s3Error 读取 S3 的 XML 错误体（截断）作为错误信息
+func s3Error(op, key string, resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("blobstore: s3 %s %s: %s: %s", op, key, resp.Status, bytes.TrimSpace(msg))
+}
+
+// signV4 按 AWS Signature V4 给请求加上 x-amz-date、x-amz-content-sha256 与 Authorization，
+// 签名覆盖 host 与请求上已有的全部 header
+func signV4(req *http.Request, payloadHash, accessKey, secretKey, region string, now time.Time) {
+	const service = "s3"
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapePath(req.URL.Path),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escapePath 按 SigV4 规则逐段编码路径，保留 /
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		segments[i] = escape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape 对 RFC 3986 非保留字符以外的字节做百分号编码
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+// Package dataloader 把短时间窗口内的并发单键查询合并成一次批量查询（DataLoader 模式），
+// 用于 fan-out 调用方按 ID 逐个取数据时减少 RPC 与数据库往返
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultWait     = 2 * time.Millisecond
+	defaultMaxBatch = 100
+	defaultTimeout  = 5 * time.Second
+)
+
+// ErrNotFound 表示批量查询的结果中没有该键
+var ErrNotFound = errors.New("dataloader: key not found")
+
+// BatchFunc 一次查询 keys（已去重）；结果中缺失的键由 Load 返回 ErrNotFound
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type Option func(*options)
+
+type options struct {
+	wait     time.Duration
+	maxBatch int
+	timeout  time.Duration
+}
+
+// WithWait 设置第一个请求到达后收集后续请求的时间窗口，默认 2ms
+func WithWait(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.wait = d
+		}
+	}
+}
+
+// WithMaxBatch 设置单批键数上限，达到上限立即发出查询，默认 100
+func WithMaxBatch(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxBatch = n
+		}
+	}
+}
+
+// WithTimeout 设置单次 BatchFunc 的超时，默认 5s；批次不随调用方取消，超时保证它不会无限挂起
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// Loader 收集并发的 Load 调用，窗口结束或批次满时用一次 BatchFunc 查询
+type Loader[K comparable, V any] struct {
+	fetch BatchFunc[K, V]
+	opts  options
+
+	mu    sync.Mutex
+	batch *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	// ctx 来自批次的第一个调用方（保留其 trace 等值），去掉了取消信号与截止时间：
+	// 单个调用方放弃等待不影响同批的其他调用方，批次的截止时间由 WithTimeout 决定
+	ctx     context.Context
+	keys    []K
+	seen    map[K]struct{}
+	done    chan struct{}
+	results map[K]V
+	err     error
+}
+
+func New[K comparable, V any](fetch BatchFunc[K, V], opts ...Option) *Loader[K, V] {
+	o := options{wait: defaultWait, maxBatch: defaultMaxBatch, timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Loader[K, V]{fetch: fetch, opts: o}
+}
+
+// Load 把 key 加入当前批次并等待结果；ctx 结束时立即返回 ctx.Err()，批次照常执行
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	b := l.add(ctx, key)
+
+	var zero V
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+	if b.err != nil {
+		return zero, b.err
+	}
+	v, ok := b.results[key]
+	if !ok {
+		return zero, ErrNotFound
+	}
+	return v, nil
+}
+
+func (l *Loader[K, V]) add(ctx context.Context, key K) *batch[K, V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.batch
+	if b == nil {
+		b = &batch[K, V]{
+			ctx:  context.WithoutCancel(ctx),
+			seen: make(map[K]struct{}),
+			done: make(chan struct{}),
+		}
+		l.batch = b
+		time.AfterFunc(l.opts.wait, func() { l.dispatchIfCurrent(b) })
+	}
+	if _, ok := b.seen[key]; !ok {
+		b.seen[key] = struct{}{}
+		b.keys = append(b.keys, key)
+	}
+	if len(b.keys) >= l.opts.maxBatch {
+		// 批次已满，之后的调用进入新批次；窗口定时器触发时发现批次已换掉，不再重复执行
+		l.batch = nil
+		go l.run(b)
+	}
+	return b
+}
+
+func (l *Loader[K, V]) dispatchIfCurrent(b *batch[K, V]) {
+	l.mu.Lock()
+	if l.batch != b {
+		l.mu.Unlock()
+		return
+	}
+	l.batch = nil
+	l.mu.Unlock()
+	l.run(b)
+}
+
+func (l *Loader[K, V]) run(b *batch[K, V]) {
+	defer close(b.done)
+	// run 在定时器或独立 goroutine 中执行，panic 不能逃出去，转成 error 交给同批的所有调用方
+	defer func() {
+		if p := recover(); p != nil {
+			b.results, b.err = nil, fmt.Errorf("dataloader: batch func panic: %v", p)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(b.ctx, l.opts.timeout)
+	defer cancel()
+	b.results, b.err = l.fetch(ctx, b.keys)
+}
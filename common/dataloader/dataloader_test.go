@@ -0,0 +1,154 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFetch returns "v<key>" for every key except the missing ones and records each batch
+type recordingFetch struct {
+	mu      sync.Mutex
+	batches [][]int
+	missing map[int]bool
+}
+
+func (f *recordingFetch) fetch(_ context.Context, keys []int) (map[int]string, error) {
+	f.mu.Lock()
+	f.batches = append(f.batches, append([]int(nil), keys...))
+	f.mu.Unlock()
+	out := make(map[int]string, len(keys))
+	for _, k := range keys {
+		if !f.missing[k] {
+			out[k] = "v" + strconv.Itoa(k)
+		}
+	}
+	return out, nil
+}
+
+func loadAll(t *testing.T, l *Loader[int, string], keys []int) ([]string, []error) {
+	t.Helper()
+	vals := make([]string, len(keys))
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, k := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vals[i], errs[i] = l.Load(context.Background(), k)
+		}()
+	}
+	wg.Wait()
+	return vals, errs
+}
+
+func TestLoader_CollectsConcurrentLoads(t *testing.T) {
+	f := &recordingFetch{missing: map[int]bool{3: true}}
+	l := New(f.fetch, WithWait(20*time.Millisecond))
+
+	vals, errs := loadAll(t, l, []int{1, 2, 3, 2})
+
+	require.Len(t, f.batches, 1)
+	assert.ElementsMatch(t, []int{1, 2, 3}, f.batches[0], "keys are deduplicated")
+	assert.Equal(t, []string{"v1", "v2", "", "v2"}, vals)
+	assert.NoError(t, errs[0])
+	assert.ErrorIs(t, errs[2], ErrNotFound)
+}
+
+func TestLoader_MaxBatch(t *testing.T) {
+	f := &recordingFetch{}
+	l := New(f.fetch, WithWait(time.Hour), WithMaxBatch(2))
+
+	vals, errs := loadAll(t, l, []int{1, 2, 3, 4})
+
+	for i, err := range errs {
+		require.NoError(t, err)
+		assert.Equal(t, "v"+strconv.Itoa(i+1), vals[i])
+	}
+	require.Len(t, f.batches, 2, "full batches are sent without waiting for the window")
+	for _, b := range f.batches {
+		assert.Len(t, b, 2)
+	}
+}
+
+func TestLoader_BatchError(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+	l := New(func(context.Context, []int) (map[int]string, error) {
+		calls.Add(1)
+		return nil, boom
+	})
+
+	_, errs := loadAll(t, l, []int{1, 2})
+	for _, err := range errs {
+		assert.ErrorIs(t, err, boom)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestLoader_CallerCancelDoesNotCancelBatch(t *testing.T) {
+	release := make(chan struct{})
+	l := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		<-release
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return map[int]string{1: "v1"}, nil
+	}, WithWait(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := l.Load(ctx, 1)
+		first <- err
+	}()
+	second := make(chan string, 1)
+	go func() {
+		time.Sleep(time.Millisecond)
+		v, _ := l.Load(context.Background(), 1)
+		second <- v
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+	close(release)
+	assert.Equal(t, "v1", <-second)
+}
+
+func TestLoader_PanicReachesAllWaiters(t *testing.T) {
+	l := New(func(context.Context, []int) (map[int]string, error) {
+		panic("bad row")
+	}, WithWait(10*time.Millisecond))
+
+	_, errs := loadAll(t, l, []int{1, 2, 3})
+	for _, err := range errs {
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "bad row")
+	}
+
+	// the loader keeps working after a panicking batch
+	l.fetch = (&recordingFetch{}).fetch
+	v, err := l.Load(context.Background(), 4)
+	require.NoError(t, err)
+	assert.Equal(t, "v4", v)
+}
+
+func TestLoader_BatchTimeout(t *testing.T) {
+	l := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, WithTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	_, err := l.Load(context.Background(), 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
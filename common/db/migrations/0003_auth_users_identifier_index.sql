@@ -0,0 +1,8 @@
+-- +goose Up
+-- login accepts either email or username, compared case-insensitively
+create unique index if not exists auth_users_email_lower_key on auth_users (lower(email));
+create unique index if not exists auth_users_username_lower_key on auth_users (lower(username)) where username is not null;
+
+-- +goose Down
+drop index if exists auth_users_username_lower_key;
+drop index if exists auth_users_email_lower_key;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- role gates admin-only rpcs such as impersonation
+-- admins are provisioned explicitly, see docs/project-overview.md
+alter table auth_users add column if not exists role varchar(32) not null default 'user';
+
+-- +goose Down
+alter table auth_users drop column if exists role;
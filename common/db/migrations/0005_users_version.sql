@@ -0,0 +1,6 @@
+-- +goose Up
+-- optimistic concurrency for profile writes, bumped by every UPDATE from user.rpc
+alter table users add column if not exists version bigint not null default 1;
+
+-- +goose Down
+alter table users drop column if exists version;
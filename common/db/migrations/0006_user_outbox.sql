@@ -0,0 +1,25 @@
+-- +goose Up
+-- transactional outbox for user events: rows are written in the same transaction as the users change
+-- and relayed to kafka by the outbox worker in user.rpc
+create table if not exists user_outbox (
+    id bigserial primary key,
+    event_id varchar(64) not null,
+    event_type varchar(128) not null,
+    partition_key varchar(256) not null default '',
+    payload jsonb not null,
+    headers jsonb not null default '{}',
+    attempts integer not null default 0,
+    last_error text,
+    next_attempt_at timestamp with time zone not null default now(),
+    created_at timestamp with time zone not null default now(),
+    sent_at timestamp with time zone
+);
+
+-- pending rows in relay order, and the per-key lookup that keeps events of one user in order
+create index if not exists user_outbox_pending_idx on user_outbox (id) where sent_at is null;
+create index if not exists user_outbox_pending_key_idx on user_outbox (partition_key, id) where sent_at is null;
+-- retention cleanup of relayed rows
+create index if not exists user_outbox_sent_at_idx on user_outbox (sent_at) where sent_at is not null;
+
+-- +goose Down
+drop table if exists user_outbox;
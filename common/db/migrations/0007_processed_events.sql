@@ -0,0 +1,13 @@
+-- +goose Up
+-- consumer side dedup for the eventbus PostgresStore, one row per (consumer group, event id)
+create table if not exists processed_events (
+    consumer_group varchar(128) not null,
+    event_id varchar(64) not null,
+    processed_at timestamp with time zone not null default now(),
+    primary key (consumer_group, event_id)
+);
+
+create index if not exists processed_events_processed_at_idx on processed_events (processed_at);
+
+-- +goose Down
+drop table if exists processed_events;
@@ -0,0 +1,56 @@
+-- +goose Up
+-- orders of order.rpc: user_id is the users / auth_users id, there is no foreign key across services
+create table if not exists orders (
+    id ulid primary key default gen_ulid(),
+    user_id ulid not null,
+    amount bigint not null check (amount > 0),
+    currency char(3) not null,
+    status varchar(32) not null default 'pending',
+    version bigint not null default 1,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+-- ListOrders pages one user's orders newest first
+create index if not exists orders_user_id_idx on orders (user_id, id desc);
+
+-- +goose StatementBegin
+create or replace function trg_orders_set_updated_at()
+returns trigger as $$
+begin
+    new.updated_at = now();
+    return new;
+end;
+$$ language 'plpgsql';
+-- +goose StatementEnd
+
+drop trigger if exists orders_set_updated_at on orders;
+create trigger orders_set_updated_at
+before update on orders
+for each row
+execute function trg_orders_set_updated_at();
+
+-- transactional outbox for order events, relayed to kafka by the outbox worker in order.rpc
+create table if not exists order_outbox (
+    id bigserial primary key,
+    event_id varchar(64) not null,
+    event_type varchar(128) not null,
+    partition_key varchar(256) not null default '',
+    payload jsonb not null,
+    headers jsonb not null default '{}',
+    attempts integer not null default 0,
+    last_error text,
+    next_attempt_at timestamp with time zone not null default now(),
+    created_at timestamp with time zone not null default now(),
+    sent_at timestamp with time zone
+);
+
+create index if not exists order_outbox_pending_idx on order_outbox (id) where sent_at is null;
+create index if not exists order_outbox_pending_key_idx on order_outbox (partition_key, id) where sent_at is null;
+create index if not exists order_outbox_sent_at_idx on order_outbox (sent_at) where sent_at is not null;
+
+-- +goose Down
+drop table if exists order_outbox;
+drop trigger if exists orders_set_updated_at on orders;
+drop function if exists trg_orders_set_updated_at;
+drop table if exists orders;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- admin listing and search of user.rpc (ListUsers / SearchUsers)
+create extension if not exists pg_trgm;
+
+-- disabled users keep their profile, active is the only status set by the services today
+alter table users add column if not exists status varchar(16) not null default 'active'
+    check (status in ('active', 'disabled'));
+
+-- trigram indexes serve both the case-insensitive prefix filters and the substring search (ILIKE)
+create index if not exists users_username_trgm_idx on users using gin (username gin_trgm_ops);
+create index if not exists users_email_trgm_idx on users using gin (email gin_trgm_ops);
+create index if not exists users_display_name_trgm_idx on users using gin (display_name gin_trgm_ops);
+
+-- keyset pagination in created_at order, the unique username index covers the username order
+create index if not exists users_created_at_id_idx on users (created_at, id);
+
+-- +goose Down
+drop index if exists users_created_at_id_idx;
+drop index if exists users_display_name_trgm_idx;
+drop index if exists users_email_trgm_idx;
+drop index if exists users_username_trgm_idx;
+alter table users drop column if exists status;
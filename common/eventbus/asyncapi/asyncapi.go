@@ -0,0 +1,240 @@
+// Package asyncapi 根据 schema 注册表与事件路由生成 AsyncAPI 3.0 文档，作为所有事件的目录：
+// 每个 stream 一个 channel，每个 (EventType, EventVersion) 一条 message，payload 为 Envelope + 该版本登记的 Go 类型。
+package asyncapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
+)
+
+const (
+	SpecVersion = "3.0.0"
+
+	envelopeSchema = "Envelope"
+	headersSchema  = "EventHeaders"
+)
+
+type Options struct {
+	Title       string
+	Version     string
+	Description string
+	// Envs topic 前缀 {env} 的可选值，缺省 dev / prod
+	Envs []string
+	// 缺省 schema.Default / event.DefaultRoutes
+	Registry *schema.Registry
+	Routes   *event.Routes
+	// Docs 类型与字段的说明，见 ParseDocs
+	Docs Docs
+	// Avro 为每条 message 附带 codec.AvroSchema 生成的 avro schema（x-avro-schema），
+	// codec 只认 schema.Default，自定义 Registry 时不要开启
+	Avro bool
+}
+
+// Document 是 AsyncAPI 文档，使用 map 使 JSON 输出的键有序、结果稳定
+type Document map[string]any
+
+// JSON 返回缩进后的文档，末尾带换行，生成物与 -check 比较时逐字节一致
+func (d Document) JSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Build 生成所有已登记事件的 AsyncAPI 文档。已登记但没有路由的事件类型返回错误，避免目录中遗漏。
+func Build(opts Options) (Document, error) {
+	if opts.Registry == nil {
+		opts.Registry = schema.Default
+	}
+	if opts.Routes == nil {
+		opts.Routes = event.DefaultRoutes
+	}
+	if len(opts.Envs) == 0 {
+		opts.Envs = []string{string(event.EnvDev), string(event.EnvProd)}
+	}
+	if opts.Title == "" {
+		opts.Title = "antBackend events"
+	}
+	if opts.Version == "" {
+		opts.Version = "1.0.0"
+	}
+
+	b := newSchemaBuilder(opts.Docs)
+	if _, err := b.define(envelopeSchema, envelopeType); err != nil {
+		return nil, err
+	}
+	b.schemas[headersSchema] = headersObject()
+
+	byStream := make(map[event.Stream][]string)
+	var unrouted []string
+	for _, t := range opts.Registry.EventTypes() {
+		stream, err := opts.Routes.StreamOf(t)
+		if err != nil {
+			unrouted = append(unrouted, t)
+			continue
+		}
+		byStream[stream] = append(byStream[stream], t)
+	}
+	if len(unrouted) > 0 {
+		return nil, fmt.Errorf("asyncapi: event types without route: %s", strings.Join(unrouted, ", "))
+	}
+
+	streams := make([]event.Stream, 0, len(byStream))
+	for s := range byStream {
+		streams = append(streams, s)
+	}
+	sort.Slice(streams, func(i, j int) bool { return streams[i] < streams[j] })
+
+	channels := make(map[string]any)
+	operations := make(map[string]any)
+	messages := make(map[string]any)
+	for _, stream := range streams {
+		id := channelID(stream)
+		chMessages := make(map[string]any)
+		var opMessages []any
+		for _, eventType := range byStream[stream] {
+			latest, _ := opts.Registry.Latest(eventType)
+			for _, version := range opts.Registry.Versions(eventType) {
+				msg, err := buildMessage(b, opts, eventType, version, latest)
+				if err != nil {
+					return nil, err
+				}
+				msgID := fmt.Sprintf("%s.v%d", eventType, version)
+				messages[msgID] = msg
+				chMessages[msgID] = ref("#/components/messages/" + msgID)
+				opMessages = append(opMessages, ref("#/channels/"+id+"/messages/"+msgID))
+			}
+		}
+		channels[id] = map[string]any{
+			"address":     "{env}." + string(stream),
+			"title":       string(stream),
+			"description": fmt.Sprintf("Kafka topic of stream %s; event types: %s", stream, strings.Join(byStream[stream], ", ")),
+			"parameters": map[string]any{
+				"env": map[string]any{
+					"enum":        opts.Envs,
+					"default":     opts.Envs[0],
+					"description": "deployment environment prefix of the topic",
+				},
+			},
+			"messages": chMessages,
+		}
+		operations["send-"+id] = map[string]any{
+			"action":   "send",
+			"channel":  ref("#/channels/" + id),
+			"summary":  "producers publish " + string(stream) + " events",
+			"messages": opMessages,
+		}
+	}
+
+	info := map[string]any{
+		"title":   opts.Title,
+		"version": opts.Version,
+	}
+	if opts.Description != "" {
+		info["description"] = opts.Description
+	}
+	return Document{
+		"asyncapi":           SpecVersion,
+		"info":               info,
+		"defaultContentType": codec.ContentTypeJSON,
+		"channels":           channels,
+		"operations":         operations,
+		"components": map[string]any{
+			"messages": messages,
+			"schemas":  b.schemas,
+		},
+	}, nil
+}
+
+func buildMessage(b *schemaBuilder, opts Options, eventType string, version, latest int) (map[string]any, error) {
+	t, ok := opts.Registry.TypeOf(eventType, version)
+	if !ok {
+		return nil, fmt.Errorf("asyncapi: %s v%d has no registered type", eventType, version)
+	}
+	data, err := b.ref(t)
+	if err != nil {
+		return nil, fmt.Errorf("asyncapi: %s v%d: %w", eventType, version, err)
+	}
+
+	msg := map[string]any{
+		"name":        eventType,
+		"title":       fmt.Sprintf("%s v%d", eventType, version),
+		"contentType": codec.ContentTypeJSON,
+		"headers":     ref("#/components/schemas/" + headersSchema),
+		"payload": map[string]any{
+			"allOf": []any{
+				ref("#/components/schemas/" + envelopeSchema),
+				map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"event_type":    map[string]any{"const": eventType},
+						"event_version": map[string]any{"const": version},
+						"data":          data,
+					},
+				},
+			},
+		},
+		"x-event-version":  version,
+		"x-latest-version": latest,
+	}
+	if doc := opts.Docs[t.Name()]; doc != "" {
+		msg["summary"] = doc
+	}
+	if version < latest {
+		msg["description"] = fmt.Sprintf("superseded by v%d; consumers upcast v%d payloads to the latest version", latest, version)
+	}
+	if opts.Avro {
+		s, err := codec.AvroSchema(eventType, version)
+		if err != nil {
+			return nil, fmt.Errorf("asyncapi: avro schema of %s v%d: %w", eventType, version, err)
+		}
+		msg["x-avro-schema"] = json.RawMessage(s)
+	}
+	return msg, nil
+}
+
+// headersObject 描述随消息写入的 header：编码方式与 trace context / 请求 ID
+func headersObject() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			codec.HeaderContentType: map[string]any{
+				"type":        "string",
+				"enum":        []string{codec.ContentTypeJSON, codec.ContentTypeProtobuf, codec.ContentTypeAvro},
+				"description": "codec of the body, absent means JSON",
+			},
+			tracing.HeaderTraceparent: map[string]any{
+				"type":        "string",
+				"description": "W3C trace context of the producing span",
+			},
+			tracing.HeaderTracestate: map[string]any{
+				"type": "string",
+			},
+			tracing.HeaderRequestID: map[string]any{
+				"type":        "string",
+				"description": "request id of the originating API call",
+			},
+		},
+	}
+}
+
+// channelID stream 名中的 . 不能出现在 channel id 中
+func channelID(s event.Stream) string {
+	return strings.ReplaceAll(string(s), ".", "-")
+}
+
+func ref(to string) map[string]any {
+	return map[string]any{"$ref": to}
+}
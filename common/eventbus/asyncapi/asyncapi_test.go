@@ -0,0 +1,152 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+)
+
+type widgetV1 struct {
+	ID string `json:"id"`
+}
+
+type widget struct {
+	ID       string            `json:"id"`
+	Tags     []string          `json:"tags,omitempty"`
+	Owner    *widgetOwner      `json:"owner"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Created  time.Time         `json:"created_at"`
+	Internal string            `json:"-"`
+}
+
+type widgetOwner struct {
+	Name string `json:"name"`
+}
+
+const testStream event.Stream = "test.service.widget-events"
+
+func testOptions() Options {
+	r := schema.NewRegistry()
+	schema.Register[widgetV1](r, "widget.created", 1)
+	schema.Register[widget](r, "widget.created", 2)
+	routes := event.NewRoutes()
+	routes.Register("widget.created", testStream)
+	return Options{
+		Registry: r,
+		Routes:   routes,
+		Docs:     Docs{"widget": "a widget", "widget.Owner": "who owns it"},
+	}
+}
+
+// decode 把文档转回通用 JSON 结构，便于按路径断言
+func decode(t *testing.T, doc Document) map[string]any {
+	b, err := doc.JSON()
+	require.NoError(t, err)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(b, &m))
+	return m
+}
+
+func path(m any, keys ...string) any {
+	for _, k := range keys {
+		m = m.(map[string]any)[k]
+	}
+	return m
+}
+
+func TestBuild(t *testing.T) {
+	doc, err := Build(testOptions())
+	require.NoError(t, err)
+	m := decode(t, doc)
+
+	assert.Equal(t, SpecVersion, m["asyncapi"])
+	assert.Equal(t, "{env}.test.service.widget-events", path(m, "channels", "test-service-widget-events", "address"))
+	assert.Equal(t, []any{"dev", "prod"}, path(m, "channels", "test-service-widget-events", "parameters", "env", "enum"))
+	assert.Equal(t, "send", path(m, "operations", "send-test-service-widget-events", "action"))
+
+	messages := path(m, "components", "messages").(map[string]any)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, float64(1), path(messages, "widget.created.v1", "x-event-version"))
+	assert.Contains(t, path(messages, "widget.created.v1", "description"), "superseded by v2")
+	assert.Equal(t, "a widget", path(messages, "widget.created.v2", "summary"))
+
+	payload := path(messages, "widget.created.v2", "payload", "allOf").([]any)
+	assert.Equal(t, "#/components/schemas/Envelope", path(payload[0], "$ref"))
+	assert.Equal(t, "#/components/schemas/widget", path(payload[1], "properties", "data", "$ref"))
+	assert.Equal(t, float64(2), path(payload[1], "properties", "event_version", "const"))
+
+	w := path(m, "components", "schemas", "widget").(map[string]any)
+	assert.Equal(t, "a widget", w["description"])
+	assert.Equal(t, []any{"id", "created_at"}, w["required"])
+	assert.NotContains(t, w["properties"], "Internal")
+	assert.Equal(t, "array", path(w, "properties", "tags", "type"))
+	assert.Equal(t, "date-time", path(w, "properties", "created_at", "format"))
+	assert.Equal(t, "who owns it", path(w, "properties", "owner", "description"))
+	assert.Equal(t, "object", path(m, "components", "schemas", "widgetOwner", "type"))
+	assert.Contains(t, path(m, "components", "schemas", "Envelope", "properties"), "occurred_at")
+}
+
+func TestBuild_Deterministic(t *testing.T) {
+	a, err := Build(testOptions())
+	require.NoError(t, err)
+	b, err := Build(testOptions())
+	require.NoError(t, err)
+	ja, err := a.JSON()
+	require.NoError(t, err)
+	jb, err := b.JSON()
+	require.NoError(t, err)
+	assert.Equal(t, string(ja), string(jb))
+}
+
+func TestBuild_Unrouted(t *testing.T) {
+	opts := testOptions()
+	schema.Register[widgetOwner](opts.Registry, "widget.orphaned", 1)
+	_, err := Build(opts)
+	assert.ErrorContains(t, err, "widget.orphaned")
+}
+
+func TestBuild_Default(t *testing.T) {
+	doc, err := Build(Options{Avro: true})
+	require.NoError(t, err)
+	m := decode(t, doc)
+	assert.Contains(t, path(m, "channels"), "auth-service-auth-events")
+	assert.Equal(t, "record", path(m, "components", "messages", "order.created.v1", "x-avro-schema", "type"))
+	assert.Equal(t, "encrypt", path(m, "components", "schemas", "AuthLoginFailedEvent", "properties", "identifier", "x-pii"))
+}
+
+func TestParseDocs(t *testing.T) {
+	dir := t.TempDir()
+	src := `package x
+
+// Thing 是一个东西
+type Thing struct {
+	// 名字
+	Name string
+	Age  int // 年龄
+	Skip bool
+}
+
+type (
+	// Other 说明
+	Other struct{}
+)
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x.go"), []byte(src), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "x_test.go"), []byte("package x\n\n// Ignored 不读\ntype Ignored struct{}\n"), 0o644))
+
+	docs, err := ParseDocs(dir)
+	require.NoError(t, err)
+	assert.Equal(t, Docs{
+		"Thing":      "Thing 是一个东西",
+		"Thing.Name": "名字",
+		"Thing.Age":  "年龄",
+		"Other":      "Other 说明",
+	}, docs)
+}
@@ -0,0 +1,82 @@
+package asyncapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Docs 类型与字段的说明：键为 "Type" 或 "Type.Field"（Go 名，不是 json 名）
+type Docs map[string]string
+
+// ParseDocs 读取 dirs 下（不含测试文件）结构体与字段的注释，作为 schema 的 description。
+// 运行时反射拿不到注释，只能从源码中解析；不同目录的同名类型后者覆盖前者。
+func ParseDocs(dirs ...string) (Docs, error) {
+	docs := make(Docs)
+	fset := token.NewFileSet()
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+			collectDocs(f, docs)
+		}
+	}
+	return docs, nil
+}
+
+func collectDocs(f *ast.File, docs Docs) {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			doc := ts.Doc
+			if doc == nil && len(gd.Specs) == 1 {
+				doc = gd.Doc
+			}
+			if text := commentText(doc); text != "" {
+				docs[ts.Name.Name] = text
+			}
+
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				text := commentText(field.Doc)
+				if text == "" {
+					text = commentText(field.Comment)
+				}
+				if text == "" {
+					continue
+				}
+				for _, n := range field.Names {
+					docs[ts.Name.Name+"."+n.Name] = text
+				}
+			}
+		}
+	}
+}
+
+// commentText 把多行注释合并为一行
+func commentText(g *ast.CommentGroup) string {
+	if g == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(g.Text()), " ")
+}
@@ -0,0 +1,187 @@
+package asyncapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	bytesType    = reflect.TypeOf([]byte(nil))
+	rawType      = reflect.TypeOf(json.RawMessage(nil))
+	envelopeType = reflect.TypeOf(event.Envelope[json.RawMessage]{})
+)
+
+// schemaBuilder 按 json tag 把 Go 结构体转换为 JSON Schema，具名结构体登记到 components.schemas 并以 $ref 引用
+type schemaBuilder struct {
+	docs    Docs
+	schemas map[string]any
+	types   map[string]reflect.Type
+}
+
+func newSchemaBuilder(docs Docs) *schemaBuilder {
+	return &schemaBuilder{
+		docs:    docs,
+		schemas: make(map[string]any),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// ref 返回 t 的 schema，具名结构体返回 $ref
+func (b *schemaBuilder) ref(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct && t != timeType && t.Name() != "" {
+		name, err := b.define(t.Name(), t)
+		if err != nil {
+			return nil, err
+		}
+		return ref("#/components/schemas/" + name), nil
+	}
+	return b.schemaOf(t)
+}
+
+// define 以 name 登记结构体 t，不同包的同名类型返回错误
+func (b *schemaBuilder) define(name string, t reflect.Type) (string, error) {
+	if prev, ok := b.types[name]; ok {
+		if prev != t {
+			return "", fmt.Errorf("schema name %s used by both %s and %s", name, prev.PkgPath(), t.PkgPath())
+		}
+		return name, nil
+	}
+	// 先占位，允许自引用的类型
+	b.types[name] = t
+	s, err := b.object(name, t)
+	if err != nil {
+		return "", err
+	}
+	b.schemas[name] = s
+	return name, nil
+}
+
+func (b *schemaBuilder) schemaOf(t reflect.Type) (map[string]any, error) {
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case rawType:
+		return map[string]any{}, nil
+	case bytesType:
+		return map[string]any{"type": "string", "format": "byte"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return b.ref(t)
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return map[string]any{"type": "integer", "format": "int32"}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}, nil
+	case reflect.Float32:
+		return map[string]any{"type": "number", "format": "float"}, nil
+	case reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := b.ref(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key %s", t.Key())
+		}
+		values, err := b.ref(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if t.Name() != "" {
+			return b.ref(t)
+		}
+		return b.object("", t)
+	case reflect.Interface:
+		return map[string]any{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// object 生成结构体的 schema：字段名取自 json tag，omitempty 与指针字段为可选
+func (b *schemaBuilder) object(name string, t reflect.Type) (map[string]any, error) {
+	properties := make(map[string]any)
+	required := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			tag = sf.Name
+		}
+		s, err := b.ref(sf.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err)
+		}
+		var doc string
+		if name != "" {
+			doc = b.docs[name+"."+sf.Name]
+		}
+		pii := sf.Tag.Get(codec.TagPII)
+		if doc != "" || pii != "" {
+			// $ref 的兄弟属性会被部分工具忽略，带说明时用 allOf 包一层
+			if _, isRef := s["$ref"]; isRef {
+				s = map[string]any{"allOf": []any{s}}
+			}
+			if doc != "" {
+				s["description"] = doc
+			}
+			// x-pii：encrypt 字段在 Envelope.key_id 非空时为密文，redact 字段在工具输出中脱敏
+			if pii != "" {
+				s["x-pii"] = pii
+			}
+		}
+		properties[tag] = s
+		if sf.Type.Kind() != reflect.Pointer && !hasOption(opts, "omitempty") {
+			required = append(required, tag)
+		}
+	}
+
+	s := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	if doc := b.docs[name]; name != "" && doc != "" {
+		s["description"] = doc
+	}
+	return s, nil
+}
+
+func hasOption(opts, want string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,396 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+)
+
+// AvroNamespace 是 Envelope 与 payload record 的 Avro namespace
+const AvroNamespace = "antbackend.event"
+
+// AvroEnvelopeSchema 是 application/avro 消息的 writer schema；
+// data 为 payload 按 AvroSchema(event_type, event_version) 编码的 Avro 二进制；
+// key_id 为后加字段，解码时兼容没有该字段的历史消息。
+const AvroEnvelopeSchema = `{"type":"record","name":"Envelope","namespace":"` + AvroNamespace + `","fields":[` +
+	`{"name":"event_type","type":"string"},` +
+	`{"name":"event_version","type":"int"},` +
+	`{"name":"event_id","type":"string"},` +
+	`{"name":"occurred_at","type":{"type":"long","logicalType":"timestamp-micros"}},` +
+	`{"name":"producer","type":"string"},` +
+	`{"name":"trace_id","type":"string"},` +
+	`{"name":"data","type":"bytes"},` +
+	`{"name":"key_id","type":"string","default":""}]}`
+
+var errAvroShort = errors.New("codec: avro input too short")
+
+// avroCodec 以 Avro 二进制编码，payload schema 由登记的 Go 类型推导（见 AvroSchema），不依赖外部 schema registry
+type avroCodec struct{}
+
+func (avroCodec) Name() string        { return "avro" }
+func (avroCodec) ContentType() string { return ContentTypeAvro }
+
+func (avroCodec) Encode(env *event.Envelope[any]) ([]byte, error) {
+	v, err := payloadValue(env.Data)
+	if err != nil {
+		return nil, err
+	}
+	data, err := avroEncode(nil, v)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 0, len(data)+len(env.EventType)+len(env.EventID)+len(env.Producer)+len(env.TraceID)+len(env.KeyID)+32)
+	b = avroString(b, env.EventType)
+	b = avroLong(b, int64(env.EventVersion))
+	b = avroString(b, env.EventID)
+	b = avroLong(b, avroTime(env.OccurredAt))
+	b = avroString(b, env.Producer)
+	b = avroString(b, env.TraceID)
+	b = avroLong(b, int64(len(data)))
+	b = append(b, data...)
+	return avroString(b, env.KeyID), nil
+}
+
+func (c avroCodec) Decode(b []byte) (*event.Envelope[any], error) {
+	r := &avroReader{b: b}
+	env := &event.Envelope[any]{
+		EventType:    r.string(),
+		EventVersion: int(r.long()),
+		EventID:      r.string(),
+		OccurredAt:   time.UnixMicro(r.long()).UTC(),
+		Producer:     r.string(),
+		TraceID:      r.string(),
+	}
+	data := r.bytes()
+	if r.pos < len(r.b) {
+		env.KeyID = r.string()
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	t, err := payloadType(env.EventType, env.EventVersion)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.New(t)
+	pr := &avroReader{b: data}
+	avroDecode(pr, v.Elem())
+	if pr.err != nil {
+		return nil, fmt.Errorf("codec: decode %s payload: %w", env.EventType, pr.err)
+	}
+	env.Data = v.Interface()
+	return env, nil
+}
+
+func (avroCodec) PeekHead(b []byte) (*EnvelopeHead, error) {
+	r := &avroReader{b: b}
+	head := &EnvelopeHead{EventType: r.string(), EventVersion: int(r.long()), EventID: r.string()}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return head, nil
+}
+
+// AvroSchema 返回 (eventType, version) payload 的 Avro schema（JSON），供非 Go 消费者解码 data
+func AvroSchema(eventType string, version int) (string, error) {
+	t, err := payloadType(eventType, version)
+	if err != nil {
+		return "", err
+	}
+	s, err := avroSchemaOf(t, make(map[reflect.Type]bool))
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+func avroSchemaOf(t reflect.Type, defined map[reflect.Type]bool) (any, error) {
+	switch {
+	case t == timeType:
+		return map[string]string{"type": "long", "logicalType": "timestamp-micros"}, nil
+	case t == bytesType:
+		return "bytes", nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "int", nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "long", nil
+	case reflect.Float32:
+		return "float", nil
+	case reflect.Float64:
+		return "double", nil
+	case reflect.Pointer:
+		inner, err := avroSchemaOf(t.Elem(), defined)
+		if err != nil {
+			return nil, err
+		}
+		return []any{"null", inner}, nil
+	case reflect.Slice:
+		items, err := avroSchemaOf(t.Elem(), defined)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("codec: avro map key must be string, got %s", t.Key())
+		}
+		values, err := avroSchemaOf(t.Elem(), defined)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "map", "values": values}, nil
+	case reflect.Struct:
+		// Avro 中命名类型只能定义一次，之后按名字引用
+		if defined[t] {
+			return AvroNamespace + "." + t.Name(), nil
+		}
+		defined[t] = true
+		fields := make([]any, 0, t.NumField())
+		for _, f := range fieldsOf(t) {
+			ft := t.Field(f.index).Type
+			fs, err := avroSchemaOf(ft, defined)
+			if err != nil {
+				return nil, err
+			}
+			af := map[string]any{"name": f.name, "type": fs}
+			if ft.Kind() == reflect.Pointer {
+				af["default"] = nil
+			}
+			fields = append(fields, af)
+		}
+		return map[string]any{"type": "record", "name": t.Name(), "namespace": AvroNamespace, "fields": fields}, nil
+	}
+	return nil, fmt.Errorf("codec: unsupported avro type %s", t)
+}
+
+func avroEncode(b []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+	switch {
+	case t == timeType:
+		return avroLong(b, avroTime(v.Interface().(time.Time))), nil
+	case t == bytesType:
+		return avroBytes(b, v.Bytes()), nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return avroString(b, v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return avroLong(b, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return avroLong(b, int64(v.Uint())), nil
+	case reflect.Float32:
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v.Float()))), nil
+	case reflect.Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float())), nil
+	case reflect.Pointer:
+		// union ["null", T]
+		if v.IsNil() {
+			return avroLong(b, 0), nil
+		}
+		return avroEncode(avroLong(b, 1), v.Elem())
+	case reflect.Slice:
+		if v.Len() > 0 {
+			b = avroLong(b, int64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				var err error
+				if b, err = avroEncode(b, v.Index(i)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return avroLong(b, 0), nil
+	case reflect.Map:
+		if v.Len() > 0 {
+			b = avroLong(b, int64(v.Len()))
+			iter := v.MapRange()
+			for iter.Next() {
+				b = avroString(b, iter.Key().String())
+				var err error
+				if b, err = avroEncode(b, iter.Value()); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return avroLong(b, 0), nil
+	case reflect.Struct:
+		for _, f := range fieldsOf(t) {
+			var err error
+			if b, err = avroEncode(b, v.Field(f.index)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("codec: unsupported avro type %s", t)
+}
+
+func avroDecode(r *avroReader, v reflect.Value) {
+	if r.err != nil {
+		return
+	}
+	t := v.Type()
+	switch {
+	case t == timeType:
+		v.Set(reflect.ValueOf(time.UnixMicro(r.long()).UTC()))
+		return
+	case t == bytesType:
+		v.SetBytes(append([]byte(nil), r.bytes()...))
+		return
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(r.string())
+	case reflect.Bool:
+		v.SetBool(r.byte() != 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(r.long())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(r.long()))
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(r.fixed(4)))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(r.fixed(8))))
+	case reflect.Pointer:
+		if r.long() == 0 {
+			return
+		}
+		v.Set(reflect.New(t.Elem()))
+		avroDecode(r, v.Elem())
+	case reflect.Slice:
+		s := reflect.MakeSlice(t, 0, 0)
+		for n := r.blockLen(); n > 0 && r.err == nil; n = r.blockLen() {
+			for i := int64(0); i < n && r.err == nil; i++ {
+				item := reflect.New(t.Elem()).Elem()
+				avroDecode(r, item)
+				s = reflect.Append(s, item)
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(t)
+		for n := r.blockLen(); n > 0 && r.err == nil; n = r.blockLen() {
+			for i := int64(0); i < n && r.err == nil; i++ {
+				key := reflect.New(t.Key()).Elem()
+				key.SetString(r.string())
+				val := reflect.New(t.Elem()).Elem()
+				avroDecode(r, val)
+				m.SetMapIndex(key, val)
+			}
+		}
+		v.Set(m)
+	case reflect.Struct:
+		for _, f := range fieldsOf(t) {
+			avroDecode(r, v.Field(f.index))
+		}
+	default:
+		r.err = fmt.Errorf("codec: unsupported avro type %s", t)
+	}
+}
+
+// avroTime 零值时间编码为 0，避免 UnixMicro 溢出
+func avroTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMicro()
+}
+
+func avroLong(b []byte, n int64) []byte {
+	return binary.AppendVarint(b, n)
+}
+
+func avroBytes(b []byte, p []byte) []byte {
+	return append(avroLong(b, int64(len(p))), p...)
+}
+
+func avroString(b []byte, s string) []byte {
+	return append(avroLong(b, int64(len(s))), s...)
+}
+
+// avroReader 顺序读取 Avro 二进制，出错后后续读取均为零值，由调用方统一检查 err
+type avroReader struct {
+	b   []byte
+	pos int
+	err error
+}
+
+func (r *avroReader) long() int64 {
+	if r.err != nil {
+		return 0
+	}
+	n, size := binary.Varint(r.b[r.pos:])
+	if size <= 0 {
+		r.err = errAvroShort
+		return 0
+	}
+	r.pos += size
+	return n
+}
+
+// next 返回后续 n 个字节；长度来自报文，超出剩余字节时在分配前拒绝，返回 nil
+func (r *avroReader) next(n int64) []byte {
+	if r.err != nil || n < 0 || n > int64(len(r.b)-r.pos) {
+		if r.err == nil {
+			r.err = errAvroShort
+		}
+		return nil
+	}
+	p := r.b[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return p
+}
+
+// fixed 读取定长字段（bool / float），读取失败时返回同样长度的零值，调用方无需检查长度
+func (r *avroReader) fixed(n int) []byte {
+	if p := r.next(int64(n)); p != nil {
+		return p
+	}
+	return make([]byte, n)
+}
+
+func (r *avroReader) byte() byte {
+	return r.fixed(1)[0]
+}
+
+func (r *avroReader) bytes() []byte {
+	return r.next(r.long())
+}
+
+func (r *avroReader) string() string {
+	return string(r.bytes())
+}
+
+// blockLen 读取 array/map 的块长度，负数表示其后跟随块字节数。
+// 每个元素至少占一个字节，超过剩余字节数的长度视为损坏的报文
+func (r *avroReader) blockLen() int64 {
+	n := r.long()
+	if n < 0 {
+		r.long()
+		n = -n
+	}
+	if n < 0 || n > int64(len(r.b)-r.pos) {
+		if r.err == nil {
+			r.err = errAvroShort
+		}
+		return 0
+	}
+	return n
+}
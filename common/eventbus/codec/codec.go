@@ -0,0 +1,194 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+)
+
+// HeaderContentType 随消息写入 Kafka headers，消费者据此选择 Codec，同一 topic 可混合多种编码
+const HeaderContentType = "content-type"
+
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+	ContentTypeAvro     = "application/avro"
+)
+
+// Codec 把 Envelope 编码为一种线上格式。
+// payload 的结构由 schema.Default 中 (EventType, EventVersion) 登记的 Go 类型决定。
+type Codec interface {
+	// Name 配置中使用的名字：json / protobuf / avro
+	Name() string
+	ContentType() string
+	// Encode 编码 env，env.Data 为该版本登记类型的值或指针
+	Encode(env *event.Envelope[any]) ([]byte, error)
+	// Decode 按写入时的版本解码，Data 为登记类型的指针（JSON 为 json.RawMessage），不做 upcast
+	Decode(b []byte) (*event.Envelope[any], error)
+	// PeekHead 只解析 Envelope 元数据
+	PeekHead(b []byte) (*EnvelopeHead, error)
+}
+
+var (
+	JSON     Codec = jsonCodec{}
+	Protobuf Codec = protoCodec{}
+	Avro     Codec = avroCodec{}
+)
+
+var codecs = []Codec{JSON, Protobuf, Avro}
+
+var ErrUnknownCodec = errors.New("codec: unknown codec")
+
+// ByName 按配置名返回 Codec，空字符串为 JSON
+func ByName(name string) (Codec, error) {
+	if name == "" {
+		return JSON, nil
+	}
+	for _, c := range codecs {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+}
+
+// ForContentType 按 content-type 返回 Codec，空字符串为 JSON
+func ForContentType(contentType string) (Codec, error) {
+	if contentType == "" {
+		return JSON, nil
+	}
+	for _, c := range codecs {
+		if c.ContentType() == contentType {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: content-type %s", ErrUnknownCodec, contentType)
+}
+
+// FromHeaders 按 content-type header 选择 Codec，缺省为 JSON（兼容未带 header 的历史消息）
+func FromHeaders(headers map[string]string) (Codec, error) {
+	return ForContentType(headers[HeaderContentType])
+}
+
+// Marshal 校验 (EventType, EventVersion) 已登记后用 c 编码；配置了 KeyProvider 时加密 pii:"encrypt" 字段
+func Marshal[T any](c Codec, env *event.Envelope[T]) ([]byte, error) {
+	if env == nil {
+		return nil, errors.New("envelope is nil")
+	}
+	if err := schema.Default.Validate(env.EventType, env.EventVersion); err != nil {
+		return nil, err
+	}
+	out := &event.Envelope[any]{
+		EventType:    env.EventType,
+		EventVersion: env.EventVersion,
+		EventID:      env.EventID,
+		OccurredAt:   env.OccurredAt,
+		Producer:     env.Producer,
+		TraceID:      env.TraceID,
+		KeyID:        env.KeyID,
+		Data:         env.Data,
+	}
+	if err := encryptPayload(out); err != nil {
+		return nil, err
+	}
+	return c.Encode(out)
+}
+
+// Unmarshal 用 c 解码、解密 PII 字段（见 SetKeyProvider）并把 payload 升级到最新版本。
+// 写入版本已是最新且类型与 T 一致时直接使用解码结果，否则经 JSON 走 schema upcaster。
+func Unmarshal[T any](c Codec, b []byte, out *event.Envelope[T]) error {
+	if out == nil {
+		return errors.New("envelope is nil")
+	}
+	if c == JSON {
+		return UnmarshalEnvelope(b, out)
+	}
+	env, err := c.Decode(b)
+	if err != nil {
+		return err
+	}
+	if err := decryptPayload(env); err != nil {
+		return err
+	}
+	out.EventType = env.EventType
+	out.EventVersion = env.EventVersion
+	out.EventID = env.EventID
+	out.OccurredAt = env.OccurredAt
+	out.Producer = env.Producer
+	out.TraceID = env.TraceID
+	out.KeyID = env.KeyID
+
+	if latest, ok := schema.Default.Latest(env.EventType); ok && latest == env.EventVersion {
+		if v, ok := env.Data.(*T); ok {
+			out.Data = *v
+			return nil
+		}
+	}
+	raw, err := json.Marshal(env.Data)
+	if err != nil {
+		return err
+	}
+	data, version, err := schema.Default.UpcastData(env.EventType, env.EventVersion, raw)
+	if err != nil {
+		return err
+	}
+	out.EventVersion = version
+	return json.Unmarshal(data, &out.Data)
+}
+
+// Transcode 把 JSON 编码的 Envelope（例如 outbox 中落库的事件）转为 c 的编码，payload 版本不变；
+// 尚未加密的 payload 在配置了 KeyProvider 时加密
+func Transcode(c Codec, body []byte) ([]byte, error) {
+	if c == JSON && currentKeyProvider() == nil {
+		return body, nil
+	}
+	env, err := JSON.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := schema.Default.TypeOf(env.EventType, env.EventVersion)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s v%d", schema.ErrUnknownEventVersion, env.EventType, env.EventVersion)
+	}
+	data := reflect.New(t)
+	if err := json.Unmarshal(env.Data.(json.RawMessage), data.Interface()); err != nil {
+		return nil, err
+	}
+	env.Data = data.Interface()
+	keyID := env.KeyID
+	if err := encryptPayload(env); err != nil {
+		return nil, err
+	}
+	if c == JSON && env.KeyID == keyID {
+		return body, nil
+	}
+	return c.Encode(env)
+}
+
+// payloadType 返回 (eventType, version) 登记的 Go 类型，二进制编码必须知道 payload 结构
+func payloadType(eventType string, version int) (reflect.Type, error) {
+	t, ok := schema.Default.TypeOf(eventType, version)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s v%d", schema.ErrUnknownEventVersion, eventType, version)
+	}
+	return t, nil
+}
+
+// payloadValue 解引用 env.Data 得到 payload 结构体
+func payloadValue(data any) (reflect.Value, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, errors.New("codec: payload is nil")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("codec: payload must be a struct, got %s", v.Type())
+	}
+	return v, nil
+}
@@ -0,0 +1,61 @@
+package codec
+
+import (
+	"testing"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+)
+
+// go test -bench . -benchmem ./eventbus/codec/
+// bytes/msg reports the encoded envelope size for the same order.created event
+
+func BenchmarkMarshal(b *testing.B) {
+	env := newOrderCreated()
+	for _, c := range codecs {
+		b.Run(c.Name(), func(b *testing.B) {
+			var body []byte
+			for i := 0; i < b.N; i++ {
+				var err error
+				if body, err = Marshal(c, env); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(body)), "bytes/msg")
+		})
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	for _, c := range codecs {
+		body, err := Marshal(c, newOrderCreated())
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(c.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var out event.Envelope[event.OrderCreatedEvent]
+				if err := Unmarshal(c, body, &out); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(body)), "bytes/msg")
+		})
+	}
+}
+
+// BenchmarkTranscode measures the outbox path: JSON stored in postgres, re-encoded before publishing
+func BenchmarkTranscode(b *testing.B) {
+	body, err := MarshalEnvelope(newOrderCreated())
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, c := range []Codec{Protobuf, Avro} {
+		b.Run(c.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Transcode(c, body); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+)
+
+const testEventGreeted = "test.codec.greeted"
+
+// test.codec.greeted has no protobuf message, it exercises avro only
+type greetedV1 struct {
+	Name string `json:"name"`
+}
+
+type greetedV2 struct {
+	FirstName string           `json:"first_name"`
+	Nickname  *string          `json:"nickname,omitempty"`
+	Tags      []string         `json:"tags"`
+	Scores    map[string]int64 `json:"scores"`
+	Ratio     float64          `json:"ratio"`
+	Active    bool             `json:"active"`
+	Raw       []byte           `json:"raw"`
+}
+
+func init() {
+	schema.Register[greetedV1](schema.Default, testEventGreeted, 1)
+	schema.Register[greetedV2](schema.Default, testEventGreeted, 2)
+	schema.Default.RegisterUpcaster(testEventGreeted, 1, func(data json.RawMessage) (json.RawMessage, error) {
+		var v1 greetedV1
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(greetedV2{FirstName: v1.Name})
+	})
+}
+
+func newOrderCreated() *event.Envelope[event.OrderCreatedEvent] {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	env := event.NewEnvelope(event.EventTypeOrderCreated, 1, "order.rpc", "trace-1", event.OrderCreatedEvent{
+		OrderID:   "o-1",
+		UserID:    "u-1",
+		Amount:    -12345678901,
+		Currency:  "EUR",
+		Status:    "created",
+		CreatedAt: now.Add(-time.Minute),
+		UpdatedAt: now,
+	})
+	env.OccurredAt = now
+	return env
+}
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			in := newOrderCreated()
+			body, err := Marshal(c, in)
+			require.NoError(t, err)
+
+			var out event.Envelope[event.OrderCreatedEvent]
+			require.NoError(t, Unmarshal(c, body, &out))
+			assert.Equal(t, in.EventID, out.EventID)
+			assert.Equal(t, in.TraceID, out.TraceID)
+			assert.True(t, in.OccurredAt.Equal(out.OccurredAt))
+			assert.Equal(t, in.Data.Amount, out.Data.Amount)
+			assert.Equal(t, in.Data.OrderID, out.Data.OrderID)
+			assert.True(t, in.Data.CreatedAt.Equal(out.Data.CreatedAt))
+			assert.True(t, in.Data.UpdatedAt.Equal(out.Data.UpdatedAt))
+
+			head, err := c.PeekHead(body)
+			require.NoError(t, err)
+			assert.Equal(t, EnvelopeHead{EventType: event.EventTypeOrderCreated, EventVersion: 1, EventID: in.EventID}, *head)
+		})
+	}
+}
+
+func TestCodecs_BinaryIsSmaller(t *testing.T) {
+	jsonBody, err := Marshal(JSON, newOrderCreated())
+	require.NoError(t, err)
+	for _, c := range []Codec{Protobuf, Avro} {
+		body, err := Marshal(c, newOrderCreated())
+		require.NoError(t, err)
+		assert.Less(t, len(body), len(jsonBody), c.Name())
+	}
+}
+
+func TestAvro_AllKindsAndUpcast(t *testing.T) {
+	nick := "ant"
+	in := event.NewEnvelope(testEventGreeted, 2, "test", "", greetedV2{
+		FirstName: "Ant", Nickname: &nick, Tags: []string{"a", "b"},
+		Scores: map[string]int64{"x": -1, "y": 2}, Ratio: 0.5, Active: true, Raw: []byte{0, 1, 2},
+	})
+	body, err := Marshal(Avro, in)
+	require.NoError(t, err)
+	var out event.Envelope[greetedV2]
+	require.NoError(t, Unmarshal(Avro, body, &out))
+	assert.Equal(t, in.Data, out.Data)
+
+	// v1 written in avro is upcast through the JSON upcaster
+	old, err := Marshal(Avro, event.NewEnvelope(testEventGreeted, 1, "test", "", greetedV1{Name: "Old"}))
+	require.NoError(t, err)
+	var upcast event.Envelope[greetedV2]
+	require.NoError(t, Unmarshal(Avro, old, &upcast))
+	assert.Equal(t, 2, upcast.EventVersion)
+	assert.Equal(t, "Old", upcast.Data.FirstName)
+	assert.Nil(t, upcast.Data.Nickname)
+}
+
+func TestAvro_TruncatedInput(t *testing.T) {
+	body, err := Marshal(Avro, newOrderCreated())
+	require.NoError(t, err)
+	var out event.Envelope[event.OrderCreatedEvent]
+	assert.Error(t, Unmarshal(Avro, body[:len(body)-3], &out))
+}
+
+// avroWithData frames data as the payload of a test.codec.greeted v2 envelope
+func avroWithData(data []byte) []byte {
+	b := avroString(nil, testEventGreeted)
+	b = avroLong(b, 2)
+	b = avroString(b, "e-1")
+	b = avroLong(b, 0)
+	b = avroString(b, "test")
+	b = avroString(b, "")
+	return avroBytes(b, data)
+}
+
+func TestAvro_CorruptInput(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{"empty", nil},
+		{"huge string length", binary.AppendVarint(nil, 1<<50)},
+		{"negative string length", binary.AppendVarint(nil, -5)},
+		{"length past the end", append(binary.AppendVarint(nil, 10), "short"...)},
+		{"huge payload length", append(avroString(avroString(nil, testEventGreeted), ""), binary.AppendVarint(nil, 1<<40)...)},
+		// first_name "", nickname null, then an array block claiming 2^40 tags
+		{"huge array block", avroWithData(binary.AppendVarint([]byte{0, 0}, 1<<40))},
+		{"huge negative array block", avroWithData(binary.AppendVarint([]byte{0, 0}, -(1 << 40)))},
+		// tags empty, then a map block claiming 2^40 entries
+		{"huge map block", avroWithData(binary.AppendVarint([]byte{0, 0, 0}, 1<<40))},
+		{"truncated float", avroWithData([]byte{0, 0, 0, 0, 1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				_, err := Avro.Decode(tt.body)
+				assert.Error(t, err)
+				_, _ = Avro.PeekHead(tt.body)
+			})
+		})
+	}
+}
+
+func FuzzAvroDecode(f *testing.F) {
+	body, err := Marshal(Avro, newOrderCreated())
+	require.NoError(f, err)
+	f.Add(body)
+	nick := "ant"
+	greeted, err := Marshal(Avro, event.NewEnvelope(testEventGreeted, 2, "test", "", greetedV2{
+		FirstName: "Ant", Nickname: &nick, Tags: []string{"a"}, Scores: map[string]int64{"x": 1}, Ratio: 1, Raw: []byte{1},
+	}))
+	require.NoError(f, err)
+	f.Add(greeted)
+	f.Add(binary.AppendVarint(nil, 1<<50))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		// corrupt input must fail with an error, never panic or allocate by a length read from the wire
+		_, _ = Avro.Decode(b)
+		_, _ = Avro.PeekHead(b)
+	})
+}
+
+func TestAvroSchema(t *testing.T) {
+	s, err := AvroSchema(testEventGreeted, 2)
+	require.NoError(t, err)
+	var parsed struct {
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string `json:"name"`
+			Type any    `json:"type"`
+		} `json:"fields"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(s), &parsed))
+	assert.Equal(t, "record", parsed.Type)
+	assert.Equal(t, "greetedV2", parsed.Name)
+	require.Len(t, parsed.Fields, 7)
+	assert.Equal(t, "nickname", parsed.Fields[1].Name)
+	assert.Equal(t, []any{"null", "string"}, parsed.Fields[1].Type)
+
+	_, err = AvroSchema("test.codec.unknown", 1)
+	assert.ErrorIs(t, err, schema.ErrUnknownEventVersion)
+}
+
+func TestProtobuf_MissingMessage(t *testing.T) {
+	_, err := Marshal(Protobuf, event.NewEnvelope(testEventGreeted, 1, "test", "", greetedV1{Name: "x"}))
+	assert.ErrorContains(t, err, "no protobuf message event.v1.greetedV1")
+}
+
+func TestTranscode(t *testing.T) {
+	in := newOrderCreated()
+	jsonBody, err := MarshalEnvelope(in)
+	require.NoError(t, err)
+	for _, c := range []Codec{Protobuf, Avro} {
+		body, err := Transcode(c, jsonBody)
+		require.NoError(t, err)
+		var out event.Envelope[event.OrderCreatedEvent]
+		require.NoError(t, Unmarshal(c, body, &out))
+		assert.Equal(t, in.EventID, out.EventID, c.Name())
+		assert.Equal(t, in.Data.Amount, out.Data.Amount, c.Name())
+	}
+	same, err := Transcode(JSON, jsonBody)
+	require.NoError(t, err)
+	assert.Equal(t, jsonBody, same)
+}
+
+func TestLookup(t *testing.T) {
+	c, err := FromHeaders(map[string]string{HeaderContentType: ContentTypeAvro})
+	require.NoError(t, err)
+	assert.Equal(t, Avro, c)
+	c, err = FromHeaders(nil)
+	require.NoError(t, err)
+	assert.Equal(t, JSON, c)
+	_, err = FromHeaders(map[string]string{HeaderContentType: "text/plain"})
+	assert.ErrorIs(t, err, ErrUnknownCodec)
+
+	c, err = ByName("protobuf")
+	require.NoError(t, err)
+	assert.Equal(t, ContentTypeProtobuf, c.ContentType())
+	_, err = ByName("xml")
+	assert.ErrorIs(t, err, ErrUnknownCodec)
+}
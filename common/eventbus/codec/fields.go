@@ -0,0 +1,44 @@
+package codec
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// field 是 payload 结构体中参与编码的字段，name 取自 json tag，与 proto 字段名 / avro 字段名一致
+type field struct {
+	index int
+	name  string
+}
+
+var (
+	fieldCache sync.Map // reflect.Type -> []field
+	timeType   = reflect.TypeOf(time.Time{})
+	bytesType  = reflect.TypeOf([]byte(nil))
+)
+
+// fieldsOf 返回 t 按声明顺序排列的导出字段，json:"-" 的字段被忽略
+func fieldsOf(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, field{index: i, name: name})
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
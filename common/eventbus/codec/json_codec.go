@@ -5,18 +5,88 @@ import (
 	"errors"
 
 	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
 )
 
+// MarshalEnvelope 以 JSON 编码，编码前校验 (EventType, EventVersion) 已在 schema.Default 登记，拒绝未知类型/版本
 func MarshalEnvelope[T any](env *event.Envelope[T]) ([]byte, error) {
-	if env == nil {
-		return nil, errors.New("envelope is nil")
-	}
-	return json.Marshal(env)
+	return Marshal(JSON, env)
 }
 
+// UnmarshalEnvelope 先解密 PII 字段，再经 schema.Default 把旧版本 payload 升级到最新版本后解码，消费者总是拿到最新结构
 func UnmarshalEnvelope[T any](b []byte, out *event.Envelope[T]) error {
 	if out == nil {
 		return errors.New("envelope is nil")
 	}
-	return json.Unmarshal(b, out)
+	b, err := decryptJSON(b)
+	if err != nil {
+		return err
+	}
+	latest, err := schema.Default.Upcast(b)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(latest, out)
+}
+
+// EnvelopeHead 是 Envelope 中与 payload 无关的元数据
+type EnvelopeHead struct {
+	EventType    string `json:"event_type"`
+	EventVersion int    `json:"event_version"`
+	EventID      string `json:"event_id"`
+}
+
+// PeekHead 只解析 Envelope 元数据，不解码 data
+func PeekHead(b []byte) (*EnvelopeHead, error) {
+	var head EnvelopeHead
+	if err := json.Unmarshal(b, &head); err != nil {
+		return nil, err
+	}
+	return &head, nil
+}
+
+// PeekEventType 只解析 event_type，用于按类型路由后再强类型解码
+func PeekEventType(b []byte) (string, error) {
+	head, err := PeekHead(b)
+	if err != nil {
+		return "", err
+	}
+	if head.EventType == "" {
+		return "", errors.New("event_type is empty")
+	}
+	return head.EventType, nil
+}
+
+// jsonCodec 是默认编码，payload 为 Go 结构体的 encoding/json 形式
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string        { return "json" }
+func (jsonCodec) ContentType() string { return ContentTypeJSON }
+
+func (jsonCodec) Encode(env *event.Envelope[any]) ([]byte, error) {
+	return json.Marshal(env)
+}
+
+func (jsonCodec) Decode(b []byte) (*event.Envelope[any], error) {
+	var raw event.Envelope[json.RawMessage]
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	if raw.EventType == "" {
+		return nil, errors.New("codec: event_type is empty")
+	}
+	return &event.Envelope[any]{
+		EventType:    raw.EventType,
+		EventVersion: raw.EventVersion,
+		EventID:      raw.EventID,
+		OccurredAt:   raw.OccurredAt,
+		Producer:     raw.Producer,
+		TraceID:      raw.TraceID,
+		KeyID:        raw.KeyID,
+		Data:         raw.Data,
+	}, nil
+}
+
+func (jsonCodec) PeekHead(b []byte) (*EnvelopeHead, error) {
+	return PeekHead(b)
 }
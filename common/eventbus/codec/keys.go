@@ -0,0 +1,108 @@
+package codec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// KeySize PII 加密使用 AES-256-GCM，key 为 32 字节
+const KeySize = 32
+
+var (
+	ErrUnknownKey = errors.New("codec: unknown pii key")
+	ErrNoKey      = errors.New("codec: no current pii key")
+)
+
+// KeyProvider 提供 PII 字段加密的 key。key 以 id 标识并写入 Envelope.KeyID，轮换后旧 key 仍需保留以解密历史事件。
+type KeyProvider interface {
+	// Current 返回加密新事件使用的 key，只解密的消费者可以返回 ErrNoKey
+	Current() (id string, key []byte, err error)
+	// Key 返回 id 对应的 key，没有该 key（无权访问）时返回 ErrUnknownKey
+	Key(id string) ([]byte, error)
+}
+
+var keyProvider atomic.Pointer[KeyProvider]
+
+// SetKeyProvider 设置进程内 Marshal / Transcode 加密与 Unmarshal 解密使用的 KeyProvider，nil 表示关闭：
+// 发布明文，消费时密文字段保持原样
+func SetKeyProvider(p KeyProvider) {
+	if p == nil {
+		keyProvider.Store(nil)
+		return
+	}
+	keyProvider.Store(&p)
+}
+
+func currentKeyProvider() KeyProvider {
+	if p := keyProvider.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// StaticKeyProvider 是内存中的一组 key，current 为空时只能解密
+type StaticKeyProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+func NewStaticKeyProvider(current string, keys map[string][]byte) (*StaticKeyProvider, error) {
+	for id, k := range keys {
+		if len(k) != KeySize {
+			return nil, fmt.Errorf("codec: pii key %q must be %d bytes, got %d", id, KeySize, len(k))
+		}
+	}
+	if _, ok := keys[current]; current != "" && !ok {
+		return nil, fmt.Errorf("%w: current key %q", ErrUnknownKey, current)
+	}
+	return &StaticKeyProvider{current: current, keys: keys}, nil
+}
+
+func (p *StaticKeyProvider) Current() (string, []byte, error) {
+	if p.current == "" {
+		return "", nil, ErrNoKey
+	}
+	return p.current, p.keys[p.current], nil
+}
+
+func (p *StaticKeyProvider) Key(id string) ([]byte, error) {
+	k, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+	}
+	return k, nil
+}
+
+// keyFile 是 NewFileKeyProvider 读取的 JSON 文件：
+//
+//	{"current": "2025-01", "keys": {"2024-07": "<base64>", "2025-01": "<base64>"}}
+type keyFile struct {
+	Current string            `json:"current"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// NewFileKeyProvider 从本地 JSON 文件加载 key（base64 编码），用于本地开发；
+// 生产环境可实现 KeyProvider 对接 KMS / Vault
+func NewFileKeyProvider(path string) (*StaticKeyProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f keyFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("codec: parse pii key file %s: %w", path, err)
+	}
+	keys := make(map[string][]byte, len(f.Keys))
+	for id, v := range f.Keys {
+		k, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("codec: pii key %q: %w", id, err)
+		}
+		keys[id] = k
+	}
+	return NewStaticKeyProvider(f.Current, keys)
+}
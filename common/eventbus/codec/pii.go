@@ -0,0 +1,285 @@
+package codec
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// TagPII 标记 payload 中的个人信息字段（string / *string / []string，标在结构体字段上时作用于其中所有字符串）：
+//
+//	pii:"encrypt"  发布时加密，Envelope.KeyID 记录所用 key；有 key 的消费者解码时透明解密
+//	pii:"redact"   明文传输，仅在日志与工具导出时脱敏
+//
+// 两种字段在 Redact 中都会被替换为 Redacted。
+const TagPII = "pii"
+
+const (
+	PIIEncrypt = "encrypt"
+	PIIRedact  = "redact"
+
+	// Redacted 是脱敏后的字段值
+	Redacted = "[redacted]"
+
+	// cipherPrefix 标记已加密的字段值，其后为 base64(nonce || AES-GCM 密文)
+	cipherPrefix = "enc:"
+)
+
+var errPIICiphertext = errors.New("codec: malformed pii ciphertext")
+
+var encryptedCache sync.Map // reflect.Type -> bool
+
+// hasEncrypted 返回 t 中是否有 pii:"encrypt" 字段
+func hasEncrypted(t reflect.Type) bool {
+	if cached, ok := encryptedCache.Load(t); ok {
+		return cached.(bool)
+	}
+	found := false
+	walkPIITypes(t, "", make(map[reflect.Type]bool), func(mode string) {
+		found = found || mode == PIIEncrypt
+	})
+	encryptedCache.Store(t, found)
+	return found
+}
+
+func walkPIITypes(t reflect.Type, mode string, seen map[reflect.Type]bool, fn func(mode string)) {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		walkPIITypes(t.Elem(), mode, seen, fn)
+	case reflect.String:
+		if mode != "" {
+			fn(mode)
+		}
+	case reflect.Struct:
+		if t == timeType || seen[t] {
+			return
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			m := mode
+			if tag := sf.Tag.Get(TagPII); tag != "" {
+				m = tag
+			}
+			walkPIITypes(sf.Type, m, seen, fn)
+		}
+	}
+}
+
+// walkPII 对 v 中带 pii tag 的非空字符串调用 fn，v 必须可写（通过指针取得）
+func walkPII(v reflect.Value, mode string, fn func(mode string, s reflect.Value) error) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return walkPII(v.Elem(), mode, fn)
+	case reflect.String:
+		if mode != "" && v.Len() > 0 {
+			return fn(mode, v)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type() == bytesType {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := walkPII(v.Index(i), mode, fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			m := mode
+			if tag := sf.Tag.Get(TagPII); tag != "" {
+				m = tag
+			}
+			if err := walkPII(v.Field(i), m, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// clonePayload 经 JSON 深拷贝 data 为 t 的新值（指针），加密与脱敏不能修改调用方持有的 payload
+func clonePayload(t reflect.Type, data any) (reflect.Value, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(b, v.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return v, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptPayload 未配置 KeyProvider、已加密或 payload 没有 pii:"encrypt" 字段时不做处理；
+// 否则把 env.Data 替换为加密后的副本并设置 env.KeyID。EventID 作为附加数据，密文不能被挪到其他事件中。
+func encryptPayload(env *event.Envelope[any]) error {
+	p := currentKeyProvider()
+	if p == nil || env.KeyID != "" {
+		return nil
+	}
+	t := reflect.TypeOf(env.Data)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || !hasEncrypted(t) {
+		return nil
+	}
+	id, key, err := p.Current()
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	clone, err := clonePayload(t, env.Data)
+	if err != nil {
+		return err
+	}
+	aad := []byte(env.EventID)
+	err = walkPII(clone, "", func(mode string, s reflect.Value) error {
+		if mode != PIIEncrypt || strings.HasPrefix(s.String(), cipherPrefix) {
+			return nil
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return err
+		}
+		sealed := aead.Seal(nonce, nonce, []byte(s.String()), aad)
+		s.SetString(cipherPrefix + base64.RawStdEncoding.EncodeToString(sealed))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	env.Data = clone.Interface()
+	env.KeyID = id
+	return nil
+}
+
+// unknownKeys 记录已告警过的 key id，每个缺失的 key 只打一次日志
+var unknownKeys sync.Map
+
+// decryptPayload 就地解密 env.Data（登记类型的指针）并清空 env.KeyID；
+// 未配置 KeyProvider 或没有该 key（无权访问）时密文与 KeyID 保持原样
+func decryptPayload(env *event.Envelope[any]) error {
+	p := currentKeyProvider()
+	if p == nil || env.KeyID == "" {
+		return nil
+	}
+	key, err := p.Key(env.KeyID)
+	if errors.Is(err, ErrUnknownKey) {
+		// 与未配置 KeyProvider 一致交给 handler 密文，而不是解码失败后经重试 topic 进入死信
+		if _, logged := unknownKeys.LoadOrStore(env.KeyID, struct{}{}); !logged {
+			logx.Infow("pii key not available, delivering ciphertext",
+				logx.Field("key_id", env.KeyID), logx.Field("event_type", env.EventType))
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	aad := []byte(env.EventID)
+	err = walkPII(reflect.ValueOf(env.Data), "", func(mode string, s reflect.Value) error {
+		ct, ok := strings.CutPrefix(s.String(), cipherPrefix)
+		if mode != PIIEncrypt || !ok {
+			return nil
+		}
+		sealed, err := base64.RawStdEncoding.DecodeString(ct)
+		if err != nil || len(sealed) < aead.NonceSize() {
+			return errPIICiphertext
+		}
+		plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+		if err != nil {
+			return fmt.Errorf("codec: decrypt pii field of %s: %w", env.EventType, err)
+		}
+		s.SetString(string(plain))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	env.KeyID = ""
+	return nil
+}
+
+// decryptJSON 解密 JSON 编码的 Envelope，在 upcast 之前按写入版本的类型处理；无需解密时原样返回
+func decryptJSON(b []byte) ([]byte, error) {
+	if currentKeyProvider() == nil {
+		return b, nil
+	}
+	env, err := JSON.Decode(b)
+	if err != nil || env.KeyID == "" {
+		return b, nil
+	}
+	t, err := payloadType(env.EventType, env.EventVersion)
+	if err != nil {
+		return nil, err
+	}
+	data := reflect.New(t)
+	if err := json.Unmarshal(env.Data.(json.RawMessage), data.Interface()); err != nil {
+		return nil, err
+	}
+	env.Data = data.Interface()
+	if err := decryptPayload(env); err != nil {
+		return nil, err
+	}
+	return json.Marshal(env)
+}
+
+// Redact 返回 env 的脱敏副本，供日志与运维工具输出：data 按 (EventType, EventVersion) 登记的类型解码后，
+// 带 pii tag 的字段替换为 Redacted。未登记的事件无法识别 PII 字段，data 原样保留。
+func Redact(env *event.Envelope[any]) (*event.Envelope[any], error) {
+	out := *env
+	t, ok := schema.Default.TypeOf(env.EventType, env.EventVersion)
+	if !ok || env.Data == nil {
+		return &out, nil
+	}
+	clone, err := clonePayload(t, env.Data)
+	if err != nil {
+		return nil, err
+	}
+	_ = walkPII(clone, "", func(_ string, s reflect.Value) error {
+		s.SetString(Redacted)
+		return nil
+	})
+	out.Data = clone.Interface()
+	return &out, nil
+}
@@ -0,0 +1,279 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+)
+
+const testEventPII = "test.codec.pii"
+
+type piiProfile struct {
+	Name string `json:"name" pii:"encrypt"`
+}
+
+type piiV1 struct {
+	Email string `json:"email" pii:"encrypt"`
+}
+
+type piiV2 struct {
+	UserID  string     `json:"user_id"`
+	Email   string     `json:"email" pii:"encrypt"`
+	Phone   *string    `json:"phone,omitempty" pii:"encrypt"`
+	Profile piiProfile `json:"profile"`
+	Aliases []string   `json:"aliases" pii:"redact"`
+}
+
+func init() {
+	schema.Register[piiV1](schema.Default, testEventPII, 1)
+	schema.Register[piiV2](schema.Default, testEventPII, 2)
+	schema.Default.RegisterUpcaster(testEventPII, 1, func(data json.RawMessage) (json.RawMessage, error) {
+		var v1 piiV1
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(piiV2{Email: v1.Email})
+	})
+}
+
+func testKeys(t *testing.T, current string, ids ...string) *StaticKeyProvider {
+	keys := make(map[string][]byte, len(ids))
+	for i, id := range ids {
+		keys[id] = bytes.Repeat([]byte{byte(i + 1)}, KeySize)
+	}
+	p, err := NewStaticKeyProvider(current, keys)
+	require.NoError(t, err)
+	return p
+}
+
+// useKeys 设置进程内 KeyProvider，测试结束后关闭加密
+func useKeys(t *testing.T, p KeyProvider) {
+	SetKeyProvider(p)
+	t.Cleanup(func() { SetKeyProvider(nil) })
+}
+
+func newLoginFailed() *event.Envelope[event.AuthLoginFailedEvent] {
+	return event.NewEnvelope(event.EventTypeAuthLoginFailed, 1, "auth.rpc", "", event.AuthLoginFailedEvent{
+		Identifier: "dave@example.com",
+		Method:     event.AuthMethodPassword,
+		Reason:     "bad_password",
+	})
+}
+
+func TestPII_EncryptRoundTrip(t *testing.T) {
+	useKeys(t, testKeys(t, "k1", "k1"))
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			in := newLoginFailed()
+			body, err := Marshal(c, in)
+			require.NoError(t, err)
+			assert.NotContains(t, string(body), "dave@example.com")
+			assert.Equal(t, "dave@example.com", in.Data.Identifier, "caller's payload must not be modified")
+
+			raw, err := c.Decode(body)
+			require.NoError(t, err)
+			assert.Equal(t, "k1", raw.KeyID)
+
+			var out event.Envelope[event.AuthLoginFailedEvent]
+			require.NoError(t, Unmarshal(c, body, &out))
+			assert.Equal(t, "dave@example.com", out.Data.Identifier)
+			assert.Equal(t, "bad_password", out.Data.Reason)
+			assert.Empty(t, out.KeyID)
+		})
+	}
+}
+
+func TestPII_NestedAndUpcast(t *testing.T) {
+	useKeys(t, testKeys(t, "k1", "k1"))
+	phone := "+49 170 0000000"
+	in := event.NewEnvelope(testEventPII, 2, "test", "", piiV2{
+		UserID:  "u-1",
+		Email:   "a@example.com",
+		Phone:   &phone,
+		Profile: piiProfile{Name: "Ant"},
+		Aliases: []string{"ant"},
+	})
+	for _, c := range []Codec{JSON, Avro} {
+		body, err := Marshal(c, in)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "a@example.com")
+		assert.NotContains(t, string(body), "+49")
+		assert.NotContains(t, string(body), "Ant\"")
+
+		var out event.Envelope[piiV2]
+		require.NoError(t, Unmarshal(c, body, &out))
+		assert.Equal(t, in.Data, out.Data)
+	}
+
+	// 旧版本的密文在 upcast 之前解密
+	v1 := event.NewEnvelope(testEventPII, 1, "test", "", piiV1{Email: "old@example.com"})
+	body, err := MarshalEnvelope(v1)
+	require.NoError(t, err)
+	var out event.Envelope[piiV2]
+	require.NoError(t, UnmarshalEnvelope(body, &out))
+	assert.Equal(t, 2, out.EventVersion)
+	assert.Equal(t, "old@example.com", out.Data.Email)
+}
+
+func TestPII_WithoutAccess(t *testing.T) {
+	useKeys(t, testKeys(t, "k2", "k1", "k2"))
+	body, err := MarshalEnvelope(newLoginFailed())
+	require.NoError(t, err)
+
+	// 没有配置 key 的消费者拿到密文
+	SetKeyProvider(nil)
+	var out event.Envelope[event.AuthLoginFailedEvent]
+	require.NoError(t, UnmarshalEnvelope(body, &out))
+	assert.Equal(t, "k2", out.KeyID)
+	assert.True(t, strings.HasPrefix(out.Data.Identifier, cipherPrefix))
+
+	// 配置了 key 但没有该 key id：同样拿到密文，不会解码失败
+	SetKeyProvider(testKeys(t, "", "k1"))
+	out = event.Envelope[event.AuthLoginFailedEvent]{}
+	require.NoError(t, UnmarshalEnvelope(body, &out))
+	assert.Equal(t, "k2", out.KeyID)
+	assert.True(t, strings.HasPrefix(out.Data.Identifier, cipherPrefix))
+
+	// 只能解密的 provider 不能发布需要加密的事件
+	_, err = MarshalEnvelope(newLoginFailed())
+	assert.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestPII_UnknownKeyDeliversCiphertext(t *testing.T) {
+	producer := testKeys(t, "k2", "k1", "k2")
+	useKeys(t, producer)
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			SetKeyProvider(producer)
+			body, err := Marshal(c, newLoginFailed())
+			require.NoError(t, err)
+
+			// 消费者只持有旧 key：拿到与未配置 key 时相同的密文，handler 不会失败进入死信
+			SetKeyProvider(testKeys(t, "", "k1"))
+			var out event.Envelope[event.AuthLoginFailedEvent]
+			require.NoError(t, Unmarshal(c, body, &out))
+			assert.Equal(t, "k2", out.KeyID)
+			assert.True(t, strings.HasPrefix(out.Data.Identifier, cipherPrefix))
+			assert.Equal(t, "bad_password", out.Data.Reason)
+
+			// 之后拿到 key 的消费者仍能解密同一条消息
+			SetKeyProvider(testKeys(t, "", "k1", "k2"))
+			out = event.Envelope[event.AuthLoginFailedEvent]{}
+			require.NoError(t, Unmarshal(c, body, &out))
+			assert.Equal(t, "dave@example.com", out.Data.Identifier)
+		})
+	}
+}
+
+func TestPII_TamperedCiphertext(t *testing.T) {
+	useKeys(t, testKeys(t, "k1", "k1"))
+	a, err := MarshalEnvelope(newLoginFailed())
+	require.NoError(t, err)
+	b := newLoginFailed()
+	body, err := MarshalEnvelope(b)
+	require.NoError(t, err)
+
+	// 把 a 的密文挪到 b 中：EventID 是附加数据，解密失败
+	var ea, eb map[string]any
+	require.NoError(t, json.Unmarshal(a, &ea))
+	require.NoError(t, json.Unmarshal(body, &eb))
+	eb["data"].(map[string]any)["identifier"] = ea["data"].(map[string]any)["identifier"]
+	moved, err := json.Marshal(eb)
+	require.NoError(t, err)
+
+	var out event.Envelope[event.AuthLoginFailedEvent]
+	assert.Error(t, UnmarshalEnvelope(moved, &out))
+}
+
+func TestPII_Transcode(t *testing.T) {
+	// outbox 中落库的明文事件在中继时加密
+	plain, err := MarshalEnvelope(newLoginFailed())
+	require.NoError(t, err)
+	useKeys(t, testKeys(t, "k1", "k1"))
+
+	for _, c := range codecs {
+		body, err := Transcode(c, plain)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "dave@example.com", c.Name())
+		var out event.Envelope[event.AuthLoginFailedEvent]
+		require.NoError(t, Unmarshal(c, body, &out))
+		assert.Equal(t, "dave@example.com", out.Data.Identifier)
+	}
+
+	// 已加密的事件不会被重复加密；没有 PII 的事件原样返回
+	encrypted, err := MarshalEnvelope(newLoginFailed())
+	require.NoError(t, err)
+	body, err := Transcode(JSON, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, encrypted, body)
+	order, err := MarshalEnvelope(newOrderCreated())
+	require.NoError(t, err)
+	body, err = Transcode(JSON, order)
+	require.NoError(t, err)
+	assert.Equal(t, order, body)
+}
+
+func TestRedact(t *testing.T) {
+	useKeys(t, testKeys(t, "k1", "k1"))
+	phone := "+49 170 0000000"
+	in := event.NewEnvelope(testEventPII, 2, "test", "", piiV2{
+		UserID:  "u-1",
+		Email:   "a@example.com",
+		Phone:   &phone,
+		Profile: piiProfile{Name: "Ant"},
+		Aliases: []string{"ant", "octane"},
+	})
+	for _, c := range []Codec{JSON, Avro} {
+		body, err := Marshal(c, in)
+		require.NoError(t, err)
+		raw, err := c.Decode(body)
+		require.NoError(t, err)
+
+		red, err := Redact(raw)
+		require.NoError(t, err)
+		assert.Equal(t, &piiV2{
+			UserID:  "u-1",
+			Email:   Redacted,
+			Phone:   ptr(Redacted),
+			Profile: piiProfile{Name: Redacted},
+			Aliases: []string{Redacted, Redacted},
+		}, red.Data)
+		assert.Equal(t, "k1", red.KeyID)
+	}
+
+	// 未登记的事件原样保留
+	unknown := &event.Envelope[any]{EventType: "test.codec.unknown", Data: json.RawMessage(`{"email":"x"}`)}
+	red, err := Redact(unknown)
+	require.NoError(t, err)
+	assert.Equal(t, unknown.Data, red.Data)
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, KeySize))
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"current":"2025-01","keys":{"2025-01":"`+key+`"}}`), 0o600))
+
+	p, err := NewFileKeyProvider(path)
+	require.NoError(t, err)
+	id, k, err := p.Current()
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01", id)
+	assert.Len(t, k, KeySize)
+	_, err = p.Key("2024-07")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"current":"a","keys":{"a":"c2hvcnQ="}}`), 0o600))
+	_, err = NewFileKeyProvider(path)
+	assert.ErrorContains(t, err, "must be 32 bytes")
+}
+
+func ptr[T any](v T) *T { return &v }
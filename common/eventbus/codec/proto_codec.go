@@ -0,0 +1,281 @@
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	eventpb "github.com/uwu-octane/antBackend/api/v1/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ProtoPackage 是事件 payload 消息所在的 proto package（api/v1/event）。
+// payload Go 类型 X 对应消息 event.v1.X，字段按 json tag 与 proto 字段名对应；指针字段对应 proto3 optional。
+const ProtoPackage = "event.v1"
+
+// protoCodec 以 api/v1/event 中的 Envelope 编码，data 为 payload 消息的 protobuf 字节
+type protoCodec struct{}
+
+func (protoCodec) Name() string        { return "protobuf" }
+func (protoCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (protoCodec) Encode(env *event.Envelope[any]) ([]byte, error) {
+	v, err := payloadValue(env.Data)
+	if err != nil {
+		return nil, err
+	}
+	mt, err := protoMessageType(v.Type())
+	if err != nil {
+		return nil, err
+	}
+	msg := mt.New()
+	if err := toProto(v, msg); err != nil {
+		return nil, err
+	}
+	data, err := proto.Marshal(msg.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&eventpb.Envelope{
+		EventType:    env.EventType,
+		EventVersion: int32(env.EventVersion),
+		EventId:      env.EventID,
+		OccurredAt:   timestamppb.New(env.OccurredAt),
+		Producer:     env.Producer,
+		TraceId:      env.TraceID,
+		Data:         data,
+		KeyId:        env.KeyID,
+	})
+}
+
+func (protoCodec) Decode(b []byte) (*event.Envelope[any], error) {
+	var pe eventpb.Envelope
+	if err := proto.Unmarshal(b, &pe); err != nil {
+		return nil, err
+	}
+	t, err := payloadType(pe.EventType, int(pe.EventVersion))
+	if err != nil {
+		return nil, err
+	}
+	mt, err := protoMessageType(t)
+	if err != nil {
+		return nil, err
+	}
+	msg := mt.New()
+	if err := proto.Unmarshal(pe.Data, msg.Interface()); err != nil {
+		return nil, fmt.Errorf("codec: decode %s payload: %w", pe.EventType, err)
+	}
+	data := reflect.New(t)
+	if err := fromProto(msg, data.Elem()); err != nil {
+		return nil, err
+	}
+	return &event.Envelope[any]{
+		EventType:    pe.EventType,
+		EventVersion: int(pe.EventVersion),
+		EventID:      pe.EventId,
+		OccurredAt:   pe.OccurredAt.AsTime(),
+		Producer:     pe.Producer,
+		TraceID:      pe.TraceId,
+		KeyID:        pe.KeyId,
+		Data:         data.Interface(),
+	}, nil
+}
+
+func (protoCodec) PeekHead(b []byte) (*EnvelopeHead, error) {
+	var pe eventpb.Envelope
+	if err := proto.Unmarshal(b, &pe); err != nil {
+		return nil, err
+	}
+	return &EnvelopeHead{EventType: pe.EventType, EventVersion: int(pe.EventVersion), EventID: pe.EventId}, nil
+}
+
+func protoMessageType(t reflect.Type) (protoreflect.MessageType, error) {
+	name := protoreflect.FullName(ProtoPackage + "." + t.Name())
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("codec: no protobuf message %s for %s: %w", name, t, err)
+	}
+	return mt, nil
+}
+
+func toProto(v reflect.Value, m protoreflect.Message) error {
+	fds := m.Descriptor().Fields()
+	for _, f := range fieldsOf(v.Type()) {
+		fd := fds.ByName(protoreflect.Name(f.name))
+		if fd == nil {
+			return fmt.Errorf("codec: %s has no field %s", m.Descriptor().FullName(), f.name)
+		}
+		fv := v.Field(f.index)
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fd.IsList() {
+			if fv.Kind() != reflect.Slice || fv.Type() == bytesType {
+				return fmt.Errorf("codec: %s must be a slice", fd.FullName())
+			}
+			list := m.Mutable(fd).List()
+			for i := 0; i < fv.Len(); i++ {
+				pv, err := protoScalar(fd, fv.Index(i))
+				if err != nil {
+					return err
+				}
+				list.Append(pv)
+			}
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			if fv.Type() == timeType {
+				if t := fv.Interface().(time.Time); !t.IsZero() {
+					m.Set(fd, protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()))
+				}
+				continue
+			}
+			if fv.Kind() != reflect.Struct {
+				return fmt.Errorf("codec: %s must be a struct", fd.FullName())
+			}
+			if err := toProto(fv, m.Mutable(fd).Message()); err != nil {
+				return err
+			}
+			continue
+		}
+		pv, err := protoScalar(fd, fv)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, pv)
+	}
+	return nil
+}
+
+func protoScalar(fd protoreflect.FieldDescriptor, v reflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if v.Kind() == reflect.String {
+			return protoreflect.ValueOfString(v.String()), nil
+		}
+	case protoreflect.BoolKind:
+		if v.Kind() == reflect.Bool {
+			return protoreflect.ValueOfBool(v.Bool()), nil
+		}
+	case protoreflect.BytesKind:
+		if v.Type() == bytesType {
+			return protoreflect.ValueOfBytes(v.Bytes()), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if v.CanInt() {
+			return protoreflect.ValueOfInt32(int32(v.Int())), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if v.CanInt() {
+			return protoreflect.ValueOfInt64(v.Int()), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if v.CanUint() {
+			return protoreflect.ValueOfUint32(uint32(v.Uint())), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if v.CanUint() {
+			return protoreflect.ValueOfUint64(v.Uint()), nil
+		}
+	case protoreflect.FloatKind:
+		if v.CanFloat() {
+			return protoreflect.ValueOfFloat32(float32(v.Float())), nil
+		}
+	case protoreflect.DoubleKind:
+		if v.CanFloat() {
+			return protoreflect.ValueOfFloat64(v.Float()), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("codec: cannot encode %s into %s field %s", v.Type(), fd.Kind(), fd.FullName())
+}
+
+func fromProto(m protoreflect.Message, v reflect.Value) error {
+	fds := m.Descriptor().Fields()
+	for _, f := range fieldsOf(v.Type()) {
+		fd := fds.ByName(protoreflect.Name(f.name))
+		if fd == nil {
+			return fmt.Errorf("codec: %s has no field %s", m.Descriptor().FullName(), f.name)
+		}
+		fv := v.Field(f.index)
+		if fv.Kind() == reflect.Pointer {
+			if !m.Has(fd) {
+				continue
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+		if fd.IsList() {
+			list := m.Get(fd).List()
+			s := reflect.MakeSlice(fv.Type(), list.Len(), list.Len())
+			for i := 0; i < list.Len(); i++ {
+				if err := setScalar(fd, list.Get(i), s.Index(i)); err != nil {
+					return err
+				}
+			}
+			fv.Set(s)
+			continue
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			if !m.Has(fd) {
+				continue
+			}
+			if fv.Type() == timeType {
+				ts := m.Get(fd).Message().Interface().(*timestamppb.Timestamp)
+				fv.Set(reflect.ValueOf(ts.AsTime()))
+				continue
+			}
+			if err := fromProto(m.Get(fd).Message(), fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := setScalar(fd, m.Get(fd), fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setScalar(fd protoreflect.FieldDescriptor, pv protoreflect.Value, v reflect.Value) error {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		if v.Kind() == reflect.String {
+			v.SetString(pv.String())
+			return nil
+		}
+	case protoreflect.BoolKind:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(pv.Bool())
+			return nil
+		}
+	case protoreflect.BytesKind:
+		if v.Type() == bytesType {
+			v.SetBytes(pv.Bytes())
+			return nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if v.CanInt() {
+			v.SetInt(pv.Int())
+			return nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if v.CanUint() {
+			v.SetUint(pv.Uint())
+			return nil
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if v.CanFloat() {
+			v.SetFloat(pv.Float())
+			return nil
+		}
+	}
+	return fmt.Errorf("codec: cannot decode %s field %s into %s", fd.Kind(), fd.FullName(), v.Type())
+}
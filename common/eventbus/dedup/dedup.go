@@ -0,0 +1,37 @@
+package dedup
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Store 记录每个消费组已处理的 EventID，在 at-least-once 投递之上保证副作用最多执行一次
+type Store interface {
+	// Run 在 (group, eventID) 未处理过时执行 fn 并记录，已处理（或正在处理）时跳过并返回 false。
+	// fn 返回 error 时不留记录，重投递后可再次处理。
+	Run(ctx context.Context, group, eventID string, fn func(ctx context.Context) error) (processed bool, err error)
+}
+
+// Middleware 按 Envelope.EventID 去重后再调用 next，没有 event_id 的消息不去重直接处理
+func Middleware(store Store, group string, next subscriber.Handler) subscriber.Handler {
+	return func(ctx context.Context, msg *subscriber.Message) error {
+		head, err := msg.Head()
+		if err != nil || head.EventID == "" {
+			return next(ctx, msg)
+		}
+		processed, err := store.Run(ctx, group, head.EventID, func(ctx context.Context) error {
+			return next(ctx, msg)
+		})
+		if err != nil {
+			return err
+		}
+		if !processed {
+			logx.WithContext(ctx).Infow("duplicate event skipped",
+				logx.Field("group", group), logx.Field("event_id", head.EventID), logx.Field("event_type", head.EventType),
+				logx.Field("topic", msg.Topic), logx.Field("partition", msg.Partition), logx.Field("offset", msg.Offset))
+		}
+		return nil
+	}
+}
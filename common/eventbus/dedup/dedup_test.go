@@ -0,0 +1,176 @@
+package dedup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+func newTestRedisStore(t *testing.T, opts RedisOptions) (*RedisStore, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rds := redis.MustNewRedis(redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType})
+	return NewRedisStore(rds, opts), mr
+}
+
+func newTestMessage(t *testing.T, offset int64) *subscriber.Message {
+	env := event.NewEnvelope(event.EventTypeUserUpdated, 1, "test", "", map[string]string{"user_id": "u-1"})
+	// json.Marshal: the test payload is not registered in schema.Default
+	body, err := json.Marshal(env)
+	require.NoError(t, err)
+	return &subscriber.Message{Topic: "dev.test", Offset: offset, Key: []byte("u-1"), Value: body}
+}
+
+// redeliver hands the same message to a fresh PartitionWorker, like a consumer after rebalance
+func redeliver(handler subscriber.Handler, msg *subscriber.Message) {
+	w := subscriber.NewPartitionWorker(context.Background(), 1, handler, func(context.Context, *subscriber.Message, error) {}, func(int64) {})
+	w.Submit(msg)
+	w.Close()
+}
+
+func TestDedup_RedeliverySkipped(t *testing.T) {
+	store, mr := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:", TTL: time.Hour})
+	var calls atomic.Int32
+	h := Middleware(store, "group-a", func(context.Context, *subscriber.Message) error {
+		calls.Add(1)
+		return nil
+	})
+
+	msg := newTestMessage(t, 7)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, h(context.Background(), msg))
+	}
+	assert.EqualValues(t, 1, calls.Load())
+
+	head, err := codec.PeekHead(msg.Value)
+	require.NoError(t, err)
+	v, err := mr.Get("test:dedup:group-a:" + head.EventID)
+	require.NoError(t, err)
+	assert.Equal(t, stateDone, v)
+	assert.InDelta(t, time.Hour, mr.TTL("test:dedup:group-a:"+head.EventID), float64(time.Second))
+}
+
+func TestDedup_GroupsAreIndependent(t *testing.T) {
+	store, _ := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:"})
+	var calls atomic.Int32
+	next := func(context.Context, *subscriber.Message) error {
+		calls.Add(1)
+		return nil
+	}
+
+	msg := newTestMessage(t, 1)
+	require.NoError(t, Middleware(store, "group-a", next)(context.Background(), msg))
+	require.NoError(t, Middleware(store, "group-b", next)(context.Background(), msg))
+	require.NoError(t, Middleware(store, "group-a", next)(context.Background(), msg))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestDedup_FailedHandlerIsRetried(t *testing.T) {
+	store, _ := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:"})
+	var calls atomic.Int32
+	h := Middleware(store, "group-a", func(context.Context, *subscriber.Message) error {
+		if calls.Add(1) == 1 {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+
+	msg := newTestMessage(t, 1)
+	require.Error(t, h(context.Background(), msg))
+	require.NoError(t, h(context.Background(), msg))
+	require.NoError(t, h(context.Background(), msg))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestDedup_CrashMidHandlerAtMostOnce(t *testing.T) {
+	store, _ := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:", TTL: time.Hour})
+	var sideEffects atomic.Int32
+	h := Middleware(store, "group-a", func(context.Context, *subscriber.Message) error {
+		sideEffects.Add(1)
+		panic("process killed after side effect")
+	})
+
+	msg := newTestMessage(t, 3)
+	redeliver(h, msg)
+	redeliver(h, msg)
+	assert.EqualValues(t, 1, sideEffects.Load())
+}
+
+func TestDedup_CrashRecoversAfterInFlightTTL(t *testing.T) {
+	store, mr := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:", TTL: time.Hour, InFlightTTL: 30 * time.Second})
+	var calls atomic.Int32
+	h := Middleware(store, "group-a", func(context.Context, *subscriber.Message) error {
+		if calls.Add(1) == 1 {
+			panic("process killed before side effect")
+		}
+		return nil
+	})
+
+	msg := newTestMessage(t, 3)
+	redeliver(h, msg)
+	// still claimed by the crashed worker
+	redeliver(h, msg)
+	assert.EqualValues(t, 1, calls.Load())
+
+	mr.FastForward(31 * time.Second)
+	redeliver(h, msg)
+	redeliver(h, msg)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestDedup_ConcurrentDuplicates(t *testing.T) {
+	store, _ := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:"})
+	var calls atomic.Int32
+	h := Middleware(store, "group-a", func(context.Context, *subscriber.Message) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	msg := newTestMessage(t, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, h(context.Background(), msg))
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestDedup_MessageWithoutEventID(t *testing.T) {
+	store, _ := newTestRedisStore(t, RedisOptions{KeyPrefix: "test:dedup:"})
+	var calls atomic.Int32
+	h := Middleware(store, "group-a", func(context.Context, *subscriber.Message) error {
+		calls.Add(1)
+		return nil
+	})
+
+	msg := &subscriber.Message{Topic: "dev.test", Value: []byte(`{"event_type":"user.updated"}`)}
+	require.NoError(t, h(context.Background(), msg))
+	require.NoError(t, h(context.Background(), msg))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestNewEventID_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+	now := time.Now()
+	for i := 0; i < 1000; i++ {
+		id := event.NewEventID(now)
+		require.False(t, seen[id], "duplicate event id %s", id)
+		seen[id] = true
+	}
+}
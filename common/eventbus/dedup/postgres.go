@@ -0,0 +1,129 @@
+package dedup
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const defaultPurgeInterval = 10 * time.Minute
+
+type sessionKey struct{}
+
+type PostgresOptions struct {
+	// TTL 已处理记录的保留时间，需覆盖 broker 可能重投递的窗口
+	TTL time.Duration
+	// PurgeInterval Start 循环中清理过期记录的间隔
+	PurgeInterval time.Duration
+}
+
+// PostgresStore 在同一事务里写 processed_events 并执行 handler。
+// handler 通过 SessionFromContext 拿到该事务写业务表时，去重记录与副作用原子提交：
+// 崩溃会一起回滚，重投递后重新处理，数据库副作用恰好一次。
+// 表不会自动过期：使用该 store 的服务需把它加入 service group，由 Start 定期清理超过 TTL 的记录。
+type PostgresStore struct {
+	conn sqlx.SqlConn
+	opts PostgresOptions
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started atomic.Bool
+	done    chan struct{}
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+func NewPostgresStore(conn sqlx.SqlConn, opts PostgresOptions) *PostgresStore {
+	if opts.TTL <= 0 {
+		opts.TTL = defaultTTL
+	}
+	if opts.PurgeInterval <= 0 {
+		opts.PurgeInterval = defaultPurgeInterval
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PostgresStore{conn: conn, opts: opts, ctx: ctx, cancel: cancel, done: make(chan struct{})}
+}
+
+// Start 阻塞运行清理循环，直到 Stop 被调用（实现 service.Service）
+func (s *PostgresStore) Start() {
+	s.started.Store(true)
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.opts.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		s.purgeExpired()
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PostgresStore) Stop() {
+	s.cancel()
+	if s.started.Load() {
+		<-s.done
+	}
+}
+
+func (s *PostgresStore) purgeExpired() {
+	n, err := s.Purge(s.ctx, time.Now().Add(-s.opts.TTL))
+	if err != nil {
+		if s.ctx.Err() == nil {
+			logx.Errorw("purge processed events failed", logx.Field("error", err))
+		}
+		return
+	}
+	if n > 0 {
+		logx.Infow("purged processed events", logx.Field("count", n))
+	}
+}
+
+// SessionFromContext 返回 PostgresStore.Run 中的事务
+func SessionFromContext(ctx context.Context) (sqlx.Session, bool) {
+	session, ok := ctx.Value(sessionKey{}).(sqlx.Session)
+	return session, ok
+}
+
+func (s *PostgresStore) Run(ctx context.Context, group, eventID string, fn func(ctx context.Context) error) (bool, error) {
+	processed := false
+	err := s.conn.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		// 并发的重复投递会阻塞在唯一索引上，直到前一个事务提交/回滚
+		const query = "INSERT INTO processed_events (consumer_group, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+		res, err := session.ExecCtx(ctx, query, group, eventID)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+		if err := fn(context.WithValue(ctx, sessionKey{}, session)); err != nil {
+			return err
+		}
+		processed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return processed, nil
+}
+
+// Purge 删除 before 之前的记录，保留窗口需覆盖 broker 可能重投递的时间
+func (s *PostgresStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	const query = "DELETE FROM processed_events WHERE processed_at < $1"
+	res, err := s.conn.ExecCtx(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
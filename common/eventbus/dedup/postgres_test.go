@@ -0,0 +1,171 @@
+package dedup
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const updateQuery = "UPDATE users SET version = version + 1 WHERE id = $1"
+
+// newTestPostgresStore scripts the database with sqlmock, every test lists the statements
+// of each delivery in order and the mock checks that nothing else ran
+func newTestPostgresStore(t *testing.T, opts PostgresOptions) (*PostgresStore, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+	return NewPostgresStore(sqlx.NewSqlConnFromDB(db), opts), mock
+}
+
+// expectClaim opens the transaction and inserts the processed_events row, claimed is false for a duplicate
+func expectClaim(mock sqlmock.Sqlmock, eventID string, claimed bool) {
+	var n int64
+	if claimed {
+		n = 1
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_events (consumer_group, event_id)")).
+		WithArgs("group-a", eventID).
+		WillReturnResult(sqlmock.NewResult(0, n))
+}
+
+func expectSideEffect(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(updateQuery)).WithArgs("u-1").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// sideEffect writes through the dedup transaction, like a consumer updating its own tables
+func sideEffect(ctx context.Context) error {
+	session, ok := SessionFromContext(ctx)
+	if !ok {
+		return errors.New("no dedup transaction in ctx")
+	}
+	_, err := session.ExecCtx(ctx, updateQuery, "u-1")
+	return err
+}
+
+func eventIDOf(t *testing.T, msg *subscriber.Message) string {
+	head, err := msg.Head()
+	require.NoError(t, err)
+	return head.EventID
+}
+
+func TestPostgres_CommittedEventSkipped(t *testing.T) {
+	store, mock := newTestPostgresStore(t, PostgresOptions{})
+	msg := newTestMessage(t, 1)
+	id := eventIDOf(t, msg)
+
+	expectClaim(mock, id, true)
+	expectSideEffect(mock)
+	mock.ExpectCommit()
+	// the committed row makes the insert a no-op, the handler does not run
+	expectClaim(mock, id, false)
+	mock.ExpectCommit()
+
+	calls := 0
+	h := Middleware(store, "group-a", func(ctx context.Context, _ *subscriber.Message) error {
+		calls++
+		return sideEffect(ctx)
+	})
+	require.NoError(t, h(context.Background(), msg))
+	require.NoError(t, h(context.Background(), msg))
+	assert.Equal(t, 1, calls)
+}
+
+func TestPostgres_FailedHandlerIsRetried(t *testing.T) {
+	store, mock := newTestPostgresStore(t, PostgresOptions{})
+	msg := newTestMessage(t, 2)
+	id := eventIDOf(t, msg)
+
+	expectClaim(mock, id, true)
+	expectSideEffect(mock)
+	mock.ExpectRollback()
+	// the rollback removed the claim, the redelivery claims and processes again
+	expectClaim(mock, id, true)
+	expectSideEffect(mock)
+	mock.ExpectCommit()
+
+	calls := 0
+	h := Middleware(store, "group-a", func(ctx context.Context, _ *subscriber.Message) error {
+		calls++
+		if err := sideEffect(ctx); err != nil {
+			return err
+		}
+		if calls == 1 {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+	require.Error(t, h(context.Background(), msg))
+	require.NoError(t, h(context.Background(), msg))
+	assert.Equal(t, 2, calls)
+}
+
+func TestPostgres_CrashMidHandlerRollsBack(t *testing.T) {
+	store, mock := newTestPostgresStore(t, PostgresOptions{})
+	msg := newTestMessage(t, 3)
+	id := eventIDOf(t, msg)
+
+	expectClaim(mock, id, true)
+	expectSideEffect(mock)
+	mock.ExpectRollback()
+	expectClaim(mock, id, true)
+	expectSideEffect(mock)
+	mock.ExpectCommit()
+
+	calls := 0
+	h := Middleware(store, "group-a", func(ctx context.Context, _ *subscriber.Message) error {
+		calls++
+		if err := sideEffect(ctx); err != nil {
+			return err
+		}
+		if calls == 1 {
+			panic("process killed after side effect")
+		}
+		return nil
+	})
+	redeliver(h, msg)
+	redeliver(h, msg)
+	assert.Equal(t, 2, calls)
+}
+
+// purgeCutoff matches the processed_at cutoff of a purge run now
+type purgeCutoff struct {
+	ttl time.Duration
+}
+
+func (c purgeCutoff) Match(v driver.Value) bool {
+	before, ok := v.(time.Time)
+	return ok && time.Since(before.Add(c.ttl)).Abs() < time.Minute
+}
+
+func TestPostgresStore_StartPurgesExpired(t *testing.T) {
+	store, mock := newTestPostgresStore(t, PostgresOptions{TTL: 48 * time.Hour, PurgeInterval: time.Hour})
+	stopped := make(chan struct{})
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM processed_events WHERE processed_at < $1")).
+		WithArgs(purgeCutoff{ttl: 48 * time.Hour}).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	go func() {
+		store.Start()
+		close(stopped)
+	}()
+	require.Eventually(t, func() bool { return mock.ExpectationsWereMet() == nil }, time.Second, time.Millisecond)
+	store.Stop()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+}
@@ -0,0 +1,82 @@
+package dedup
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+const (
+	stateProcessing = "processing"
+	stateDone       = "done"
+
+	defaultTTL = 24 * time.Hour
+)
+
+type RedisOptions struct {
+	// KeyPrefix 例如 "user:dedup:"，完整 key 为 <prefix><group>:<event_id>
+	KeyPrefix string
+	// TTL 已处理记录的保留时间，需覆盖 broker 可能重投递的窗口
+	TTL time.Duration
+	// InFlightTTL 处理中占位的有效期。为 0 时与 TTL 相同：handler 中途崩溃的事件不会再被处理（严格 at-most-once）；
+	// 设为较短值则占位过期后允许重投递重新处理，以少量重复换取崩溃可恢复。
+	InFlightTTL time.Duration
+}
+
+// RedisStore 用 SET NX 占位 + TTL 记录已处理事件
+type RedisStore struct {
+	rds  *redis.Redis
+	opts RedisOptions
+}
+
+var _ Store = (*RedisStore)(nil)
+
+func NewRedisStore(rds *redis.Redis, opts RedisOptions) *RedisStore {
+	if opts.TTL <= 0 {
+		opts.TTL = defaultTTL
+	}
+	if opts.InFlightTTL <= 0 || opts.InFlightTTL > opts.TTL {
+		opts.InFlightTTL = opts.TTL
+	}
+	return &RedisStore{rds: rds, opts: opts}
+}
+
+func (s *RedisStore) Run(ctx context.Context, group, eventID string, fn func(ctx context.Context) error) (bool, error) {
+	key := s.key(group, eventID)
+	ok, err := s.rds.SetnxExCtx(ctx, key, stateProcessing, seconds(s.opts.InFlightTTL))
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+
+	// panic 视同崩溃：占位保留到 InFlightTTL 过期
+	if err := fn(ctx); err != nil {
+		if _, derr := s.rds.DelCtx(context.WithoutCancel(ctx), key); derr != nil {
+			logx.WithContext(ctx).Errorw("release dedup claim failed",
+				logx.Field("key", key), logx.Field("error", derr))
+		}
+		return false, err
+	}
+
+	// 副作用已发生，记录失败只记日志：占位仍会在 InFlightTTL 内挡住重复
+	if err := s.rds.SetexCtx(context.WithoutCancel(ctx), key, stateDone, seconds(s.opts.TTL)); err != nil {
+		logx.WithContext(ctx).Errorw("mark event processed failed",
+			logx.Field("key", key), logx.Field("error", err))
+	}
+	return true, nil
+}
+
+func (s *RedisStore) key(group, eventID string) string {
+	return s.opts.KeyPrefix + group + ":" + eventID
+}
+
+func seconds(d time.Duration) int {
+	if d < time.Second {
+		return 1
+	}
+	return int(d / time.Second)
+}
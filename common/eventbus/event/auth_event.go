@@ -0,0 +1,41 @@
+package event
+
+// 登录方式
+const (
+	AuthMethodPassword  = "password"
+	AuthMethodLoginCode = "login_code"
+	AuthMethodMagicLink = "magic_link"
+	AuthMethodDevice    = "device"
+)
+
+type AuthLoginSucceededEvent struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+	Method    string `json:"method"`
+}
+
+type AuthLoginFailedEvent struct {
+	// 登录时提交的 email / username（已归一化），账号不存在时 UserID 为空
+	Identifier string `json:"identifier,omitempty" pii:"encrypt"`
+	UserID     string `json:"user_id,omitempty"`
+	Method     string `json:"method"`
+	Reason     string `json:"reason"`
+}
+
+type AuthSessionRevokedEvent struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+	// logout / logout_all
+	Reason string `json:"reason"`
+}
+
+type AuthRefreshReusedEvent struct {
+	UserID    string `json:"user_id"`
+	SessionID string `json:"session_id"`
+	// 被重放的 refresh token jti
+	Jti string `json:"jti"`
+}
+
+type AuthPasswordChangedEvent struct {
+	UserID string `json:"user_id"`
+}
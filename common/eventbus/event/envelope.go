@@ -1,6 +1,8 @@
 package event
 
 import (
+	"crypto/rand"
+	"sync"
 	"time"
 
 	"github.com/oklog/ulid"
@@ -8,9 +10,16 @@ import (
 
 const (
 	EventTypeOrderCreated   = "order.created"
+	EventTypeOrderCancelled = "order.cancelled"
 	EventTypeUserRegistered = "user.registered"
 	EventTypeUserUpdated    = "user.updated"
 	EventTypeUserDeleted    = "user.deleted"
+
+	EventTypeAuthLoginSucceeded  = "auth.login.succeeded"
+	EventTypeAuthLoginFailed     = "auth.login.failed"
+	EventTypeAuthSessionRevoked  = "auth.session.revoked"
+	EventTypeAuthRefreshReused   = "auth.refresh.reused"
+	EventTypeAuthPasswordChanged = "auth.password.changed"
 )
 
 type Envelope[T any] struct {
@@ -26,18 +35,33 @@ type Envelope[T any] struct {
 	Producer string `json:"producer"`
 	// 追踪 ID：用于关联事件和上游事件
 	TraceID string `json:"trace_id,omitempty"`
+	// PII 字段加密使用的 key id：data 中 pii:"encrypt" 字段为密文，空表示 payload 为明文
+	KeyID string `json:"key_id,omitempty"`
 	// 事件数据：具体事件内容
 	Data T `json:"data"`
 }
 
 func NewEnvelope[T any](eventType string, eventVersion int, producer string, traceID string, data T) *Envelope[T] {
+	now := time.Now().UTC()
 	return &Envelope[T]{
 		EventType:    eventType,
 		EventVersion: eventVersion,
-		EventID:      ulid.MustNew(1, nil).String(),
-		OccurredAt:   time.Now().UTC(),
+		EventID:      NewEventID(now),
+		OccurredAt:   now,
 		Producer:     producer,
 		TraceID:      traceID,
 		Data:         data,
 	}
 }
+
+var (
+	idMu      sync.Mutex
+	idEntropy = ulid.Monotonic(rand.Reader, 0)
+)
+
+// NewEventID 生成按时间有序的 ULID，同一毫秒内单调递增
+func NewEventID(t time.Time) string {
+	idMu.Lock()
+	defer idMu.Unlock()
+	return ulid.MustNew(ulid.Timestamp(t), idEntropy).String()
+}
@@ -2,10 +2,6 @@ package event
 
 import "time"
 
-const (
-	TopicSuffixOrderEvents = ".order.service.order-events"
-)
-
 type OrderCreatedEvent struct {
 	OrderID   string    `json:"order_id"`
 	UserID    string    `json:"user_id"`
@@ -15,3 +11,10 @@ type OrderCreatedEvent struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+type OrderCancelledEvent struct {
+	OrderID     string    `json:"order_id"`
+	UserID      string    `json:"user_id"`
+	Reason      string    `json:"reason"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
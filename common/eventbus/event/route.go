@@ -0,0 +1,74 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var ErrNoRoute = errors.New("event: no stream registered for event type")
+
+// Routes 把 EventType 映射到 Stream，EventBusPublisher 据此选择 topic
+type Routes struct {
+	mu     sync.RWMutex
+	routes map[string]Stream
+}
+
+// DefaultRoutes 全局路由表，事件定义所在的包在 init 中与 schema 一起登记
+var DefaultRoutes = NewRoutes()
+
+func NewRoutes() *Routes {
+	return &Routes{routes: make(map[string]Stream)}
+}
+
+// Register 把 eventType 路由到 stream；同一类型登记到不同 stream 会 panic
+func (r *Routes) Register(eventType string, stream Stream) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.routes[eventType]; ok && s != stream {
+		panic(fmt.Sprintf("event: %s already routed to %s", eventType, s))
+	}
+	r.routes[eventType] = stream
+}
+
+// StreamOf 返回 eventType 所在的 stream
+func (r *Routes) StreamOf(eventType string) (Stream, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.routes[eventType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNoRoute, eventType)
+	}
+	return s, nil
+}
+
+// Streams 返回已登记的 stream（去重、排序）
+func (r *Routes) Streams() []Stream {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	seen := make(map[Stream]bool)
+	streams := make([]Stream, 0, len(r.routes))
+	for _, s := range r.routes {
+		if !seen[s] {
+			seen[s] = true
+			streams = append(streams, s)
+		}
+	}
+	sort.Slice(streams, func(i, j int) bool { return streams[i] < streams[j] })
+	return streams
+}
+
+// EventTypes 返回路由到 stream 的事件类型（排序后）
+func (r *Routes) EventTypes(stream Stream) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var types []string
+	for t, s := range r.routes {
+		if s == stream {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutes(t *testing.T) {
+	r := NewRoutes()
+	r.Register("a.created", StreamOrderEvents)
+	r.Register("b.created", StreamAuthEvents)
+	r.Register("a.deleted", StreamOrderEvents)
+	// 重复登记到同一 stream 是允许的
+	r.Register("a.created", StreamOrderEvents)
+
+	s, err := r.StreamOf("a.created")
+	require.NoError(t, err)
+	assert.Equal(t, StreamOrderEvents, s)
+
+	_, err = r.StreamOf("unknown")
+	assert.ErrorIs(t, err, ErrNoRoute)
+
+	assert.Equal(t, []Stream{StreamAuthEvents, StreamOrderEvents}, r.Streams())
+	assert.Equal(t, []string{"a.created", "a.deleted"}, r.EventTypes(StreamOrderEvents))
+	assert.Panics(t, func() { r.Register("a.created", StreamUserEvents) })
+}
+
+func TestDefaultRoutes(t *testing.T) {
+	s, err := DefaultRoutes.StreamOf(EventTypeAuthLoginFailed)
+	require.NoError(t, err)
+	assert.Equal(t, StreamAuthEvents, s)
+
+	s, err = DefaultRoutes.StreamOf(EventTypeOrderCreated)
+	require.NoError(t, err)
+	assert.Equal(t, StreamOrderEvents, s)
+}
+
+func TestTopicSet(t *testing.T) {
+	topics := BuildTopics(EnvDev)
+	assert.Equal(t, "dev.user.service.user-events", topics.UserEvents())
+	assert.Equal(t, "dev.auth.service.auth-events", topics.AuthEvents())
+	assert.Equal(t, "dev.order.service.order-events", topics.OrderEvents())
+	assert.Equal(t, []string{"dev.user.service.user-events", "dev.order.service.order-events"},
+		topics.Topics(StreamUserEvents, StreamOrderEvents))
+	assert.Equal(t, "dev.user.service.user-events.dlq", DLQTopic(topics.UserEvents()))
+}
@@ -0,0 +1,27 @@
+package event
+
+import "github.com/uwu-octane/antBackend/common/eventbus/schema"
+
+// 在 schema.Default 登记本包定义的事件 payload 并在 DefaultRoutes 登记所在 stream；
+// 服务私有的事件在各自的 event 包中登记
+func init() {
+	schema.Register[OrderCreatedEvent](schema.Default, EventTypeOrderCreated, 1)
+	schema.Register[OrderCancelledEvent](schema.Default, EventTypeOrderCancelled, 1)
+	DefaultRoutes.Register(EventTypeOrderCreated, StreamOrderEvents)
+	DefaultRoutes.Register(EventTypeOrderCancelled, StreamOrderEvents)
+
+	schema.Register[AuthLoginSucceededEvent](schema.Default, EventTypeAuthLoginSucceeded, 1)
+	schema.Register[AuthLoginFailedEvent](schema.Default, EventTypeAuthLoginFailed, 1)
+	schema.Register[AuthSessionRevokedEvent](schema.Default, EventTypeAuthSessionRevoked, 1)
+	schema.Register[AuthRefreshReusedEvent](schema.Default, EventTypeAuthRefreshReused, 1)
+	schema.Register[AuthPasswordChangedEvent](schema.Default, EventTypeAuthPasswordChanged, 1)
+	for _, t := range []string{
+		EventTypeAuthLoginSucceeded,
+		EventTypeAuthLoginFailed,
+		EventTypeAuthSessionRevoked,
+		EventTypeAuthRefreshReused,
+		EventTypeAuthPasswordChanged,
+	} {
+		DefaultRoutes.Register(t, StreamAuthEvents)
+	}
+}
@@ -1,5 +1,10 @@
 package event
 
+import (
+	"strconv"
+	"time"
+)
+
 type Env string
 
 const (
@@ -7,13 +12,84 @@ const (
 	EnvProd Env = "prod"
 )
 
+// Stream 是一条事件流的名字（不含 Env 前缀），每个 stream 对应一个 topic；事件类型通过 Routes 映射到 stream
+type Stream string
+
+const (
+	StreamUserEvents Stream = "user.service.user-events"
+	// 认证安全事件流：登录、登出、刷新令牌重放等
+	StreamAuthEvents  Stream = "auth.service.auth-events"
+	StreamOrderEvents Stream = "order.service.order-events"
+)
+
+// Topic 返回 env 下 stream 的 topic，例如 dev.user.service.user-events
+func (s Stream) Topic(env Env) string {
+	return string(env) + "." + string(s)
+}
+
+// TopicSet 是某个 Env 下各 stream 的 topic 名
 type TopicSet struct {
-	UserEvents string
+	Env Env
+}
+
+func BuildTopics(env Env) TopicSet {
+	return TopicSet{Env: env}
+}
+
+// Topic 返回 stream 的 topic
+func (t TopicSet) Topic(stream Stream) string {
+	return stream.Topic(t.Env)
+}
+
+// UserEvents 返回用户事件流的 topic
+func (t TopicSet) UserEvents() string {
+	return t.Topic(StreamUserEvents)
+}
+
+// AuthEvents 返回认证事件流的 topic
+func (t TopicSet) AuthEvents() string {
+	return t.Topic(StreamAuthEvents)
+}
+
+// OrderEvents 返回订单事件流的 topic
+func (t TopicSet) OrderEvents() string {
+	return t.Topic(StreamOrderEvents)
+}
+
+// Topics 返回 streams 对应的 topic
+func (t TopicSet) Topics(streams ...Stream) []string {
+	topics := make([]string, len(streams))
+	for i, s := range streams {
+		topics[i] = t.Topic(s)
+	}
+	return topics
+}
+
+const (
+	retryTopicInfix = ".retry."
+	dlqTopicSuffix  = ".dlq"
+)
+
+// RetryTopic 返回 topic 的延迟重试 topic，例如 dev.user.service.user-events.retry.1m。
+// topic 应来自 BuildTopics，派生名称因此带有相同的 Env 前缀。
+func RetryTopic(topic string, delay time.Duration) string {
+	return topic + retryTopicInfix + delayLabel(delay)
+}
+
+// DLQTopic 返回 topic 的死信 topic，例如 dev.user.service.user-events.dlq
+func DLQTopic(topic string) string {
+	return topic + dlqTopicSuffix
 }
 
-func BuildTopics(env Env, suffix string) TopicSet {
-	prefix := string(env)
-	return TopicSet{
-		UserEvents: prefix + suffix,
+func delayLabel(d time.Duration) string {
+	switch {
+	case d >= time.Hour && d%time.Hour == 0:
+		return strconv.FormatInt(int64(d/time.Hour), 10) + "h"
+	case d >= time.Minute && d%time.Minute == 0:
+		return strconv.FormatInt(int64(d/time.Minute), 10) + "m"
+	case d >= time.Second && d%time.Second == 0:
+		return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+	default:
+		return strconv.FormatInt(d.Milliseconds(), 10) + "ms"
 	}
 }
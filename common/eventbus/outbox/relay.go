@@ -0,0 +1,291 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const (
+	purgeInterval = time.Minute
+	purgeBatch    = 1000
+)
+
+// Conf 控制 outbox 中继：轮询、重试退避与已发送行的保留时间
+type Conf struct {
+	Disabled       bool `json:",optional"`
+	PollIntervalMs int  `json:",default=500"`
+	BatchSize      int  `json:",default=100"`
+	MaxAttempts    int  `json:",default=10"`
+	BaseBackoffMs  int  `json:",default=1000"`
+	MaxBackoffMs   int  `json:",default=60000"`
+	RetentionHours int  `json:",default=72"`
+}
+
+// Publisher 发送一条已编码的 outbox 行，确认结果通过 Delivery 获取
+type Publisher interface {
+	PublishEncoded(ctx context.Context, msg *Message) *publisher.Delivery
+}
+
+type eventBusPublisher struct {
+	p *publisher.EventBusPublisher
+}
+
+// EventBus 把 EventBusPublisher 适配为 Publisher，topic 按事件类型路由
+func EventBus(p *publisher.EventBusPublisher) Publisher {
+	return eventBusPublisher{p: p}
+}
+
+func (e eventBusPublisher) PublishEncoded(ctx context.Context, msg *Message) *publisher.Delivery {
+	return publisher.SendEncodedAsync(ctx, e.p, "", msg.Payload, []byte(msg.PartitionKey), msg.HeaderMap())
+}
+
+type relayMetrics struct {
+	pending metric.GaugeVec
+	parked  metric.GaugeVec
+	lag     metric.GaugeVec
+	relayed metric.CounterVec
+}
+
+var (
+	metricsMu sync.Mutex
+	// metricsByName 同一进程内可能运行多个服务的中继（cmd/boot），指标按 namespace 只注册一次
+	metricsByName = map[string]*relayMetrics{}
+)
+
+func metricsFor(namespace string) *relayMetrics {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	if m, ok := metricsByName[namespace]; ok {
+		return m
+	}
+	m := &relayMetrics{
+		pending: metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "pending",
+			Help:      "outbox rows not yet relayed to kafka.",
+		}),
+		parked: metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "parked",
+			Help:      "outbox rows that exhausted their attempts.",
+		}),
+		lag: metric.NewGaugeVec(&metric.GaugeVecOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "lag_seconds",
+			Help:      "age of the oldest outbox row not yet relayed.",
+		}),
+		relayed: metric.NewCounterVec(&metric.CounterVecOpts{
+			Namespace: namespace,
+			Subsystem: "outbox",
+			Name:      "relayed_total",
+			Help:      "outbox publish attempts by result.",
+			Labels:    []string{"result"},
+		}),
+	}
+	metricsByName[namespace] = m
+	return m
+}
+
+// Relay 把 outbox 表中已提交的事件转发到 Kafka（at-least-once）。
+// 可多副本运行：每轮通过事务级 advisory lock 选出唯一中继副本，
+// 同一 partition key 的事件严格按写入顺序发送，失败的事件按指数退避重试。
+type Relay struct {
+	name    string
+	conf    Conf
+	store   Store
+	pusher  Publisher
+	metrics *relayMetrics
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	started   atomic.Bool
+	done      chan struct{}
+	lastPurge time.Time
+}
+
+// NewRelay 的 name 是服务名，用作指标 namespace（<name>_outbox_pending 等）与日志前缀
+func NewRelay(name string, conf Conf, store Store, pusher Publisher) *Relay {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Relay{
+		name:    name,
+		conf:    conf,
+		store:   store,
+		pusher:  pusher,
+		metrics: metricsFor(name),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start 阻塞运行轮询循环，直到 Stop 被调用（实现 service.Service）
+func (r *Relay) Start() {
+	r.started.Store(true)
+	defer close(r.done)
+
+	ticker := time.NewTicker(time.Duration(r.conf.PollIntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		r.poll()
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) Stop() {
+	r.cancel()
+	if r.started.Load() {
+		<-r.done
+	}
+}
+
+func (r *Relay) poll() {
+	for {
+		sent, err := r.RelayOnce(r.ctx)
+		if err != nil {
+			if r.ctx.Err() == nil {
+				logx.Errorw(r.name+" outbox relay failed", logx.Field("error", err))
+			}
+			break
+		}
+		// 满批说明还有积压，不等 ticker 继续发送
+		if sent < r.conf.BatchSize || r.ctx.Err() != nil {
+			break
+		}
+	}
+	r.reportLag()
+	r.purge()
+}
+
+// RelayOnce 发送一批到期的行，返回标记为已发送的行数；未抢到 advisory lock 的副本不发送
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	sent := 0
+	_, err := r.store.TransactRelay(ctx, func(ctx context.Context, session sqlx.Session) error {
+		msgs, err := r.store.ClaimDue(ctx, session, r.conf.BatchSize, r.conf.MaxAttempts)
+		if err != nil {
+			return err
+		}
+
+		// 按波次发送，每一波每个 key 至多一行：不同 key 的行由异步发布器合批发送，
+		// 同一 key 的下一行要等上一行确认后才发送；某个 key 失败后，该 key 的后续行留到下次，保证 key 内顺序
+		blocked := make(map[string]bool)
+		ids := make([]int64, 0, len(msgs))
+		for len(msgs) > 0 {
+			var wave, rest []*Message
+			inWave := make(map[string]bool)
+			for _, msg := range msgs {
+				switch {
+				case blocked[msg.PartitionKey]:
+				case inWave[msg.PartitionKey]:
+					rest = append(rest, msg)
+				default:
+					inWave[msg.PartitionKey] = true
+					wave = append(wave, msg)
+				}
+			}
+
+			deliveries := make([]*publisher.Delivery, len(wave))
+			for i, msg := range wave {
+				deliveries[i] = r.pusher.PublishEncoded(ctx, msg)
+			}
+			for i, msg := range wave {
+				if err := deliveries[i].Wait(ctx); err != nil {
+					blocked[msg.PartitionKey] = true
+					r.metrics.relayed.Inc("error")
+					if err := r.markFailed(ctx, session, msg, err); err != nil {
+						return err
+					}
+					continue
+				}
+				r.metrics.relayed.Inc("ok")
+				ids = append(ids, msg.Id)
+			}
+			msgs = rest
+		}
+
+		// 发送后事务回滚时这些行会在下一轮重发，消费者按 EventID 去重
+		if err := r.store.MarkSent(ctx, session, ids); err != nil {
+			return err
+		}
+		sent = len(ids)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return sent, nil
+}
+
+func (r *Relay) markFailed(ctx context.Context, session sqlx.Session, msg *Message, cause error) error {
+	attempts := msg.Attempts + 1
+	fields := []logx.LogField{
+		logx.Field("outbox_id", msg.Id),
+		logx.Field("event_id", msg.EventId),
+		logx.Field("event_type", msg.EventType),
+		logx.Field("attempts", attempts),
+		logx.Field("error", cause),
+	}
+	if attempts >= r.conf.MaxAttempts {
+		logx.Errorw(r.name+" outbox event parked after max attempts", fields...)
+	} else {
+		logx.Infow(r.name+" outbox publish failed, will retry", fields...)
+	}
+	return r.store.MarkFailed(ctx, session, msg.Id, cause.Error(), time.Now().Add(r.backoff(attempts)))
+}
+
+// backoff 从 BaseBackoffMs 起每次失败翻倍，上限 MaxBackoffMs
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := time.Duration(r.conf.BaseBackoffMs) * time.Millisecond
+	limit := time.Duration(r.conf.MaxBackoffMs) * time.Millisecond
+	for i := 1; i < attempts && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	return d
+}
+
+func (r *Relay) reportLag() {
+	stats, err := r.store.Stats(r.ctx, r.conf.MaxAttempts)
+	if err != nil {
+		if r.ctx.Err() == nil {
+			logx.Errorw(r.name+" outbox stats failed", logx.Field("error", err))
+		}
+		return
+	}
+	r.metrics.pending.Set(float64(stats.Pending))
+	r.metrics.parked.Set(float64(stats.Parked))
+	r.metrics.lag.Set(stats.OldestPendingSeconds)
+}
+
+func (r *Relay) purge() {
+	if r.conf.RetentionHours <= 0 || time.Since(r.lastPurge) < purgeInterval {
+		return
+	}
+	r.lastPurge = time.Now()
+	before := time.Now().Add(-time.Duration(r.conf.RetentionHours) * time.Hour)
+	n, err := r.store.PurgeSent(r.ctx, before, purgeBatch)
+	if err != nil {
+		if r.ctx.Err() == nil {
+			logx.Errorw(r.name+" outbox purge failed", logx.Field("error", err))
+		}
+		return
+	}
+	if n > 0 {
+		logx.Infow(r.name+" outbox purged sent rows", logx.Field("count", n))
+	}
+}
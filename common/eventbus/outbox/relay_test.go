@@ -0,0 +1,297 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var errCommit = errors.New("commit failed")
+
+// memStore is an in-memory Store with the ClaimDue rules of PostgresStore,
+// TransactRelay restores the rows when fn fails or when a commit failure is injected.
+type memStore struct {
+	rows       []*Message
+	failCommit int
+}
+
+var _ Store = (*memStore)(nil)
+
+func newMemStore(keys ...string) *memStore {
+	s := &memStore{}
+	for i, key := range keys {
+		s.rows = append(s.rows, &Message{
+			Id:           int64(i + 1),
+			EventId:      fmt.Sprintf("%s%d", key, i+1),
+			EventType:    "test.event",
+			PartitionKey: key,
+		})
+	}
+	return s
+}
+
+func (s *memStore) row(eventID string) *Message {
+	for _, m := range s.rows {
+		if m.EventId == eventID {
+			return m
+		}
+	}
+	return nil
+}
+
+func (s *memStore) TransactRelay(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) (bool, error) {
+	snapshot := make([]Message, len(s.rows))
+	for i, m := range s.rows {
+		snapshot[i] = *m
+	}
+	err := fn(ctx, nil)
+	if err == nil && s.failCommit > 0 {
+		s.failCommit--
+		err = errCommit
+	}
+	if err != nil {
+		for i := range s.rows {
+			*s.rows[i] = snapshot[i]
+		}
+	}
+	return true, err
+}
+
+func (s *memStore) ClaimDue(_ context.Context, _ sqlx.Session, limit, maxAttempts int) ([]*Message, error) {
+	now := time.Now()
+	held := make(map[string]bool)
+	var out []*Message
+	for _, m := range s.rows {
+		if m.SentAt.Valid {
+			continue
+		}
+		waiting := m.NextAttemptAt.After(now) || m.Attempts >= maxAttempts
+		if !waiting && !held[m.PartitionKey] && len(out) < limit {
+			out = append(out, m)
+		}
+		if waiting {
+			held[m.PartitionKey] = true
+		}
+	}
+	return out, nil
+}
+
+func (s *memStore) MarkSent(_ context.Context, _ sqlx.Session, ids []int64) error {
+	for _, id := range ids {
+		m := s.rows[id-1]
+		m.SentAt.Time, m.SentAt.Valid = time.Now(), true
+		m.Attempts++
+		m.LastError.Valid = false
+	}
+	return nil
+}
+
+func (s *memStore) MarkFailed(_ context.Context, _ sqlx.Session, id int64, reason string, nextAttemptAt time.Time) error {
+	m := s.rows[id-1]
+	m.Attempts++
+	m.LastError.String, m.LastError.Valid = reason, true
+	m.NextAttemptAt = nextAttemptAt
+	return nil
+}
+
+func (s *memStore) Stats(_ context.Context, maxAttempts int) (*Stats, error) {
+	stats := &Stats{}
+	for _, m := range s.rows {
+		if m.SentAt.Valid {
+			continue
+		}
+		stats.Pending++
+		if m.Attempts >= maxAttempts {
+			stats.Parked++
+		}
+	}
+	return stats, nil
+}
+
+func (s *memStore) PurgeSent(context.Context, time.Time, int) (int64, error) {
+	return 0, nil
+}
+
+// fakePublisher acknowledges every row shortly after it is published and records which
+// rows of a key were published while an earlier row of the same key was still unacknowledged.
+type fakePublisher struct {
+	mu          sync.Mutex
+	fail        func(msg *Message) error
+	published   []string
+	inFlight    map[string]int
+	maxInFlight int
+	overlapped  []string
+}
+
+func newFakePublisher(fail func(msg *Message) error) *fakePublisher {
+	return &fakePublisher{fail: fail, inFlight: make(map[string]int)}
+}
+
+func (p *fakePublisher) PublishEncoded(_ context.Context, msg *Message) *publisher.Delivery {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published = append(p.published, msg.EventId)
+	if p.inFlight[msg.PartitionKey] > 0 {
+		p.overlapped = append(p.overlapped, msg.EventId)
+	}
+	p.inFlight[msg.PartitionKey]++
+	total := 0
+	for _, n := range p.inFlight {
+		total += n
+	}
+	p.maxInFlight = max(p.maxInFlight, total)
+
+	var err error
+	if p.fail != nil {
+		err = p.fail(msg)
+	}
+	d := publisher.NewDelivery()
+	time.AfterFunc(time.Millisecond, func() {
+		p.mu.Lock()
+		p.inFlight[msg.PartitionKey]--
+		p.mu.Unlock()
+		d.Resolve(err)
+	})
+	return d
+}
+
+func (p *fakePublisher) publishedOf(key string, store *memStore) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []string
+	for _, id := range p.published {
+		if store.row(id).PartitionKey == key {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func newTestRelay(store Store, pub Publisher, conf Conf) *Relay {
+	if conf.BatchSize == 0 {
+		conf.BatchSize = 100
+	}
+	if conf.MaxAttempts == 0 {
+		conf.MaxAttempts = 10
+	}
+	return NewRelay("outbox_test", conf, store, pub)
+}
+
+func TestRelayOnce_KeyOrderInWaves(t *testing.T) {
+	store := newMemStore("a", "a", "b", "a", "b", "c")
+	pub := newFakePublisher(nil)
+
+	sent, err := newTestRelay(store, pub, Conf{}).RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 6, sent)
+
+	assert.Equal(t, []string{"a1", "a2", "a4"}, pub.publishedOf("a", store))
+	assert.Equal(t, []string{"b3", "b5"}, pub.publishedOf("b", store))
+	assert.Empty(t, pub.overlapped, "a row was published before the previous row of its key was acknowledged")
+	// the first wave holds one row of each key
+	assert.Equal(t, 3, pub.maxInFlight)
+	for _, m := range store.rows {
+		assert.True(t, m.SentAt.Valid, m.EventId)
+	}
+}
+
+func TestRelayOnce_FailedKeyBlocksLaterRows(t *testing.T) {
+	store := newMemStore("a", "a", "b", "b")
+	pub := newFakePublisher(func(msg *Message) error {
+		if msg.EventId == "a1" {
+			return errors.New("broker down")
+		}
+		return nil
+	})
+	relay := newTestRelay(store, pub, Conf{BaseBackoffMs: 60000, MaxBackoffMs: 60000})
+
+	sent, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"a1"}, pub.publishedOf("a", store), "a2 must wait for a1")
+	assert.Equal(t, []string{"b3", "b4"}, pub.publishedOf("b", store))
+
+	a1, a2 := store.row("a1"), store.row("a2")
+	assert.False(t, a1.SentAt.Valid)
+	assert.Equal(t, 1, a1.Attempts)
+	assert.Equal(t, "broker down", a1.LastError.String)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), a1.NextAttemptAt, 5*time.Second)
+	assert.False(t, a2.SentAt.Valid)
+	assert.Zero(t, a2.Attempts)
+
+	// a1 is backing off, so a2 is not claimed either
+	sent, err = relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Equal(t, []string{"a1"}, pub.publishedOf("a", store))
+}
+
+func TestRelayOnce_ParksAfterMaxAttempts(t *testing.T) {
+	store := newMemStore("a", "a")
+	pub := newFakePublisher(func(msg *Message) error {
+		if msg.EventId == "a1" {
+			return errors.New("rejected")
+		}
+		return nil
+	})
+	// no backoff so the failed row is due again on the next call
+	relay := newTestRelay(store, pub, Conf{MaxAttempts: 2})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := relay.RelayOnce(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"a1", "a1"}, pub.publishedOf("a", store), "a parked row is not published again")
+	assert.Equal(t, 2, store.row("a1").Attempts)
+	assert.False(t, store.row("a2").SentAt.Valid, "a parked row keeps blocking its key")
+
+	stats, err := store.Stats(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, &Stats{Pending: 2, Parked: 1}, stats)
+}
+
+func TestRelayOnce_RollbackResends(t *testing.T) {
+	store := newMemStore("a", "b")
+	store.failCommit = 1
+	pub := newFakePublisher(nil)
+	relay := newTestRelay(store, pub, Conf{})
+
+	_, err := relay.RelayOnce(context.Background())
+	assert.ErrorIs(t, err, errCommit)
+	for _, m := range store.rows {
+		assert.False(t, m.SentAt.Valid, m.EventId)
+	}
+
+	sent, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	// at-least-once: the same events go out again, consumers dedup by EventID
+	assert.ElementsMatch(t, []string{"a1", "b2", "a1", "b2"}, pub.published)
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := newTestRelay(newMemStore(), newFakePublisher(nil), Conf{BaseBackoffMs: 100, MaxBackoffMs: 1000})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{20, time.Second},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, relay.backoff(tt.attempts), "attempts=%d", tt.attempts)
+	}
+}
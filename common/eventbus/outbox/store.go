@@ -0,0 +1,168 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// Message 是 outbox 表中的一行：已编码的 Envelope 及其 partition key、发送状态
+type Message struct {
+	Id            int64          `db:"id"`
+	EventId       string         `db:"event_id"`
+	EventType     string         `db:"event_type"`
+	PartitionKey  string         `db:"partition_key"`
+	Payload       []byte         `db:"payload"`
+	Headers       []byte         `db:"headers"`
+	Attempts      int            `db:"attempts"`
+	LastError     sql.NullString `db:"last_error"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	CreatedAt     time.Time      `db:"created_at"`
+	SentAt        sql.NullTime   `db:"sent_at"`
+}
+
+// HeaderMap 解码落库的 kafka headers，内容损坏时视为没有 headers
+func (m *Message) HeaderMap() map[string]string {
+	if len(m.Headers) == 0 {
+		return nil
+	}
+	var h map[string]string
+	if err := json.Unmarshal(m.Headers, &h); err != nil || len(h) == 0 {
+		return nil
+	}
+	return h
+}
+
+type Stats struct {
+	Pending int64
+	// Parked 已耗尽 MaxAttempts 的行，人工处理前会阻塞同一 partition key 的后续行
+	Parked int64
+	// OldestPendingSeconds 最早一条未发送行的存在时长，outbox 为空时为 0
+	OldestPendingSeconds float64
+}
+
+// Store 是 Relay 读写 outbox 表的接口
+type Store interface {
+	// TransactRelay 在持有中继 advisory lock 的主库事务中执行 fn，其他副本持有锁时不调用 fn
+	TransactRelay(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) (leader bool, err error)
+	// ClaimDue 按 id 顺序返回到期的行，跳过排在同一 partition key 退避中或已搁置的行之后的行
+	ClaimDue(ctx context.Context, session sqlx.Session, limit, maxAttempts int) ([]*Message, error)
+	MarkSent(ctx context.Context, session sqlx.Session, ids []int64) error
+	MarkFailed(ctx context.Context, session sqlx.Session, id int64, reason string, nextAttemptAt time.Time) error
+	Stats(ctx context.Context, maxAttempts int) (*Stats, error)
+	// PurgeSent 删除最多 limit 条在 before 之前已发送的行
+	PurgeSent(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// PostgresStore 在 PostgreSQL 上实现 Store，各服务使用各自的 outbox 表（结构见 user_outbox 迁移）与 advisory lock key
+type PostgresStore struct {
+	master  sqlx.SqlConn
+	table   string
+	lockKey string
+}
+
+var _ Store = (*PostgresStore)(nil)
+
+// NewPostgresStore 的 table 与 lockKey 来自代码常量，不能来自外部输入
+func NewPostgresStore(master sqlx.SqlConn, table, lockKey string) *PostgresStore {
+	return &PostgresStore{master: master, table: table, lockKey: lockKey}
+}
+
+const fields = "id, event_id, event_type, partition_key, payload, headers, attempts, last_error, next_attempt_at, created_at, sent_at"
+
+// Append 在调用方的事务中把 msgs 写入 table，随业务写操作一起提交
+func Append(ctx context.Context, session sqlx.Session, table string, msgs []*Message) error {
+	query := "INSERT INTO " + table + " (event_id, event_type, partition_key, payload, headers) VALUES ($1, $2, $3, $4, $5)"
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		headers := msg.Headers
+		if len(headers) == 0 {
+			headers = []byte("{}")
+		}
+		if _, err := session.ExecCtx(ctx, query, msg.EventId, msg.EventType, msg.PartitionKey, msg.Payload, headers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) TransactRelay(ctx context.Context, fn func(ctx context.Context, session sqlx.Session) error) (bool, error) {
+	leader := false
+	err := s.master.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		// 事务级锁：提交或回滚时释放，崩溃的中继不会一直持有
+		const lockQuery = "SELECT pg_try_advisory_xact_lock(hashtext($1))"
+		if err := session.QueryRowCtx(ctx, &leader, lockQuery, s.lockKey); err != nil {
+			return err
+		}
+		if !leader {
+			return nil
+		}
+		return fn(ctx, session)
+	})
+	return leader, err
+}
+
+func (s *PostgresStore) ClaimDue(ctx context.Context, session sqlx.Session, limit, maxAttempts int) ([]*Message, error) {
+	query := `SELECT ` + fields + ` FROM ` + s.table + ` o
+WHERE o.sent_at IS NULL AND o.attempts < $1 AND o.next_attempt_at <= now()
+  AND NOT EXISTS (
+    SELECT 1 FROM ` + s.table + ` p
+    WHERE p.partition_key = o.partition_key AND p.sent_at IS NULL AND p.id < o.id
+      AND (p.next_attempt_at > now() OR p.attempts >= $1)
+  )
+ORDER BY o.id
+LIMIT $2
+FOR UPDATE`
+	var msgs []*Message
+	if err := session.QueryRowsCtx(ctx, &msgs, query, maxAttempts, limit); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+func (s *PostgresStore) MarkSent(ctx context.Context, session sqlx.Session, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := "UPDATE " + s.table + " SET sent_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = ANY($1)"
+	_, err := session.ExecCtx(ctx, query, pq.Array(ids))
+	return err
+}
+
+func (s *PostgresStore) MarkFailed(ctx context.Context, session sqlx.Session, id int64, reason string, nextAttemptAt time.Time) error {
+	query := "UPDATE " + s.table + " SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3 WHERE id = $1"
+	_, err := session.ExecCtx(ctx, query, id, reason, nextAttemptAt)
+	return err
+}
+
+func (s *PostgresStore) Stats(ctx context.Context, maxAttempts int) (*Stats, error) {
+	query := `SELECT
+  count(*) AS pending,
+  count(*) FILTER (WHERE attempts >= $1) AS parked,
+  coalesce(extract(epoch FROM now() - min(created_at)), 0)::float8 AS oldest
+FROM ` + s.table + ` WHERE sent_at IS NULL`
+	var row struct {
+		Pending int64   `db:"pending"`
+		Parked  int64   `db:"parked"`
+		Oldest  float64 `db:"oldest"`
+	}
+	if err := s.master.QueryRowCtx(ctx, &row, query, maxAttempts); err != nil {
+		return nil, err
+	}
+	return &Stats{Pending: row.Pending, Parked: row.Parked, OldestPendingSeconds: row.Oldest}, nil
+}
+
+func (s *PostgresStore) PurgeSent(ctx context.Context, before time.Time, limit int) (int64, error) {
+	query := "DELETE FROM " + s.table + " WHERE id IN (SELECT id FROM " + s.table + " WHERE sent_at IS NOT NULL AND sent_at < $1 ORDER BY sent_at LIMIT $2)"
+	res, err := s.master.ExecCtx(ctx, query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -6,35 +6,167 @@ import (
 
 	"github.com/uwu-octane/antBackend/common/eventbus/codec"
 	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
 )
 
-// EventBusPublisher 面向业务的语义化发布器（强类型泛型）
+// EventBusPublisher 面向业务的语义化发布器（强类型泛型）。
+// 事件按 EventType 经 Routes 找到所在 stream，再由 Topics 得到 topic，任何服务都可以用同一个发布器发送任意已登记的事件。
 type EventBusPublisher struct {
 	Pub    Publisher
 	Topics event.TopicSet
+	// Routes EventType 到 stream 的路由表，nil 时为 event.DefaultRoutes
+	Routes *event.Routes
+	// Codec 事件编码，nil 时为 codec.JSON；content-type header 随消息发送
+	Codec codec.Codec
 }
 
-func NewEventBusPublisher(pub Publisher, topics event.TopicSet) *EventBusPublisher {
-	return &EventBusPublisher{Pub: pub, Topics: topics}
+type Option func(*EventBusPublisher)
+
+// WithCodec 指定事件编码（codec.JSON / codec.Protobuf / codec.Avro）
+func WithCodec(c codec.Codec) Option {
+	return func(p *EventBusPublisher) {
+		p.Codec = c
+	}
+}
+
+// WithRoutes 指定路由表，默认 event.DefaultRoutes
+func WithRoutes(r *event.Routes) Option {
+	return func(p *EventBusPublisher) {
+		p.Routes = r
+	}
+}
+
+func NewEventBusPublisher(pub Publisher, topics event.TopicSet, opts ...Option) *EventBusPublisher {
+	p := &EventBusPublisher{Pub: pub, Topics: topics}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *EventBusPublisher) codec() codec.Codec {
+	if p.Codec == nil {
+		return codec.JSON
+	}
+	return p.Codec
+}
+
+func (p *EventBusPublisher) routes() *event.Routes {
+	if p.Routes == nil {
+		return event.DefaultRoutes
+	}
+	return p.Routes
 }
 
-// SendUserEvent 发送用户流上的任意 Envelope
+// TopicFor 返回 eventType 路由到的 topic；未登记路由时返回 event.ErrNoRoute
+func (p *EventBusPublisher) TopicFor(eventType string) (string, error) {
+	stream, err := p.routes().StreamOf(eventType)
+	if err != nil {
+		return "", err
+	}
+	return p.Topics.Topic(stream), nil
+}
+
+// Send 按 EventType 路由发送 Envelope
 func Send[T any](ctx context.Context, p *EventBusPublisher, envelope *event.Envelope[T], key []byte, headers map[string]string) error {
+	if p == nil {
+		return errors.New("publisher or pub is nil")
+	}
+	if envelope == nil {
+		return errors.New("envelope is nil")
+	}
+	topic, err := p.TopicFor(envelope.EventType)
+	if err != nil {
+		return err
+	}
+	return SendTo(ctx, p, topic, envelope, key, headers)
+}
+
+// SendTo 发送 Envelope 到指定 topic，不经过路由（例如重试、死信 topic）
+func SendTo[T any](ctx context.Context, p *EventBusPublisher, topic string, envelope *event.Envelope[T], key []byte, headers map[string]string) error {
 	if p == nil || p.Pub == nil {
 		return errors.New("publisher or pub is nil")
 	}
 	if envelope == nil {
 		return errors.New("envelope is nil")
 	}
-	if p.Topics.UserEvents == "" {
+	if topic == "" {
 		return errors.New("topic not set")
 	}
-	body, err := codec.MarshalEnvelope(envelope)
+	c := p.codec()
+	body, err := codec.Marshal(c, envelope)
 	if err != nil {
 		return err
 	}
-	return p.Pub.Publish(ctx, p.Topics.UserEvents, body, &PublishOptions{
+	return publish(ctx, p, c, topic, body, key, headers)
+}
+
+// SendEncoded 发送 JSON 编码的 Envelope（例如 outbox 中落库的事件），按 p.Codec 转码后发送；
+// topic 为空时按 EventType 路由
+func SendEncoded(ctx context.Context, p *EventBusPublisher, topic string, body []byte, key []byte, headers map[string]string) error {
+	return SendEncodedAsync(ctx, p, topic, body, key, headers).Wait(ctx)
+}
+
+// SendEncodedAsync 与 SendEncoded 相同，但不等待 broker 确认；Pub 不是 AsyncPublisher 时同步发送后返回已完成的 Delivery
+func SendEncodedAsync(ctx context.Context, p *EventBusPublisher, topic string, body []byte, key []byte, headers map[string]string) *Delivery {
+	if p == nil || p.Pub == nil {
+		return Delivered(errors.New("publisher or pub is nil"))
+	}
+	head, err := codec.PeekHead(body)
+	if err != nil {
+		return Delivered(err)
+	}
+	if topic == "" {
+		if topic, err = p.TopicFor(head.EventType); err != nil {
+			return Delivered(err)
+		}
+	}
+	if err := schema.Default.Validate(head.EventType, head.EventVersion); err != nil {
+		return Delivered(err)
+	}
+	c := p.codec()
+	if body, err = codec.Transcode(c, body); err != nil {
+		return Delivered(err)
+	}
+	return publishAsync(ctx, p, c, topic, body, key, headers)
+}
+
+// Flush 等待异步发布的消息全部得到确认，Pub 不是 AsyncPublisher 时直接返回
+func (p *EventBusPublisher) Flush(ctx context.Context) error {
+	if ap, ok := p.Pub.(AsyncPublisher); ok {
+		return ap.Flush(ctx)
+	}
+	return nil
+}
+
+// Close 先 Flush 再关闭底层 Publisher，用于服务退出
+func (p *EventBusPublisher) Close(ctx context.Context) error {
+	if p == nil || p.Pub == nil {
+		return nil
+	}
+	err := p.Flush(ctx)
+	return errors.Join(err, p.Pub.Close())
+}
+
+func publish(ctx context.Context, p *EventBusPublisher, c codec.Codec, topic string, body []byte, key []byte, headers map[string]string) error {
+	return publishAsync(ctx, p, c, topic, body, key, headers).Wait(ctx)
+}
+
+// publishAsync 附加 content-type 以及 ctx 中的 traceparent / tracestate / x-request-id（headers 中已有的不覆盖）
+func publishAsync(ctx context.Context, p *EventBusPublisher, c codec.Codec, topic string, body []byte, key []byte, headers map[string]string) *Delivery {
+	h := make(map[string]string, len(headers)+4)
+	for k, v := range headers {
+		h[k] = v
+	}
+	h[codec.HeaderContentType] = c.ContentType()
+	tracing.Inject(ctx, h)
+	opts := &PublishOptions{
 		PartitionKey: key,
-		Headers:      headers,
-	})
+		Headers:      h,
+	}
+	if ap, ok := p.Pub.(AsyncPublisher); ok {
+		return ap.PublishAsync(ctx, topic, body, opts)
+	}
+	return Delivered(p.Pub.Publish(ctx, topic, body, opts))
 }
@@ -0,0 +1,78 @@
+package publisher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/memory"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newEnvelope[T any](eventType string, data T) *event.Envelope[T] {
+	return &event.Envelope[T]{
+		EventType:    eventType,
+		EventVersion: 1,
+		EventID:      "evt-1",
+		OccurredAt:   time.Now(),
+		Producer:     "test",
+		Data:         data,
+	}
+}
+
+func TestSend_RoutesByEventType(t *testing.T) {
+	bus := memory.NewBus()
+	p := publisher.NewEventBusPublisher(bus, event.BuildTopics(event.EnvDev))
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Send(ctx, p, newEnvelope(event.EventTypeOrderCreated, event.OrderCreatedEvent{OrderID: "o-1"}), []byte("u-1"), nil))
+	require.NoError(t, publisher.Send(ctx, p, newEnvelope(event.EventTypeAuthLoginFailed, event.AuthLoginFailedEvent{}), []byte("u-1"), nil))
+
+	assert.Len(t, bus.Messages("dev.order.service.order-events"), 1)
+	assert.Len(t, bus.Messages("dev.auth.service.auth-events"), 1)
+}
+
+func TestSend_NoRoute(t *testing.T) {
+	bus := memory.NewBus()
+	p := publisher.NewEventBusPublisher(bus, event.BuildTopics(event.EnvDev), publisher.WithRoutes(event.NewRoutes()))
+
+	err := publisher.Send(context.Background(), p, newEnvelope(event.EventTypeOrderCreated, event.OrderCreatedEvent{}), nil, nil)
+	assert.ErrorIs(t, err, event.ErrNoRoute)
+}
+
+func TestSendEncoded_RoutesWhenTopicEmpty(t *testing.T) {
+	bus := memory.NewBus()
+	p := publisher.NewEventBusPublisher(bus, event.BuildTopics(event.EnvProd))
+
+	body, err := codec.MarshalEnvelope(newEnvelope(event.EventTypeOrderCreated, event.OrderCreatedEvent{OrderID: "o-1"}))
+	require.NoError(t, err)
+	require.NoError(t, publisher.SendEncoded(context.Background(), p, "", body, []byte("u-1"), nil))
+
+	msgs := bus.Messages("prod.order.service.order-events")
+	require.Len(t, msgs, 1)
+	assert.Equal(t, codec.ContentTypeJSON, msgs[0].Headers[codec.HeaderContentType])
+}
+
+func TestSend_InjectsTraceHeaders(t *testing.T) {
+	bus := memory.NewBus()
+	p := publisher.NewEventBusPublisher(bus, event.BuildTopics(event.EnvDev))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	ctx = requestid.NewContext(ctx, "req-1")
+
+	require.NoError(t, publisher.Send(ctx, p, newEnvelope(event.EventTypeOrderCreated, event.OrderCreatedEvent{OrderID: "o-1"}), nil, nil))
+
+	msgs := bus.Messages("dev.order.service.order-events")
+	require.Len(t, msgs, 1)
+	assert.Contains(t, msgs[0].Headers[tracing.HeaderTraceparent], span.SpanContext().TraceID().String())
+	assert.Equal(t, "req-1", msgs[0].Headers[tracing.HeaderRequestID])
+}
@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// TopicSpec 自动创建 topic 时使用的参数；已存在的 topic 不会被修改
+type TopicSpec struct {
+	Partitions        int32
+	ReplicationFactor int16
+	// RetentionMs 为 0 时使用 broker 默认的 retention.ms
+	RetentionMs int64
+}
+
+// EnsureTopics 创建 topics 中尚不存在的 topic，返回实际创建的 topic。
+// 用于开发环境自动准备各 stream 及其重试/死信 topic，生产环境的 topic 应由运维预先创建。
+func EnsureTopics(opts *ProducerOptions, topics []string, spec TopicSpec) ([]string, error) {
+	if opts == nil || len(opts.Brokers) == 0 {
+		return nil, errors.New("kafka admin: brokers not set")
+	}
+	cfg, err := BuildSaramaConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	admin, err := sarama.NewClusterAdmin(opts.Brokers, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer admin.Close()
+
+	existing, err := admin.ListTopics()
+	if err != nil {
+		return nil, err
+	}
+	detail := &sarama.TopicDetail{
+		NumPartitions:     spec.Partitions,
+		ReplicationFactor: spec.ReplicationFactor,
+	}
+	if detail.NumPartitions <= 0 {
+		detail.NumPartitions = 1
+	}
+	if detail.ReplicationFactor <= 0 {
+		detail.ReplicationFactor = 1
+	}
+	if spec.RetentionMs > 0 {
+		retention := strconv.FormatInt(spec.RetentionMs, 10)
+		detail.ConfigEntries = map[string]*string{"retention.ms": &retention}
+	}
+
+	var created []string
+	for _, topic := range topics {
+		if _, ok := existing[topic]; ok {
+			continue
+		}
+		err := admin.CreateTopic(topic, detail, false)
+		// 其他实例可能同时创建
+		if errors.Is(err, sarama.ErrTopicAlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return created, err
+		}
+		existing[topic] = *detail
+		created = append(created, topic)
+		logx.Infow("create kafka topic", logx.Field("topic", topic),
+			logx.Field("partitions", detail.NumPartitions), logx.Field("replication", detail.ReplicationFactor))
+	}
+	return created, nil
+}
@@ -0,0 +1,221 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/metric"
+)
+
+var (
+	metricInflight = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "eventbus",
+		Subsystem: "kafka_producer",
+		Name:      "inflight",
+		Help:      "messages handed to the async kafka producer and not yet acknowledged.",
+		Labels:    []string{"client"},
+	})
+	metricQueueDepth = metric.NewGaugeVec(&metric.GaugeVecOpts{
+		Namespace: "eventbus",
+		Subsystem: "kafka_producer",
+		Name:      "queue_depth",
+		Help:      "messages waiting in the async kafka producer input queue.",
+		Labels:    []string{"client"},
+	})
+	metricDelivered = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: "eventbus",
+		Subsystem: "kafka_producer",
+		Name:      "delivered_total",
+		Help:      "async kafka producer deliveries by result.",
+		Labels:    []string{"client", "result"},
+	})
+)
+
+// DeliveryFunc 在每条消息得到确认或最终失败时回调，运行在 producer 的结果 goroutine 中，不应阻塞
+type DeliveryFunc func(topic string, key []byte, err error)
+
+// AsyncSaramaPublisher 基于 sarama.AsyncProducer：PublishAsync 只把消息放入发送队列，
+// 由 sarama 按 FlushBytes / FlushMessages / FlushFrequency 批量发送，结果通过 Delivery 与 DeliveryFunc 返回。
+// 同一分区内的顺序由 sarama 保证（Idempotent 时 MaxOpenRequests=1）。
+type AsyncSaramaPublisher struct {
+	producer   sarama.AsyncProducer
+	client     string
+	onDelivery DeliveryFunc
+
+	// mu 保护 closed 与向 Input 发送，Close 之后不再写入
+	mu     sync.RWMutex
+	closed bool
+
+	// inflight 已提交未确认的消息数，归零时唤醒 Flush
+	inflightMu sync.Mutex
+	inflight   int
+	idle       []chan struct{}
+
+	done chan struct{}
+}
+
+var _ publisher.AsyncPublisher = (*AsyncSaramaPublisher)(nil)
+
+// NewAsyncSaramaPublisher 创建异步发布器，onDelivery 可为 nil
+func NewAsyncSaramaPublisher(opts *ProducerOptions, onDelivery DeliveryFunc) (*AsyncSaramaPublisher, error) {
+	cfg, err := BuildSaramaConfig(opts)
+	if err != nil {
+		logx.Errorw("build sarama config failed", logx.Field("error", err))
+		return nil, err
+	}
+	cfg.Producer.Return.Errors = true
+	p, err := sarama.NewAsyncProducer(opts.Brokers, cfg)
+	if err != nil {
+		logx.Errorw("create sarama async producer failed", logx.Field("error", err))
+		return nil, err
+	}
+	logx.Infow("create sarama async producer", logx.Field("brokers", opts.Brokers), logx.Field("client", cfg.ClientID))
+	return newAsyncSaramaPublisher(p, cfg.ClientID, onDelivery), nil
+}
+
+func newAsyncSaramaPublisher(producer sarama.AsyncProducer, client string, onDelivery DeliveryFunc) *AsyncSaramaPublisher {
+	s := &AsyncSaramaPublisher{
+		producer:   producer,
+		client:     client,
+		onDelivery: onDelivery,
+		done:       make(chan struct{}),
+	}
+	go s.dispatch()
+	return s
+}
+
+// Publish 提交消息并等待确认；并发调用方的消息会被合并到同一批次
+func (s *AsyncSaramaPublisher) Publish(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) error {
+	return s.PublishAsync(ctx, topic, data, opts).Wait(ctx)
+}
+
+// PublishAsync 把消息放入发送队列后立即返回；队列满时阻塞直到有空位或 ctx 结束
+func (s *AsyncSaramaPublisher) PublishAsync(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) *publisher.Delivery {
+	d := publisher.NewDelivery()
+	msg := &sarama.ProducerMessage{
+		Topic:    topic,
+		Value:    sarama.ByteEncoder(data),
+		Metadata: d,
+	}
+	if opts != nil {
+		if len(opts.PartitionKey) > 0 {
+			msg.Key = sarama.ByteEncoder(opts.PartitionKey)
+		}
+		for k, v := range opts.Headers {
+			msg.Headers = append(msg.Headers, sarama.RecordHeader{
+				Key:   []byte(k),
+				Value: []byte(v),
+			})
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		d.Resolve(ErrClosed)
+		return d
+	}
+	s.addInflight(1)
+	select {
+	case s.producer.Input() <- msg:
+		metricQueueDepth.Set(float64(len(s.producer.Input())), s.client)
+	case <-ctx.Done():
+		s.addInflight(-1)
+		d.Resolve(ctx.Err())
+	}
+	return d
+}
+
+// Flush 等待已提交的消息全部得到确认（成功或失败）
+func (s *AsyncSaramaPublisher) Flush(ctx context.Context) error {
+	s.inflightMu.Lock()
+	if s.inflight == 0 {
+		s.inflightMu.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	s.idle = append(s.idle, ch)
+	s.inflightMu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close 停止接收新消息，发送队列中的消息发送完毕并回调后返回
+func (s *AsyncSaramaPublisher) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	s.producer.AsyncClose()
+	<-s.done
+	return nil
+}
+
+// dispatch 读取 Successes / Errors 直到 producer 关闭
+func (s *AsyncSaramaPublisher) dispatch() {
+	defer close(s.done)
+	successes, errs := s.producer.Successes(), s.producer.Errors()
+	for successes != nil || errs != nil {
+		select {
+		case msg, ok := <-successes:
+			if !ok {
+				successes = nil
+				continue
+			}
+			s.deliver(msg, nil)
+		case pe, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			s.deliver(pe.Msg, pe.Err)
+		}
+	}
+}
+
+func (s *AsyncSaramaPublisher) deliver(msg *sarama.ProducerMessage, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+		logx.Errorw("kafka async publish failed", logx.Field("topic", msg.Topic), logx.Field("error", err))
+	}
+	metricDelivered.Inc(s.client, result)
+	metricQueueDepth.Set(float64(len(s.producer.Input())), s.client)
+
+	var key []byte
+	if msg.Key != nil {
+		key, _ = msg.Key.Encode()
+	}
+	if s.onDelivery != nil {
+		s.onDelivery(msg.Topic, key, err)
+	}
+	if d, ok := msg.Metadata.(*publisher.Delivery); ok {
+		d.Resolve(err)
+	}
+	s.addInflight(-1)
+}
+
+func (s *AsyncSaramaPublisher) addInflight(n int) {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+	s.inflight += n
+	metricInflight.Set(float64(s.inflight), s.client)
+	if s.inflight == 0 {
+		for _, ch := range s.idle {
+			close(ch)
+		}
+		s.idle = nil
+	}
+}
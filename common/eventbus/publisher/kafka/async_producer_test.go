@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+)
+
+func newMockAsync(t *testing.T, onDelivery DeliveryFunc) (*AsyncSaramaPublisher, *mocks.AsyncProducer) {
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	mock := mocks.NewAsyncProducer(t, cfg)
+	return newAsyncSaramaPublisher(mock, "test", onDelivery), mock
+}
+
+func TestAsyncSaramaPublisher_Deliveries(t *testing.T) {
+	var mu sync.Mutex
+	var results []error
+	pub, mock := newMockAsync(t, func(topic string, key []byte, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "t1", topic)
+		assert.Equal(t, []byte("k"), key)
+		results = append(results, err)
+	})
+	boom := errors.New("boom")
+	mock.ExpectInputAndSucceed()
+	mock.ExpectInputAndFail(boom)
+
+	ctx := context.Background()
+	opts := &publisher.PublishOptions{PartitionKey: []byte("k"), Headers: map[string]string{"traceparent": "tp"}}
+	ok := pub.PublishAsync(ctx, "t1", []byte("a"), opts)
+	failed := pub.PublishAsync(ctx, "t1", []byte("b"), opts)
+
+	require.NoError(t, ok.Wait(ctx))
+	assert.ErrorIs(t, failed.Wait(ctx), boom)
+	require.NoError(t, pub.Flush(ctx))
+	require.NoError(t, pub.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []error{nil, boom}, results)
+}
+
+func TestAsyncSaramaPublisher_FlushWaitsForInflight(t *testing.T) {
+	pub, mock := newMockAsync(t, nil)
+	for i := 0; i < 3; i++ {
+		mock.ExpectInputAndSucceed()
+	}
+	ctx := context.Background()
+	deliveries := make([]*publisher.Delivery, 3)
+	for i := range deliveries {
+		deliveries[i] = pub.PublishAsync(ctx, "t1", []byte("x"), nil)
+	}
+
+	flushCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, pub.Flush(flushCtx))
+	for _, d := range deliveries {
+		select {
+		case <-d.Done():
+			assert.NoError(t, d.Err())
+		default:
+			t.Fatal("Flush returned before every delivery resolved")
+		}
+	}
+	require.NoError(t, pub.Close())
+}
+
+func TestAsyncSaramaPublisher_PublishAfterClose(t *testing.T) {
+	pub, _ := newMockAsync(t, nil)
+	require.NoError(t, pub.Close())
+	require.NoError(t, pub.Close())
+	assert.ErrorIs(t, pub.Publish(context.Background(), "t1", []byte("x"), nil), ErrClosed)
+	assert.NoError(t, pub.Flush(context.Background()))
+}
@@ -76,6 +76,10 @@ func BuildSaramaConfig(c *ProducerOptions) (*sarama.Config, error) {
 		cfg.Net.TLS.Enable = true
 	}
 
+	if c.ClientID != "" {
+		cfg.ClientID = c.ClientID
+	}
+
 	if strings.TrimSpace(c.KafkaVersion) != "" {
 		version, err := sarama.ParseKafkaVersion(c.KafkaVersion)
 		if err != nil {
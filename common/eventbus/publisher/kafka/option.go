@@ -19,4 +19,5 @@ type ProducerOptions struct {
 	SASLPassword    string
 	EnableTLS       bool
 	KafkaVersion    string // "3.6.0" 等，可留空用默认
+	ClientID        string // 写入 sarama ClientID，同时作为 producer 指标的 client 标签
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
@@ -12,8 +13,11 @@ import (
 	"github.com/Shopify/sarama"
 )
 
+var ErrClosed = errors.New("kafka publisher: closed")
+
 type SaramaPublisher struct {
 	producer sarama.SyncProducer
+	closed   atomic.Bool
 }
 
 func NewSaramaPublisher(opts *ProducerOptions) (*SaramaPublisher, error) {
@@ -32,12 +36,18 @@ func NewSaramaPublisher(opts *ProducerOptions) (*SaramaPublisher, error) {
 }
 
 func (s *SaramaPublisher) Publish(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) error {
+	// 关闭后的 SyncProducer 发送会 panic
+	if s.closed.Load() {
+		return ErrClosed
+	}
 	msg := &sarama.ProducerMessage{
 		Topic: topic,
 		Value: sarama.ByteEncoder(data),
 	}
-	if opts != nil && len(opts.PartitionKey) > 0 {
-		msg.Key = sarama.ByteEncoder(opts.PartitionKey)
+	if opts != nil {
+		if len(opts.PartitionKey) > 0 {
+			msg.Key = sarama.ByteEncoder(opts.PartitionKey)
+		}
 		for k, v := range opts.Headers {
 			msg.Headers = append(msg.Headers, sarama.RecordHeader{
 				Key:   []byte(k),
@@ -79,5 +89,8 @@ func (s *SaramaPublisher) Publish(ctx context.Context, topic string, data []byte
 }
 
 func (s *SaramaPublisher) Close() error {
+	if s.closed.Swap(true) {
+		return nil
+	}
 	return s.producer.Close()
 }
@@ -0,0 +1,66 @@
+package kafka_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/kafka"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/publishertest"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	ksub "github.com/uwu-octane/antBackend/common/eventbus/subscriber/kafka"
+)
+
+// 需要真实 broker（topic 自动创建），例如 KAFKA_BROKERS=localhost:9092 go test ./eventbus/publisher/kafka/
+func TestSaramaPublisher_Conformance(t *testing.T) {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		t.Skip("KAFKA_BROKERS not set")
+	}
+	publishertest.Run(t, func(t *testing.T) *publishertest.Backend {
+		pub, err := kafka.NewSaramaPublisher(&kafka.ProducerOptions{
+			Brokers: strings.Split(brokers, ","),
+			Acks:    "all",
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = pub.Close() })
+		return kafkaBackend(brokers, pub)
+	})
+}
+
+func TestAsyncSaramaPublisher_Conformance(t *testing.T) {
+	brokers := os.Getenv("KAFKA_BROKERS")
+	if brokers == "" {
+		t.Skip("KAFKA_BROKERS not set")
+	}
+	publishertest.Run(t, func(t *testing.T) *publishertest.Backend {
+		pub, err := kafka.NewAsyncSaramaPublisher(&kafka.ProducerOptions{
+			Brokers:        strings.Split(brokers, ","),
+			Acks:           "all",
+			FlushFrequency: 10 * time.Millisecond,
+		}, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = pub.Close() })
+		return kafkaBackend(brokers, pub)
+	})
+}
+
+func kafkaBackend(brokers string, pub publisher.Publisher) *publishertest.Backend {
+	return &publishertest.Backend{
+		Pub: pub,
+		Subscribe: func(t *testing.T, group string, topics []string, handler subscriber.Handler) subscriber.Subscriber {
+			sub, err := ksub.NewSaramaSubscriber(&ksub.ConsumerOptions{
+				Brokers:     strings.Split(brokers, ","),
+				Group:       group + "." + topics[0],
+				Topics:      topics,
+				Offset:      "first",
+				Concurrency: 4,
+			}, handler, nil)
+			require.NoError(t, err)
+			return sub
+		},
+	}
+}
@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+)
+
+var ErrClosed = errors.New("memory bus: closed")
+
+// Bus 进程内事件总线，用于单元/集成测试与没有 broker 的本地开发。
+// 语义与 kafka 消费组一致：每个消费组收到订阅 topic 上的全部消息，同一 key 的消息按发布顺序处理；
+// 消息不落盘，进程退出即丢失。
+type Bus struct {
+	mu     sync.Mutex
+	closed bool
+	// offsets 每个 topic 的下一个 offset
+	offsets map[string]int64
+	// log 保留已发布的消息，供测试断言
+	log  map[string][]subscriber.Message
+	subs map[string][]*Subscriber
+}
+
+var _ publisher.Publisher = (*Bus)(nil)
+
+func NewBus() *Bus {
+	return &Bus{
+		offsets: make(map[string]int64),
+		log:     make(map[string][]subscriber.Message),
+		subs:    make(map[string][]*Subscriber),
+	}
+}
+
+// Publish 记录消息并投递给订阅了 topic 的每个消费组，不会阻塞在消费者上
+func (b *Bus) Publish(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if topic == "" {
+		return errors.New("memory bus: topic not set")
+	}
+	msg := subscriber.Message{
+		Topic:     topic,
+		Value:     slices.Clone(data),
+		Timestamp: time.Now(),
+	}
+	if opts != nil {
+		msg.Key = slices.Clone(opts.PartitionKey)
+		msg.Headers = maps.Clone(opts.Headers)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrClosed
+	}
+	msg.Offset = b.offsets[topic]
+	b.offsets[topic]++
+	b.log[topic] = append(b.log[topic], msg)
+
+	delivered := make(map[string]bool)
+	for _, s := range b.subs[topic] {
+		// 同一消费组的多个订阅者只有一个收到消息
+		if delivered[s.group] {
+			continue
+		}
+		delivered[s.group] = true
+		s.enqueue(msg)
+	}
+	return nil
+}
+
+// Messages 返回 topic 上已发布消息的副本（按发布顺序）
+func (b *Bus) Messages(topic string) []subscriber.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.log[topic])
+}
+
+// Close 之后 Publish 返回 ErrClosed，已入队的消息仍会被订阅者处理
+func (b *Bus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+// NewSubscriber 以 group 订阅 topics：只接收创建之后发布的消息，Start 之前发布的消息会排队等待。
+// concurrency 与 kafka 订阅者的分区内并发一致，相同 key 的消息串行处理。
+func (b *Bus) NewSubscriber(group string, topics []string, concurrency int, handler subscriber.Handler, onError subscriber.ErrorHandler) *Subscriber {
+	s := &Subscriber{
+		bus:         b,
+		group:       group,
+		topics:      topics,
+		concurrency: concurrency,
+		handler:     handler,
+		onError:     onError,
+		notify:      make(chan struct{}, 1),
+		stopping:    make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, t := range topics {
+		b.subs[t] = append(b.subs[t], s)
+	}
+	return s
+}
+
+func (b *Bus) unsubscribe(s *Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, t := range s.topics {
+		b.subs[t] = slices.DeleteFunc(b.subs[t], func(x *Subscriber) bool { return x == s })
+	}
+}
+
+// Subscriber 是 Bus 上的一个消费组成员，实现 subscriber.Subscriber
+type Subscriber struct {
+	bus         *Bus
+	group       string
+	topics      []string
+	concurrency int
+	handler     subscriber.Handler
+	onError     subscriber.ErrorHandler
+
+	mu     sync.Mutex
+	queue  []subscriber.Message
+	notify chan struct{}
+
+	started  atomic.Bool
+	stopOnce sync.Once
+	stopping chan struct{}
+	done     chan struct{}
+}
+
+var _ subscriber.Subscriber = (*Subscriber)(nil)
+
+func (s *Subscriber) enqueue(msg subscriber.Message) {
+	s.mu.Lock()
+	s.queue = append(s.queue, msg)
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start 阻塞处理消息直到 Stop；每个 topic 一个 PartitionWorker，与 kafka 的分区对应
+func (s *Subscriber) Start() {
+	s.started.Store(true)
+	defer close(s.done)
+	ctx := subscriber.WithStopping(context.Background(), s.stopping)
+	workers := make(map[string]*subscriber.PartitionWorker, len(s.topics))
+	defer func() {
+		for _, w := range workers {
+			w.Close()
+		}
+	}()
+
+	for {
+		s.mu.Lock()
+		batch := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for i := range batch {
+			w, ok := workers[batch[i].Topic]
+			if !ok {
+				w = subscriber.NewPartitionWorker(ctx, s.concurrency, s.handler, s.onError, func(int64) {})
+				workers[batch[i].Topic] = w
+			}
+			w.Submit(&batch[i])
+		}
+		select {
+		case <-s.notify:
+		case <-s.stopping:
+			return
+		}
+	}
+}
+
+// Stop 取消订阅并等待在途消息处理完成；尚未开始处理的消息被丢弃
+func (s *Subscriber) Stop() {
+	s.stopOnce.Do(func() {
+		s.bus.unsubscribe(s)
+		close(s.stopping)
+	})
+	if s.started.Load() {
+		<-s.done
+	}
+}
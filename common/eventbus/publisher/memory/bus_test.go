@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/publishertest"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+)
+
+func TestBus_Conformance(t *testing.T) {
+	publishertest.Run(t, func(t *testing.T) *publishertest.Backend {
+		bus := NewBus()
+		return &publishertest.Backend{
+			Pub: bus,
+			Subscribe: func(t *testing.T, group string, topics []string, handler subscriber.Handler) subscriber.Subscriber {
+				return bus.NewSubscriber(group, topics, 4, handler, nil)
+			},
+		}
+	})
+}
+
+func TestBus_MessagesKeepsLog(t *testing.T) {
+	bus := NewBus()
+	headers := map[string]string{"h": "1"}
+	require.NoError(t, bus.Publish(context.Background(), "t", []byte("a"), &publisher.PublishOptions{PartitionKey: []byte("k"), Headers: headers}))
+	require.NoError(t, bus.Publish(context.Background(), "t", []byte("b"), nil))
+	headers["h"] = "changed"
+
+	msgs := bus.Messages("t")
+	require.Len(t, msgs, 2)
+	assert.Equal(t, int64(0), msgs[0].Offset)
+	assert.Equal(t, int64(1), msgs[1].Offset)
+	assert.Equal(t, "1", msgs[0].Headers["h"])
+	assert.Empty(t, bus.Messages("other"))
+}
+
+func TestBus_StopUnsubscribes(t *testing.T) {
+	bus := NewBus()
+	sub := bus.NewSubscriber("g", []string{"t"}, 1, func(context.Context, *subscriber.Message) error { return nil }, nil)
+	sub.Stop()
+	require.NoError(t, bus.Publish(context.Background(), "t", []byte("a"), nil))
+	assert.Empty(t, sub.queue)
+}
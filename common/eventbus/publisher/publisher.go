@@ -15,3 +15,54 @@ type Publisher interface {
 	Publish(ctx context.Context, topic string, data []byte, opts *PublishOptions) error
 	Close() error
 }
+
+// AsyncPublisher 不等待 broker 确认即返回，确认结果通过 Delivery 获取；批量由后端按 flush 配置合并发送
+type AsyncPublisher interface {
+	Publisher
+	PublishAsync(ctx context.Context, topic string, data []byte, opts *PublishOptions) *Delivery
+	// Flush 等待已提交的消息全部得到确认
+	Flush(ctx context.Context) error
+}
+
+// Delivery 是一次异步发布的结果（future）
+type Delivery struct {
+	done chan struct{}
+	err  error
+}
+
+func NewDelivery() *Delivery {
+	return &Delivery{done: make(chan struct{})}
+}
+
+// Delivered 返回已完成的 Delivery，供同步后端使用
+func Delivered(err error) *Delivery {
+	d := NewDelivery()
+	d.Resolve(err)
+	return d
+}
+
+// Resolve 记录结果并唤醒等待者，只能调用一次
+func (d *Delivery) Resolve(err error) {
+	d.err = err
+	close(d.done)
+}
+
+// Done 在结果可用时关闭
+func (d *Delivery) Done() <-chan struct{} {
+	return d.done
+}
+
+// Err 返回发布结果，需在 Done 关闭后调用
+func (d *Delivery) Err() error {
+	return d.err
+}
+
+// Wait 等待结果；ctx 结束时返回 ctx.Err()，消息仍可能在之后送达
+func (d *Delivery) Wait(ctx context.Context) error {
+	select {
+	case <-d.done:
+		return d.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,186 @@
+// Package publishertest 提供 publisher.Publisher 各后端共用的一致性测试，
+// 新增后端时在其测试中调用 Run。
+package publishertest
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+)
+
+const waitTimeout = 10 * time.Second
+
+// Backend 是被测后端：Pub 发布消息，Subscribe 以消费组订阅 topics。
+// Subscribe 返回之后发布的消息必须能被该组收到。
+type Backend struct {
+	Pub       publisher.Publisher
+	Subscribe func(t *testing.T, group string, topics []string, handler subscriber.Handler) subscriber.Subscriber
+}
+
+// Run 对 newBackend 创建的后端执行全部一致性用例，每个用例使用独立的后端与 topic
+func Run(t *testing.T, newBackend func(t *testing.T) *Backend) {
+	t.Run("RoundTrip", func(t *testing.T) { testRoundTrip(t, newBackend(t)) })
+	t.Run("HeadersWithoutKey", func(t *testing.T) { testHeadersWithoutKey(t, newBackend(t)) })
+	t.Run("KeyOrdering", func(t *testing.T) { testKeyOrdering(t, newBackend(t)) })
+	t.Run("ConsumerGroups", func(t *testing.T) { testConsumerGroups(t, newBackend(t)) })
+	t.Run("TopicIsolation", func(t *testing.T) { testTopicIsolation(t, newBackend(t)) })
+	t.Run("PublishAfterClose", func(t *testing.T) { testPublishAfterClose(t, newBackend(t)) })
+}
+
+// collector 记录收到的消息，wait 等待收到 n 条
+type collector struct {
+	mu   sync.Mutex
+	msgs []subscriber.Message
+	ch   chan struct{}
+}
+
+func newCollector() *collector {
+	return &collector{ch: make(chan struct{}, 1024)}
+}
+
+func (c *collector) handle(_ context.Context, msg *subscriber.Message) error {
+	c.mu.Lock()
+	c.msgs = append(c.msgs, *msg)
+	c.mu.Unlock()
+	c.ch <- struct{}{}
+	return nil
+}
+
+func (c *collector) wait(t *testing.T, n int) []subscriber.Message {
+	t.Helper()
+	deadline := time.After(waitTimeout)
+	for i := 0; i < n; i++ {
+		select {
+		case <-c.ch:
+		case <-deadline:
+			t.Fatalf("received %d of %d messages", i, n)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]subscriber.Message(nil), c.msgs...)
+}
+
+// run 启动订阅者，测试结束时停止
+func run(t *testing.T, b *Backend, group string, topics []string, handler subscriber.Handler) {
+	t.Helper()
+	sub := b.Subscribe(t, group, topics, handler)
+	go sub.Start()
+	t.Cleanup(sub.Stop)
+}
+
+// uniqueTopic 只包含 kafka topic 允许的字符
+func uniqueTopic(t *testing.T) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, t.Name())
+	return fmt.Sprintf("conformance.%s.%d", name, time.Now().UnixNano())
+}
+
+func testRoundTrip(t *testing.T, b *Backend) {
+	topic := uniqueTopic(t)
+	c := newCollector()
+	run(t, b, "g1", []string{topic}, c.handle)
+
+	headers := map[string]string{"content-type": "application/json", "traceparent": "00-abc-def-01"}
+	require.NoError(t, b.Pub.Publish(context.Background(), topic, []byte(`{"n":1}`), &publisher.PublishOptions{
+		PartitionKey: []byte("u-1"),
+		Headers:      headers,
+	}))
+
+	msg := c.wait(t, 1)[0]
+	assert.Equal(t, topic, msg.Topic)
+	assert.Equal(t, []byte(`{"n":1}`), msg.Value)
+	assert.Equal(t, []byte("u-1"), msg.Key)
+	for k, v := range headers {
+		assert.Equal(t, v, msg.Headers[k], "header %s", k)
+	}
+}
+
+// 没有分区 key 的消息同样要带上 headers（traceparent、content-type 等）
+func testHeadersWithoutKey(t *testing.T, b *Backend) {
+	topic := uniqueTopic(t)
+	c := newCollector()
+	run(t, b, "g1", []string{topic}, c.handle)
+
+	headers := map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", "x-request-id": "req-1"}
+	require.NoError(t, b.Pub.Publish(context.Background(), topic, []byte("keyless"), &publisher.PublishOptions{Headers: headers}))
+
+	msg := c.wait(t, 1)[0]
+	assert.Empty(t, msg.Key)
+	for k, v := range headers {
+		assert.Equal(t, v, msg.Headers[k], "header %s", k)
+	}
+}
+
+func testKeyOrdering(t *testing.T, b *Backend) {
+	topic := uniqueTopic(t)
+	c := newCollector()
+	run(t, b, "g1", []string{topic}, c.handle)
+
+	const perKey = 20
+	keys := []string{"a", "b", "c"}
+	for i := 0; i < perKey; i++ {
+		for _, k := range keys {
+			require.NoError(t, b.Pub.Publish(context.Background(), topic, []byte(strconv.Itoa(i)), &publisher.PublishOptions{
+				PartitionKey: []byte(k),
+			}))
+		}
+	}
+
+	next := make(map[string]int)
+	for _, msg := range c.wait(t, perKey*len(keys)) {
+		k := string(msg.Key)
+		assert.Equal(t, strconv.Itoa(next[k]), string(msg.Value), "key %s out of order", k)
+		next[k]++
+	}
+}
+
+func testConsumerGroups(t *testing.T, b *Backend) {
+	topic := uniqueTopic(t)
+	g1, g2 := newCollector(), newCollector()
+	run(t, b, "g1", []string{topic}, g1.handle)
+	run(t, b, "g2", []string{topic}, g2.handle)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, b.Pub.Publish(context.Background(), topic, []byte(strconv.Itoa(i)), &publisher.PublishOptions{
+			PartitionKey: []byte("k"),
+		}))
+	}
+	assert.Len(t, g1.wait(t, 5), 5)
+	assert.Len(t, g2.wait(t, 5), 5)
+}
+
+func testTopicIsolation(t *testing.T, b *Backend) {
+	topic, other := uniqueTopic(t), uniqueTopic(t)+".other"
+	c := newCollector()
+	run(t, b, "g1", []string{topic}, c.handle)
+
+	require.NoError(t, b.Pub.Publish(context.Background(), other, []byte("other"), &publisher.PublishOptions{PartitionKey: []byte("k")}))
+	require.NoError(t, b.Pub.Publish(context.Background(), topic, []byte("mine"), &publisher.PublishOptions{PartitionKey: []byte("k")}))
+
+	c.wait(t, 1)
+	// 给误投递的消息留出到达的时间
+	time.Sleep(200 * time.Millisecond)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	require.Len(t, c.msgs, 1)
+	assert.Equal(t, []byte("mine"), c.msgs[0].Value)
+}
+
+func testPublishAfterClose(t *testing.T, b *Backend) {
+	require.NoError(t, b.Pub.Close())
+	assert.Error(t, b.Pub.Publish(context.Background(), uniqueTopic(t), []byte("x"), &publisher.PublishOptions{}))
+}
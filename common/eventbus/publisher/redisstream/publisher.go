@@ -0,0 +1,86 @@
+package redisstream
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// stream entry 的字段名，订阅者按相同约定解析
+const (
+	FieldKey          = "key"
+	FieldValue        = "value"
+	FieldHeaderPrefix = "h:"
+)
+
+const DefaultKeyPrefix = "eventbus:"
+
+var ErrClosed = errors.New("redis stream publisher: closed")
+
+// XADD 并按近似长度裁剪，MAXLEN ~ 由 Redis 以宏节点为单位裁剪，开销可以忽略
+const luaXAdd = `
+if tonumber(ARGV[1]) > 0 then
+	return redis.call('XADD', KEYS[1], 'MAXLEN', '~', ARGV[1], '*', unpack(ARGV, 2))
+end
+return redis.call('XADD', KEYS[1], '*', unpack(ARGV, 2))
+`
+
+type Options struct {
+	// KeyPrefix stream key = KeyPrefix + topic，默认 eventbus:
+	KeyPrefix string
+	// MaxLen 每个 stream 保留的近似最大条数，0 表示不裁剪
+	MaxLen int64
+}
+
+// Publisher 把消息写入 Redis Stream（每个 topic 一个 stream），用于本地开发时复用已有的 Redis 代替 kafka
+type Publisher struct {
+	rds    *redis.Redis
+	opts   Options
+	closed atomic.Bool
+}
+
+var _ publisher.Publisher = (*Publisher)(nil)
+
+func NewPublisher(rds *redis.Redis, opts Options) *Publisher {
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = DefaultKeyPrefix
+	}
+	return &Publisher{rds: rds, opts: opts}
+}
+
+// StreamKey 返回 topic 对应的 stream key
+func StreamKey(prefix, topic string) string {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+	return prefix + topic
+}
+
+func (p *Publisher) Publish(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) error {
+	if p.closed.Load() {
+		return ErrClosed
+	}
+	if topic == "" {
+		return errors.New("redis stream publisher: topic not set")
+	}
+	args := []any{p.opts.MaxLen, FieldValue, data}
+	if opts != nil {
+		if len(opts.PartitionKey) > 0 {
+			args = append(args, FieldKey, opts.PartitionKey)
+		}
+		for k, v := range opts.Headers {
+			args = append(args, FieldHeaderPrefix+k, v)
+		}
+	}
+	_, err := p.rds.EvalCtx(ctx, luaXAdd, []string{StreamKey(p.opts.KeyPrefix, topic)}, args...)
+	return err
+}
+
+// Close 之后 Publish 返回 ErrClosed；Redis 连接由调用方管理，不在这里关闭
+func (p *Publisher) Close() error {
+	p.closed.Store(true)
+	return nil
+}
@@ -0,0 +1,137 @@
+package redisstream_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	red "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/publishertest"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/redisstream"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	substream "github.com/uwu-octane/antBackend/common/eventbus/subscriber/redisstream"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+func newTestRedis(t *testing.T) (*redis.Redis, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	return redis.MustNewRedis(redis.RedisConf{Host: mr.Addr(), Type: redis.NodeType}), mr
+}
+
+func newSubscriber(t *testing.T, rds *redis.Redis, group string, topics []string, handler subscriber.Handler) *substream.Subscriber {
+	sub, err := substream.NewSubscriber(rds, &substream.Options{
+		KeyPrefix:   "test:",
+		Group:       group,
+		Consumer:    "c1",
+		Topics:      topics,
+		Concurrency: 4,
+		Block:       50 * time.Millisecond,
+	}, handler, nil)
+	require.NoError(t, err)
+	return sub
+}
+
+func TestRedisStream_Conformance(t *testing.T) {
+	publishertest.Run(t, func(t *testing.T) *publishertest.Backend {
+		rds, _ := newTestRedis(t)
+		return &publishertest.Backend{
+			Pub: redisstream.NewPublisher(rds, redisstream.Options{KeyPrefix: "test:"}),
+			Subscribe: func(t *testing.T, group string, topics []string, handler subscriber.Handler) subscriber.Subscriber {
+				return newSubscriber(t, rds, group, topics, handler)
+			},
+		}
+	})
+}
+
+func TestRedisStream_MaxLen(t *testing.T) {
+	rds, mr := newTestRedis(t)
+	pub := redisstream.NewPublisher(rds, redisstream.Options{MaxLen: 10})
+	for i := 0; i < 50; i++ {
+		require.NoError(t, pub.Publish(context.Background(), "t", []byte(strconv.Itoa(i)), &publisher.PublishOptions{}))
+	}
+	entries, err := mr.Stream(redisstream.StreamKey("", "t"))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(entries), 10)
+	assert.Equal(t, "eventbus:t", redisstream.StreamKey("", "t"))
+}
+
+func TestRedisStream_UnackedRedeliveredAfterRestart(t *testing.T) {
+	rds, _ := newTestRedis(t)
+	pub := redisstream.NewPublisher(rds, redisstream.Options{KeyPrefix: "test:"})
+
+	stopping := make(chan struct{})
+	first := newSubscriber(t, rds, "g", []string{"t"}, func(ctx context.Context, msg *subscriber.Message) error {
+		close(stopping)
+		<-subscriber.Stopping(ctx)
+		return subscriber.ErrUncommitted
+	})
+	go first.Start()
+	require.NoError(t, pub.Publish(context.Background(), "t", []byte("a"), &publisher.PublishOptions{PartitionKey: []byte("k")}))
+	<-stopping
+	first.Stop()
+
+	got := make(chan string, 1)
+	second := newSubscriber(t, rds, "g", []string{"t"}, func(ctx context.Context, msg *subscriber.Message) error {
+		got <- string(msg.Value)
+		return nil
+	})
+	go second.Start()
+	defer second.Stop()
+	select {
+	case v := <-got:
+		assert.Equal(t, "a", v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending message was not redelivered")
+	}
+}
+
+func TestRedisStream_ClaimsIdleEntriesOfAnotherConsumer(t *testing.T) {
+	rds, mr := newTestRedis(t)
+	pub := redisstream.NewPublisher(rds, redisstream.Options{KeyPrefix: "test:"})
+
+	// the first replica reads the message and never comes back under its name
+	stopping := make(chan struct{})
+	dead := newSubscriber(t, rds, "g", []string{"t"}, func(ctx context.Context, msg *subscriber.Message) error {
+		close(stopping)
+		<-subscriber.Stopping(ctx)
+		return subscriber.ErrUncommitted
+	})
+	go dead.Start()
+	require.NoError(t, pub.Publish(context.Background(), "t", []byte("a"), &publisher.PublishOptions{PartitionKey: []byte("k")}))
+	<-stopping
+	dead.Stop()
+	time.Sleep(20 * time.Millisecond)
+
+	got := make(chan string, 1)
+	other, err := substream.NewSubscriber(rds, &substream.Options{
+		KeyPrefix:    "test:",
+		Group:        "g",
+		Consumer:     "c2",
+		Topics:       []string{"t"},
+		Block:        50 * time.Millisecond,
+		ClaimMinIdle: 10 * time.Millisecond,
+	}, func(ctx context.Context, msg *subscriber.Message) error {
+		got <- string(msg.Value)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	go other.Start()
+	select {
+	case v := <-got:
+		assert.Equal(t, "a", v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle message of the stopped consumer was not claimed")
+	}
+	other.Stop()
+
+	client := red.NewClient(&red.Options{Addr: mr.Addr()})
+	defer client.Close()
+	pending, err := client.XPending(context.Background(), redisstream.StreamKey("test:", "t"), "g").Result()
+	require.NoError(t, err)
+	assert.Zero(t, pending.Count, "the claimed message is acked by the new owner")
+}
@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 失败元数据随消息写入 Kafka headers
+const (
+	HeaderAttempts          = "x-retry-attempts"
+	HeaderError             = "x-retry-error"
+	HeaderNotBefore         = "x-retry-not-before" // unix 毫秒，早于该时间不重新处理
+	HeaderFirstFailedAt     = "x-retry-first-failed-at"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+)
+
+const (
+	maxErrorLen         = 1024
+	republishBackoff    = 500 * time.Millisecond
+	maxRepublishBackoff = 30 * time.Second
+)
+
+// Policy 消费侧失败策略：handler 失败后依次投递到 <topic>.retry.<delay>，最后进入 <topic>.dlq。
+// 重试 topic 与主 topic 由同一个消费组订阅（见 Topics），消息在 not-before 之前会在分区内等待。
+type Policy struct {
+	// Topic 主 topic，应来自 event.BuildTopics
+	Topic string
+	// Delays 各级重试的延迟，例如 1m、10m；为空时失败直接进入 DLQ
+	Delays []time.Duration
+	Pub    publisher.Publisher
+}
+
+func NewPolicy(topic string, delays []time.Duration, pub publisher.Publisher) *Policy {
+	return &Policy{Topic: topic, Delays: delays, Pub: pub}
+}
+
+// Topics 返回消费组需要订阅的 topic：主 topic 加全部重试 topic（不含 DLQ）
+func (p *Policy) Topics() []string {
+	topics := []string{p.Topic}
+	for _, d := range p.Delays {
+		topics = append(topics, event.RetryTopic(p.Topic, d))
+	}
+	return topics
+}
+
+// DLQTopic 返回死信 topic
+func (p *Policy) DLQTopic() string {
+	return event.DLQTopic(p.Topic)
+}
+
+// Wrap 包装 handler：重试消息等待到期后再处理，处理失败（含 panic）转投下一级重试或 DLQ 并提交原消息
+func (p *Policy) Wrap(next subscriber.Handler) subscriber.Handler {
+	return func(ctx context.Context, msg *subscriber.Message) error {
+		if err := waitNotBefore(ctx, msg); err != nil {
+			return err
+		}
+		err := call(ctx, next, msg)
+		if err == nil || errors.Is(err, subscriber.ErrUncommitted) {
+			return err
+		}
+		return p.fail(ctx, msg, err)
+	}
+}
+
+// call 把 handler 的 panic 转成 error，坏消息与普通失败一样进入重试 / DLQ，而不是在订阅者里被当作已处理提交
+func call(ctx context.Context, next subscriber.Handler, msg *subscriber.Message) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panic: %v", p)
+		}
+	}()
+	return next(ctx, msg)
+}
+
+func (p *Policy) fail(ctx context.Context, msg *subscriber.Message, cause error) error {
+	attempts := Attempts(msg) + 1
+	target := p.DLQTopic()
+	var delay time.Duration
+	if attempts <= len(p.Delays) {
+		delay = p.Delays[attempts-1]
+		target = event.RetryTopic(p.Topic, delay)
+	}
+
+	headers := failureHeaders(msg, cause, attempts, delay)
+	fields := []logx.LogField{
+		logx.Field("topic", msg.Topic),
+		logx.Field("offset", msg.Offset),
+		logx.Field("attempts", attempts),
+		logx.Field("target", target),
+		logx.Field("error", cause),
+	}
+	if target == p.DLQTopic() {
+		logx.WithContext(ctx).Errorw("event handler failed, moved to dlq", fields...)
+	} else {
+		logx.WithContext(ctx).Infow("event handler failed, scheduled retry", fields...)
+	}
+	return p.republish(ctx, target, msg, headers)
+}
+
+// republish 一直重试到成功或订阅者停止：消息已失败，丢弃会导致事件丢失
+func (p *Policy) republish(ctx context.Context, topic string, msg *subscriber.Message, headers map[string]string) error {
+	backoff := republishBackoff
+	for {
+		err := p.Pub.Publish(ctx, topic, msg.Value, &publisher.PublishOptions{
+			PartitionKey: msg.Key,
+			Headers:      headers,
+		})
+		if err == nil {
+			return nil
+		}
+		logx.WithContext(ctx).Errorw("republish failed event failed",
+			logx.Field("target", topic), logx.Field("offset", msg.Offset), logx.Field("error", err))
+		select {
+		case <-subscriber.Stopping(ctx):
+			return subscriber.ErrUncommitted
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRepublishBackoff)
+	}
+}
+
+// Attempts 返回消息已失败的次数，首次投递为 0
+func Attempts(msg *subscriber.Message) int {
+	n, err := strconv.Atoi(msg.Headers[HeaderAttempts])
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func failureHeaders(msg *subscriber.Message, cause error, attempts int, delay time.Duration) map[string]string {
+	headers := make(map[string]string, len(msg.Headers)+4)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	now := time.Now()
+	errText := cause.Error()
+	if len(errText) > maxErrorLen {
+		errText = errText[:maxErrorLen]
+	}
+	headers[HeaderAttempts] = strconv.Itoa(attempts)
+	headers[HeaderError] = errText
+	if _, ok := headers[HeaderOriginalTopic]; !ok {
+		headers[HeaderOriginalTopic] = msg.Topic
+		headers[HeaderOriginalPartition] = strconv.FormatInt(int64(msg.Partition), 10)
+		headers[HeaderOriginalOffset] = strconv.FormatInt(msg.Offset, 10)
+		headers[HeaderFirstFailedAt] = now.UTC().Format(time.RFC3339)
+	}
+	if delay > 0 {
+		headers[HeaderNotBefore] = strconv.FormatInt(now.Add(delay).UnixMilli(), 10)
+	} else {
+		delete(headers, HeaderNotBefore)
+	}
+	return headers
+}
+
+// waitNotBefore 阻塞到重试消息到期；订阅者停止时返回 ErrUncommitted，消息稍后重新投递
+func waitNotBefore(ctx context.Context, msg *subscriber.Message) error {
+	ms, err := strconv.ParseInt(msg.Headers[HeaderNotBefore], 10, 64)
+	if err != nil {
+		return nil
+	}
+	wait := time.Until(time.UnixMilli(ms))
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-subscriber.Stopping(ctx):
+		return subscriber.ErrUncommitted
+	}
+}
@@ -0,0 +1,213 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+)
+
+type published struct {
+	topic   string
+	key     []byte
+	value   []byte
+	headers map[string]string
+}
+
+// recordingPublisher keeps every message, failures makes the first n Publish calls fail
+type recordingPublisher struct {
+	mu       sync.Mutex
+	msgs     []published
+	failures int
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, data []byte, opts *publisher.PublishOptions) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	p.msgs = append(p.msgs, published{topic: topic, key: opts.PartitionKey, value: data, headers: opts.Headers})
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+func (p *recordingPublisher) last(t *testing.T) published {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	require.NotEmpty(t, p.msgs)
+	return p.msgs[len(p.msgs)-1]
+}
+
+// redeliver turns a published message back into what the consumer would receive
+func redeliver(m published, offset int64) *subscriber.Message {
+	return &subscriber.Message{Topic: m.topic, Partition: 0, Offset: offset, Key: m.key, Value: m.value, Headers: m.headers}
+}
+
+func TestPolicy_Topics(t *testing.T) {
+	topic := event.BuildTopics(event.EnvProd).UserEvents()
+	p := NewPolicy(topic, []time.Duration{time.Minute, 10 * time.Minute, 2 * time.Hour}, nil)
+	assert.Equal(t, []string{
+		"prod.user.service.user-events",
+		"prod.user.service.user-events.retry.1m",
+		"prod.user.service.user-events.retry.10m",
+		"prod.user.service.user-events.retry.2h",
+	}, p.Topics())
+	assert.Equal(t, "prod.user.service.user-events.dlq", p.DLQTopic())
+}
+
+func TestPolicy_RetryTiersThenDLQ(t *testing.T) {
+	pub := &recordingPublisher{}
+	p := NewPolicy("dev.test", []time.Duration{time.Millisecond, 2 * time.Millisecond}, pub)
+	calls := 0
+	h := p.Wrap(func(context.Context, *subscriber.Message) error {
+		calls++
+		return errors.New("boom")
+	})
+
+	msg := &subscriber.Message{Topic: "dev.test", Partition: 3, Offset: 42, Key: []byte("u-1"), Value: []byte(`{}`),
+		Headers: map[string]string{"traceparent": "00-abc-def-01"}}
+	require.NoError(t, h(context.Background(), msg))
+	first := pub.last(t)
+	assert.Equal(t, "dev.test.retry.1ms", first.topic)
+	assert.Equal(t, []byte("u-1"), first.key)
+	assert.Equal(t, "1", first.headers[HeaderAttempts])
+	assert.Equal(t, "boom", first.headers[HeaderError])
+	assert.Equal(t, "dev.test", first.headers[HeaderOriginalTopic])
+	assert.Equal(t, "3", first.headers[HeaderOriginalPartition])
+	assert.Equal(t, "42", first.headers[HeaderOriginalOffset])
+	assert.Equal(t, "00-abc-def-01", first.headers["traceparent"])
+	assert.NotEmpty(t, first.headers[HeaderNotBefore])
+
+	require.NoError(t, h(context.Background(), redeliver(first, 0)))
+	second := pub.last(t)
+	assert.Equal(t, "dev.test.retry.2ms", second.topic)
+	assert.Equal(t, "2", second.headers[HeaderAttempts])
+	// the original position survives every hop
+	assert.Equal(t, "42", second.headers[HeaderOriginalOffset])
+
+	require.NoError(t, h(context.Background(), redeliver(second, 0)))
+	dead := pub.last(t)
+	assert.Equal(t, "dev.test.dlq", dead.topic)
+	assert.Equal(t, "3", dead.headers[HeaderAttempts])
+	assert.Empty(t, dead.headers[HeaderNotBefore])
+	assert.Equal(t, 3, calls)
+}
+
+func TestPolicy_SuccessAfterRetry(t *testing.T) {
+	pub := &recordingPublisher{}
+	p := NewPolicy("dev.test", []time.Duration{time.Millisecond}, pub)
+	calls := 0
+	h := p.Wrap(func(context.Context, *subscriber.Message) error {
+		calls++
+		if calls == 1 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	require.NoError(t, h(context.Background(), &subscriber.Message{Topic: "dev.test", Value: []byte(`{}`)}))
+	require.NoError(t, h(context.Background(), redeliver(pub.last(t), 0)))
+	assert.Len(t, pub.msgs, 1)
+	assert.Equal(t, 2, calls)
+}
+
+func TestPolicy_PanicIsRetriedThenDLQ(t *testing.T) {
+	tests := []struct {
+		name   string
+		delays []time.Duration
+		topics []string
+	}{
+		{
+			name:   "panic goes through the retry tiers",
+			delays: []time.Duration{time.Millisecond},
+			topics: []string{"dev.test.retry.1ms", "dev.test.dlq"},
+		},
+		{
+			name:   "panic without retry tiers goes to the dlq",
+			topics: []string{"dev.test.dlq"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pub := &recordingPublisher{}
+			h := NewPolicy("dev.test", tt.delays, pub).Wrap(func(context.Context, *subscriber.Message) error {
+				panic("bad payload")
+			})
+
+			msg := &subscriber.Message{Topic: "dev.test", Offset: 7, Value: []byte(`{}`)}
+			for range tt.topics {
+				require.NoError(t, h(context.Background(), msg))
+				msg = redeliver(pub.last(t), 0)
+			}
+			var topics []string
+			for _, m := range pub.msgs {
+				topics = append(topics, m.topic)
+				assert.Equal(t, "handler panic: bad payload", m.headers[HeaderError])
+				assert.Equal(t, "7", m.headers[HeaderOriginalOffset])
+			}
+			assert.Equal(t, tt.topics, topics)
+		})
+	}
+}
+
+func TestPolicy_WaitsUntilNotBefore(t *testing.T) {
+	p := NewPolicy("dev.test", []time.Duration{time.Minute}, &recordingPublisher{})
+	var handledAt time.Time
+	h := p.Wrap(func(context.Context, *subscriber.Message) error {
+		handledAt = time.Now()
+		return nil
+	})
+
+	notBefore := time.Now().Add(50 * time.Millisecond)
+	msg := &subscriber.Message{Topic: "dev.test.retry.1m", Value: []byte(`{}`),
+		Headers: map[string]string{HeaderAttempts: "1", HeaderNotBefore: strconv.FormatInt(notBefore.UnixMilli(), 10)}}
+	require.NoError(t, h(context.Background(), msg))
+	assert.False(t, handledAt.Before(notBefore.Truncate(time.Millisecond)))
+}
+
+func TestPolicy_StopWhileWaitingLeavesUncommitted(t *testing.T) {
+	p := NewPolicy("dev.test", []time.Duration{time.Hour}, &recordingPublisher{})
+	called := false
+	h := p.Wrap(func(context.Context, *subscriber.Message) error {
+		called = true
+		return nil
+	})
+
+	stopping := make(chan struct{})
+	ctx := subscriber.WithStopping(context.Background(), stopping)
+	msg := &subscriber.Message{Topic: "dev.test.retry.1h", Value: []byte(`{}`),
+		Headers: map[string]string{HeaderNotBefore: strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10)}}
+
+	done := make(chan error, 1)
+	go func() { done <- h(ctx, msg) }()
+	close(stopping)
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, subscriber.ErrUncommitted)
+	case <-time.After(time.Second):
+		t.Fatal("handler did not return after stop")
+	}
+	assert.False(t, called)
+}
+
+func TestPolicy_RepublishRetriesUntilBrokerBack(t *testing.T) {
+	pub := &recordingPublisher{failures: 2}
+	p := NewPolicy("dev.test", nil, pub)
+	h := p.Wrap(func(context.Context, *subscriber.Message) error {
+		return errors.New("boom")
+	})
+
+	require.NoError(t, h(context.Background(), &subscriber.Message{Topic: "dev.test", Value: []byte(`{}`)}))
+	assert.Equal(t, "dev.test.dlq", pub.last(t).topic)
+}
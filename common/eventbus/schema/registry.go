@@ -0,0 +1,209 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrUnknownEventType    = errors.New("schema: unknown event type")
+	ErrUnknownEventVersion = errors.New("schema: unknown event version")
+)
+
+// Upcaster 把 from 版本的 data（原始 JSON）升级为 from+1 版本
+type Upcaster func(data json.RawMessage) (json.RawMessage, error)
+
+// Registry 维护 (EventType, EventVersion) -> Go 类型 与逐级 upcaster 链。
+// 发布时校验类型/版本已登记，消费时把旧版本 payload 升级到最新版本。
+type Registry struct {
+	mu    sync.RWMutex
+	types map[string]*typeSchema
+}
+
+type typeSchema struct {
+	latest    int
+	goTypes   map[int]reflect.Type
+	upcasters map[int]Upcaster
+}
+
+// Default 全局注册表，事件定义所在的包在 init 中登记
+var Default = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{types: make(map[string]*typeSchema)}
+}
+
+// Register 登记 eventType 的 version 版本 payload 为 T，重复登记会 panic
+func Register[T any](r *Registry, eventType string, version int) {
+	if version < 1 {
+		panic(fmt.Sprintf("schema: %s version must start at 1, got %d", eventType, version))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.schemaLocked(eventType)
+	if _, ok := s.goTypes[version]; ok {
+		panic(fmt.Sprintf("schema: %s v%d already registered", eventType, version))
+	}
+	s.goTypes[version] = reflect.TypeOf((*T)(nil)).Elem()
+	if version > s.latest {
+		s.latest = version
+	}
+}
+
+// RegisterUpcaster 登记 eventType 从 from 升级到 from+1 的函数
+func (r *Registry) RegisterUpcaster(eventType string, from int, fn Upcaster) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.schemaLocked(eventType)
+	if _, ok := s.upcasters[from]; ok {
+		panic(fmt.Sprintf("schema: %s upcaster v%d->v%d already registered", eventType, from, from+1))
+	}
+	s.upcasters[from] = fn
+}
+
+func (r *Registry) schemaLocked(eventType string) *typeSchema {
+	s, ok := r.types[eventType]
+	if !ok {
+		s = &typeSchema{goTypes: make(map[int]reflect.Type), upcasters: make(map[int]Upcaster)}
+		r.types[eventType] = s
+	}
+	return s
+}
+
+// Latest 返回 eventType 的最新版本
+func (r *Registry) Latest(eventType string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.types[eventType]
+	if !ok || s.latest == 0 {
+		return 0, false
+	}
+	return s.latest, true
+}
+
+// TypeOf 返回 (eventType, version) 登记的 Go 类型
+func (r *Registry) TypeOf(eventType string, version int) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.types[eventType]
+	if !ok {
+		return nil, false
+	}
+	t, ok := s.goTypes[version]
+	return t, ok
+}
+
+// EventTypes 返回已登记的事件类型（排序后）
+func (r *Registry) EventTypes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]string, 0, len(r.types))
+	for t := range r.types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Versions 返回 eventType 已登记的版本（升序）
+func (r *Registry) Versions(eventType string) []int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.types[eventType]
+	if !ok {
+		return nil
+	}
+	versions := make([]int, 0, len(s.goTypes))
+	for v := range s.goTypes {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// Validate 校验 (eventType, version) 已登记，且存在升级到最新版本的完整 upcaster 链
+func (r *Registry) Validate(eventType string, version int) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.types[eventType]
+	if !ok || s.latest == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	if _, ok := s.goTypes[version]; !ok {
+		return fmt.Errorf("%w: %s v%d", ErrUnknownEventVersion, eventType, version)
+	}
+	for v := version; v < s.latest; v++ {
+		if _, ok := s.upcasters[v]; !ok {
+			return fmt.Errorf("schema: %s has no upcaster v%d->v%d", eventType, v, v+1)
+		}
+	}
+	return nil
+}
+
+// Upcast 把 Envelope 原始字节中的 data 升级到最新版本并更新 event_version。
+// 已是最新版本或未登记的事件类型原样返回；版本高于已知最新版本时返回错误（消费者需要先升级）。
+func (r *Registry) Upcast(b []byte) ([]byte, error) {
+	var env map[string]json.RawMessage
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, err
+	}
+	var eventType string
+	var version int
+	if err := json.Unmarshal(env["event_type"], &eventType); err != nil {
+		return nil, fmt.Errorf("schema: decode event_type: %w", err)
+	}
+	if raw, ok := env["event_version"]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, fmt.Errorf("schema: decode event_version: %w", err)
+		}
+	}
+
+	data, latest, err := r.UpcastData(eventType, version, env["data"])
+	if err != nil {
+		return nil, err
+	}
+	if latest == version {
+		return b, nil
+	}
+	v, err := json.Marshal(latest)
+	if err != nil {
+		return nil, err
+	}
+	env["data"] = data
+	env["event_version"] = v
+	return json.Marshal(env)
+}
+
+// UpcastData 把 version 版本的 data 逐级升级到最新版本，返回升级后的 data 与版本号。
+// 未登记的事件类型原样返回。
+func (r *Registry) UpcastData(eventType string, version int, data json.RawMessage) (json.RawMessage, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.types[eventType]
+	if !ok || version == s.latest {
+		return data, version, nil
+	}
+	if version > s.latest {
+		return nil, 0, fmt.Errorf("%w: %s v%d is newer than v%d", ErrUnknownEventVersion, eventType, version, s.latest)
+	}
+
+	for v := version; v < s.latest; v++ {
+		up, ok := s.upcasters[v]
+		if !ok {
+			return nil, 0, fmt.Errorf("schema: %s has no upcaster v%d->v%d", eventType, v, v+1)
+		}
+		next, err := up(data)
+		if err != nil {
+			return nil, 0, fmt.Errorf("schema: upcast %s v%d->v%d: %w", eventType, v, v+1, err)
+		}
+		data = next
+	}
+	return data, s.latest, nil
+}
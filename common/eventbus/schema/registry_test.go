@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type greetedV1 struct {
+	Name string `json:"name"`
+}
+
+type greetedV2 struct {
+	FirstName string `json:"first_name"`
+	Polite    bool   `json:"polite"`
+}
+
+func newTestRegistry() *Registry {
+	r := NewRegistry()
+	Register[greetedV1](r, "test.greeted", 1)
+	Register[greetedV2](r, "test.greeted", 2)
+	r.RegisterUpcaster("test.greeted", 1, func(data json.RawMessage) (json.RawMessage, error) {
+		var v1 greetedV1
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(greetedV2{FirstName: v1.Name})
+	})
+	return r
+}
+
+func TestRegistry_UpcastV1ToLatest(t *testing.T) {
+	r := newTestRegistry()
+	in := []byte(`{"event_id":"e-1","event_type":"test.greeted","event_version":1,"data":{"name":"ant"}}`)
+
+	out, err := r.Upcast(in)
+	require.NoError(t, err)
+
+	var env struct {
+		EventID      string    `json:"event_id"`
+		EventVersion int       `json:"event_version"`
+		Data         greetedV2 `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(out, &env))
+	assert.Equal(t, "e-1", env.EventID)
+	assert.Equal(t, 2, env.EventVersion)
+	assert.Equal(t, greetedV2{FirstName: "ant"}, env.Data)
+}
+
+func TestRegistry_UpcastPassThrough(t *testing.T) {
+	r := newTestRegistry()
+	latest := []byte(`{"event_type":"test.greeted","event_version":2,"data":{"first_name":"ant"}}`)
+	out, err := r.Upcast(latest)
+	require.NoError(t, err)
+	assert.Equal(t, latest, out)
+
+	unknown := []byte(`{"event_type":"test.other","event_version":7,"data":{}}`)
+	out, err = r.Upcast(unknown)
+	require.NoError(t, err)
+	assert.Equal(t, unknown, out)
+}
+
+func TestRegistry_UpcastNewerVersion(t *testing.T) {
+	r := newTestRegistry()
+	_, err := r.Upcast([]byte(`{"event_type":"test.greeted","event_version":3,"data":{}}`))
+	assert.ErrorIs(t, err, ErrUnknownEventVersion)
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	r := newTestRegistry()
+	assert.NoError(t, r.Validate("test.greeted", 1))
+	assert.NoError(t, r.Validate("test.greeted", 2))
+	assert.ErrorIs(t, r.Validate("test.greeted", 3), ErrUnknownEventVersion)
+	assert.ErrorIs(t, r.Validate("test.other", 1), ErrUnknownEventType)
+
+	// v1 登记但缺少 upcaster 时无法升级到最新版本
+	Register[greetedV1](r, "test.broken", 1)
+	Register[greetedV2](r, "test.broken", 2)
+	assert.Error(t, r.Validate("test.broken", 1))
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	r := newTestRegistry()
+	v, ok := r.Latest("test.greeted")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	typ, ok := r.TypeOf("test.greeted", 1)
+	require.True(t, ok)
+	assert.Equal(t, "greetedV1", typ.Name())
+	assert.Equal(t, []string{"test.greeted"}, r.EventTypes())
+	assert.Equal(t, []int{1, 2}, r.Versions("test.greeted"))
+	assert.Nil(t, r.Versions("test.other"))
+
+	assert.Panics(t, func() { Register[greetedV1](r, "test.greeted", 1) })
+}
@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+func BuildSaramaConfig(c *ConsumerOptions) (*sarama.Config, error) {
+	if c == nil {
+		c = &ConsumerOptions{}
+	}
+
+	cfg := sarama.NewConfig()
+
+	switch strings.ToLower(c.Offset) {
+	case "first":
+		cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	case "last", "":
+		cfg.Consumer.Offsets.Initial = sarama.OffsetNewest
+	default:
+		return nil, fmt.Errorf("unsupported kafka consumer offset: %s", c.Offset)
+	}
+
+	// 位点由 PartitionWorker 按连续完成的前缀 MarkOffset，sarama 定期自动提交
+	cfg.Consumer.Offsets.AutoCommit.Enable = true
+	cfg.Consumer.Offsets.AutoCommit.Interval = time.Second
+	cfg.Consumer.Return.Errors = true
+	cfg.Consumer.Group.Rebalance.GroupStrategies = []sarama.BalanceStrategy{sarama.BalanceStrategySticky}
+
+	cfg.Metadata.Retry.Max = 10
+	cfg.Metadata.Retry.Backoff = 250 * time.Millisecond
+	cfg.Metadata.RefreshFrequency = 30 * time.Second
+	cfg.Net.DialTimeout = 10 * time.Second
+	cfg.Net.ReadTimeout = 10 * time.Second
+	cfg.Net.WriteTimeout = 10 * time.Second
+
+	if c.EnableSASL {
+		if err := applySASL(cfg, c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.EnableTLS {
+		cfg.Net.TLS.Enable = true
+	}
+
+	if strings.TrimSpace(c.KafkaVersion) != "" {
+		version, err := sarama.ParseKafkaVersion(c.KafkaVersion)
+		if err != nil {
+			return nil, fmt.Errorf("parse kafka version: %w", err)
+		}
+		cfg.Version = version
+	} else {
+		// consumer group 需要 >= 0.10.2
+		cfg.Version = sarama.V2_1_0_0
+	}
+
+	return cfg, nil
+}
+
+func applySASL(cfg *sarama.Config, opts *ConsumerOptions) error {
+	switch strings.ToLower(opts.SASLMechanism) {
+	case "scram-sha256":
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+	case "scram-sha512":
+		cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+	default:
+		cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+	}
+
+	cfg.Net.SASL.Enable = true
+	cfg.Net.SASL.User = opts.SASLUsername
+	cfg.Net.SASL.Password = opts.SASLPassword
+	if cfg.Net.SASL.User == "" || cfg.Net.SASL.Password == "" {
+		return fmt.Errorf("sasl username/password must be set when sasl is enabled")
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package kafka
+
+// 供各服务层适配使用的通用 consumer 选项
+type ConsumerOptions struct {
+	Brokers       []string
+	Group         string
+	Topics        []string
+	Offset        string // "first" | "last"，仅在消费组没有已提交位点时生效
+	Concurrency   int    // 每个分区的并发 worker 数，相同 key 始终串行
+	EnableSASL    bool
+	SASLMechanism string // "plain" | "scram-sha256" | "scram-sha512"
+	SASLUsername  string
+	SASLPassword  string
+	EnableTLS     bool
+	KafkaVersion  string // "3.6.0" 等，可留空用默认
+}
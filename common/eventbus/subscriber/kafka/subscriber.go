@@ -0,0 +1,153 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const rejoinBackoff = time.Second
+
+// SaramaSubscriber 基于 sarama ConsumerGroup 的订阅者。
+// 分区内通过 subscriber.PartitionWorker 并发处理（相同 key 保序），Stop 时等待在途消息处理完成并提交位点。
+type SaramaSubscriber struct {
+	group       sarama.ConsumerGroup
+	topics      []string
+	concurrency int
+	handler     subscriber.Handler
+	onError     subscriber.ErrorHandler
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ subscriber.Subscriber = (*SaramaSubscriber)(nil)
+
+func NewSaramaSubscriber(opts *ConsumerOptions, handler subscriber.Handler, onError subscriber.ErrorHandler) (*SaramaSubscriber, error) {
+	if opts == nil || len(opts.Brokers) == 0 || opts.Group == "" || len(opts.Topics) == 0 {
+		return nil, errors.New("kafka subscriber: brokers, group and topics are required")
+	}
+	if handler == nil {
+		return nil, errors.New("kafka subscriber: handler is nil")
+	}
+	cfg, err := BuildSaramaConfig(opts)
+	if err != nil {
+		logx.Errorw("build sarama consumer config failed", logx.Field("error", err))
+		return nil, err
+	}
+	group, err := sarama.NewConsumerGroup(opts.Brokers, opts.Group, cfg)
+	if err != nil {
+		logx.Errorw("create sarama consumer group failed", logx.Field("error", err))
+		return nil, err
+	}
+	logx.Infow("create sarama consumer group",
+		logx.Field("brokers", opts.Brokers), logx.Field("group", opts.Group), logx.Field("topics", opts.Topics))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SaramaSubscriber{
+		group:       group,
+		topics:      opts.Topics,
+		concurrency: opts.Concurrency,
+		handler:     handler,
+		onError:     onError,
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
+}
+
+// Start 阻塞消费，rebalance 后自动重新加入消费组，直到 Stop
+func (s *SaramaSubscriber) Start() {
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	go func() {
+		for err := range s.group.Errors() {
+			logx.Errorw("kafka consumer group error", logx.Field("error", err))
+		}
+	}()
+
+	h := &groupHandler{s: s}
+	for {
+		if err := s.group.Consume(s.ctx, s.topics, h); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return
+			}
+			logx.Errorw("kafka consume failed", logx.Field("error", err))
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(rejoinBackoff):
+		}
+	}
+}
+
+// Stop 退出消费组：停止拉取、等待在途消息处理完成、提交位点后关闭连接
+func (s *SaramaSubscriber) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	if err := s.group.Close(); err != nil {
+		logx.Errorw("close kafka consumer group failed", logx.Field("error", err))
+	}
+}
+
+type groupHandler struct {
+	s *SaramaSubscriber
+}
+
+func (h *groupHandler) Setup(sess sarama.ConsumerGroupSession) error {
+	logx.Infow("kafka consumer group session started",
+		logx.Field("member_id", sess.MemberID()), logx.Field("claims", sess.Claims()))
+	return nil
+}
+
+func (h *groupHandler) Cleanup(sess sarama.ConsumerGroupSession) error {
+	sess.Commit()
+	return nil
+}
+
+func (h *groupHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	// handler 不随 session 取消，保证 rebalance/Stop 时在途消息能处理完
+	ctx := subscriber.WithStopping(context.WithoutCancel(sess.Context()), sess.Context().Done())
+	worker := subscriber.NewPartitionWorker(ctx, h.s.concurrency, h.s.handler, h.s.onError, func(offset int64) {
+		sess.MarkOffset(claim.Topic(), claim.Partition(), offset+1, "")
+	})
+	defer worker.Close()
+
+	for {
+		select {
+		case m, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			worker.Submit(toMessage(m))
+		case <-sess.Context().Done():
+			return nil
+		}
+	}
+}
+
+func toMessage(m *sarama.ConsumerMessage) *subscriber.Message {
+	headers := make(map[string]string, len(m.Headers))
+	for _, h := range m.Headers {
+		if h == nil {
+			continue
+		}
+		headers[string(h.Key)] = string(h.Value)
+	}
+	return &subscriber.Message{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       m.Key,
+		Value:     m.Value,
+		Headers:   headers,
+		Timestamp: m.Timestamp,
+	}
+}
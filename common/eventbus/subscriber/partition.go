@@ -0,0 +1,144 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const workerQueueSize = 64
+
+// PartitionWorker 在单个分区内并发处理消息：相同 partition key 的消息落在同一 worker 上串行执行（保序），
+// 无 key 的消息按 offset 轮询分配。commit 只推进到连续完成的最大 offset，进程中途退出不会跳过未完成的消息。
+type PartitionWorker struct {
+	ctx     context.Context
+	handler Handler
+	onError ErrorHandler
+	commit  func(offset int64)
+
+	queues []chan *Message
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	pending []*inflight
+}
+
+type inflight struct {
+	offset int64
+	done   bool
+}
+
+// NewPartitionWorker 启动 concurrency 个 worker；commit 以已完成消息的 offset 调用（由调用方决定 +1 语义）
+func NewPartitionWorker(ctx context.Context, concurrency int, handler Handler, onError ErrorHandler, commit func(offset int64)) *PartitionWorker {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	w := &PartitionWorker{
+		ctx:     ctx,
+		handler: handler,
+		onError: onError,
+		commit:  commit,
+		queues:  make([]chan *Message, concurrency),
+	}
+	for i := range w.queues {
+		q := make(chan *Message, workerQueueSize)
+		w.queues[i] = q
+		w.wg.Add(1)
+		go w.run(q)
+	}
+	return w
+}
+
+// Submit 按 offset 递增顺序提交消息，worker 队列满时阻塞（背压）
+func (w *PartitionWorker) Submit(msg *Message) {
+	w.mu.Lock()
+	w.pending = append(w.pending, &inflight{offset: msg.Offset})
+	w.mu.Unlock()
+	w.queues[w.pick(msg)] <- msg
+}
+
+// Close 停止接收并等待已提交的消息全部处理完成
+func (w *PartitionWorker) Close() {
+	for _, q := range w.queues {
+		close(q)
+	}
+	w.wg.Wait()
+}
+
+func (w *PartitionWorker) pick(msg *Message) int {
+	n := len(w.queues)
+	if n == 1 {
+		return 0
+	}
+	if len(msg.Key) == 0 {
+		return int(msg.Offset % int64(n))
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(msg.Key)
+	return int(h.Sum32() % uint32(n))
+}
+
+func (w *PartitionWorker) run(q <-chan *Message) {
+	defer w.wg.Done()
+	for msg := range q {
+		if w.process(msg) {
+			w.complete(msg.Offset)
+		}
+	}
+}
+
+// process 返回消息是否可以提交；ErrUncommitted 的消息不提交，重启/rebalance 后重新投递
+func (w *PartitionWorker) process(msg *Message) bool {
+	err := w.handle(msg)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, ErrUncommitted) {
+		return false
+	}
+	if w.onError != nil {
+		w.onError(w.ctx, msg, err)
+		return true
+	}
+	logx.WithContext(w.ctx).Errorw("event handler failed",
+		logx.Field("topic", msg.Topic), logx.Field("partition", msg.Partition),
+		logx.Field("offset", msg.Offset), logx.Field("error", err))
+	return true
+}
+
+// handle 把 handler 的 panic 转成 error，避免一条坏消息拖垮整个消费进程
+func (w *PartitionWorker) handle(msg *Message) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panic: %v", p)
+		}
+	}()
+	return w.handler(w.ctx, msg)
+}
+
+// complete 标记完成，并把连续完成的前缀提交出去
+func (w *PartitionWorker) complete(offset int64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, f := range w.pending {
+		if f.offset == offset {
+			f.done = true
+			break
+		}
+	}
+	last := int64(-1)
+	i := 0
+	for ; i < len(w.pending) && w.pending[i].done; i++ {
+		last = w.pending[i].offset
+	}
+	if i == 0 {
+		return
+	}
+	w.pending = w.pending[i:]
+	w.commit(last)
+}
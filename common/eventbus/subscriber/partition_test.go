@@ -0,0 +1,273 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitLog is a fake commit func that records every committed offset
+type commitLog struct {
+	mu      sync.Mutex
+	offsets []int64
+}
+
+func (c *commitLog) commit(offset int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offsets = append(c.offsets, offset)
+}
+
+func (c *commitLog) get() []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]int64(nil), c.offsets...)
+}
+
+// gatedHandler blocks each offset until it is released, so a test decides the completion order
+type gatedHandler struct {
+	gates    map[int64]chan struct{}
+	returned map[int64]chan struct{}
+	result   func(offset int64) error
+
+	mu      sync.Mutex
+	handled map[int64]int
+}
+
+func newGatedHandler(offsets []int64, result func(offset int64) error) *gatedHandler {
+	h := &gatedHandler{
+		gates:    make(map[int64]chan struct{}),
+		returned: make(map[int64]chan struct{}),
+		result:   result,
+		handled:  make(map[int64]int),
+	}
+	for _, o := range offsets {
+		h.gates[o] = make(chan struct{})
+		h.returned[o] = make(chan struct{}, 1)
+	}
+	return h
+}
+
+func (h *gatedHandler) handle(_ context.Context, msg *Message) error {
+	<-h.gates[msg.Offset]
+	defer func() { h.returned[msg.Offset] <- struct{}{} }()
+	h.mu.Lock()
+	h.handled[msg.Offset]++
+	h.mu.Unlock()
+	if h.result != nil {
+		return h.result(msg.Offset)
+	}
+	return nil
+}
+
+// settled reports whether the worker has finished bookkeeping for offset
+func settled(w *PartitionWorker, offset int64) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, f := range w.pending {
+		if f.offset == offset {
+			return f.done
+		}
+	}
+	return true
+}
+
+func offsetsFrom(first int64, n int) []int64 {
+	out := make([]int64, n)
+	for i := range out {
+		out[i] = first + int64(i)
+	}
+	return out
+}
+
+func TestPartitionWorker_CommitsContiguousPrefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		count       int
+		release     []int64
+		uncommitted []int64
+		failed      []int64
+		want        []int64
+	}{
+		{
+			name:    "in order commits every offset",
+			count:   3,
+			release: []int64{10, 11, 12},
+			want:    []int64{10, 11, 12},
+		},
+		{
+			name:    "reverse order commits once the head is done",
+			count:   3,
+			release: []int64{12, 11, 10},
+			want:    []int64{12},
+		},
+		{
+			name:    "a gap holds back later offsets",
+			count:   4,
+			release: []int64{11, 13, 10, 12},
+			want:    []int64{11, 13},
+		},
+		{
+			name:        "uncommitted offset stops the commit",
+			count:       4,
+			release:     []int64{10, 11, 12, 13},
+			uncommitted: []int64{11},
+			want:        []int64{10},
+		},
+		{
+			name:        "uncommitted head commits nothing",
+			count:       3,
+			release:     []int64{12, 11, 10},
+			uncommitted: []int64{10},
+		},
+		{
+			name:    "failed offset goes to onError and is committed",
+			count:   3,
+			release: []int64{10, 11, 12},
+			failed:  []int64{11},
+			want:    []int64{10, 11, 12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offsets := offsetsFrom(10, tt.count)
+			h := newGatedHandler(offsets, func(offset int64) error {
+				switch {
+				case contains(tt.uncommitted, offset):
+					return ErrUncommitted
+				case contains(tt.failed, offset):
+					return errors.New("boom")
+				}
+				return nil
+			})
+			var failedMu sync.Mutex
+			var failed []int64
+			onError := func(_ context.Context, msg *Message, _ error) {
+				failedMu.Lock()
+				failed = append(failed, msg.Offset)
+				failedMu.Unlock()
+			}
+			commits := &commitLog{}
+			// one worker per offset: keyless messages are spread by offset, so no offset waits on another
+			w := NewPartitionWorker(context.Background(), tt.count, h.handle, onError, commits.commit)
+			for _, o := range offsets {
+				w.Submit(&Message{Offset: o})
+			}
+
+			for _, o := range tt.release {
+				close(h.gates[o])
+				<-h.returned[o]
+				if contains(tt.uncommitted, o) {
+					// an uncommitted offset leaves no bookkeeping behind
+					continue
+				}
+				require.Eventually(t, func() bool { return settled(w, o) }, time.Second, time.Millisecond)
+			}
+			w.Close()
+
+			assert.Equal(t, tt.want, commits.get())
+			assert.ElementsMatch(t, tt.failed, failed)
+		})
+	}
+}
+
+func TestPartitionWorker_UncommittedIsRedelivered(t *testing.T) {
+	attempts := map[int64]int{}
+	var mu sync.Mutex
+	handler := func(_ context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[msg.Offset]++
+		if msg.Offset == 1 && attempts[msg.Offset] == 1 {
+			return ErrUncommitted
+		}
+		return nil
+	}
+
+	// first run: offset 1 is left uncommitted, so the consumer resumes from it
+	commits := &commitLog{}
+	w := NewPartitionWorker(context.Background(), 1, handler, nil, commits.commit)
+	for _, o := range offsetsFrom(0, 3) {
+		w.Submit(&Message{Offset: o})
+	}
+	w.Close()
+	require.Equal(t, []int64{0}, commits.get())
+
+	// second run after a restart starts after the last committed offset
+	resume := commits.get()[len(commits.get())-1] + 1
+	commits = &commitLog{}
+	w = NewPartitionWorker(context.Background(), 1, handler, nil, commits.commit)
+	for _, o := range offsetsFrom(resume, 2) {
+		w.Submit(&Message{Offset: o})
+	}
+	w.Close()
+	assert.Equal(t, []int64{1, 2}, commits.get())
+	assert.Equal(t, map[int64]int{0: 1, 1: 2, 2: 2}, attempts)
+}
+
+func TestPartitionWorker_CloseDrainsInFlight(t *testing.T) {
+	offsets := offsetsFrom(0, 6)
+	h := newGatedHandler(offsets, nil)
+	commits := &commitLog{}
+	w := NewPartitionWorker(context.Background(), 2, h.handle, nil, commits.commit)
+	for _, o := range offsets {
+		w.Submit(&Message{Offset: o, Key: []byte{byte('a' + o%2)}})
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		w.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Close returned while messages were still being handled")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	for _, o := range offsets {
+		close(h.gates[o])
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return after the in-flight messages finished")
+	}
+
+	for _, o := range offsets {
+		assert.Equal(t, 1, h.handled[o], "offset %d", o)
+	}
+	got := commits.get()
+	require.NotEmpty(t, got)
+	assert.Equal(t, int64(5), got[len(got)-1])
+}
+
+func TestPartitionWorker_PanicIsReported(t *testing.T) {
+	var reported error
+	commits := &commitLog{}
+	w := NewPartitionWorker(context.Background(), 1, func(context.Context, *Message) error {
+		panic("bad message")
+	}, func(_ context.Context, _ *Message, err error) {
+		reported = err
+	}, commits.commit)
+	w.Submit(&Message{Offset: 7})
+	w.Close()
+
+	require.Error(t, reported)
+	assert.Contains(t, reported.Error(), "bad message")
+	assert.Equal(t, []int64{7}, commits.get())
+}
+
+func contains(offsets []int64, o int64) bool {
+	for _, v := range offsets {
+		if v == o {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package redisstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	red "github.com/redis/go-redis/v9"
+	pubstream "github.com/uwu-octane/antBackend/common/eventbus/publisher/redisstream"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+const (
+	defaultBatchSize       = 64
+	defaultBlock           = 2 * time.Second
+	defaultClaimMinIdle    = 5 * time.Minute
+	defaultReclaimInterval = time.Minute
+	readErrorBackoff       = time.Second
+)
+
+type Options struct {
+	// KeyPrefix 与 publisher/redisstream.Options.KeyPrefix 一致，默认 eventbus:
+	KeyPrefix string
+	Group     string
+	// Consumer 消费组内的成员名，默认 hostname-pid；重启后沿用同名可以接管自己未 ack 的消息
+	Consumer string
+	Topics   []string
+	// Concurrency 每个 stream 内的并发数，相同 key 串行
+	Concurrency int
+	// StartID 新建消费组时的起始位置："$"（默认）只消费之后写入的消息，"0" 从头消费
+	StartID   string
+	BatchSize int64
+	Block     time.Duration
+	// ClaimMinIdle 其他成员读取后超过该时间仍未 ack 的消息会被 XAUTOCLAIM 接管（成员崩溃或改名后不再回来），
+	// 需大于 handler 最长处理时间，否则会接管仍在处理中的消息造成重复；默认 5 分钟
+	ClaimMinIdle time.Duration
+	// ReclaimInterval 启动后每隔多久执行一次接管，默认 1 分钟
+	ReclaimInterval time.Duration
+}
+
+// Subscriber 基于 Redis Streams 消费组（XREADGROUP / XACK）的订阅者，与 kafka 订阅者语义一致：
+// 每个 stream 对应一个 PartitionWorker，仅 ack 连续完成的前缀；启动时先重放本成员未 ack 的消息，
+// 之后定期接管其他成员闲置超过 ClaimMinIdle 的消息。
+//
+// 同一 key 的顺序只在单个成员内成立：stream 没有按 key 分区，同组多副本时各成员读到的消息交错，
+// 被接管的消息也会晚于后续消息处理。需要跨副本的 key 内顺序时只运行一个成员，或改用 kafka。
+type Subscriber struct {
+	rds     *redis.Redis
+	opts    Options
+	streams []string
+	handler subscriber.Handler
+	onError subscriber.ErrorHandler
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ subscriber.Subscriber = (*Subscriber)(nil)
+
+// NewSubscriber 创建（或复用）各 stream 上的消费组，之后写入的消息都会被该组收到
+func NewSubscriber(rds *redis.Redis, opts *Options, handler subscriber.Handler, onError subscriber.ErrorHandler) (*Subscriber, error) {
+	if rds == nil || opts == nil || opts.Group == "" || len(opts.Topics) == 0 {
+		return nil, errors.New("redis stream subscriber: redis, group and topics are required")
+	}
+	if handler == nil {
+		return nil, errors.New("redis stream subscriber: handler is nil")
+	}
+	o := *opts
+	if o.Consumer == "" {
+		host, _ := os.Hostname()
+		o.Consumer = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	if o.StartID == "" {
+		o.StartID = "$"
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultBatchSize
+	}
+	if o.Block <= 0 {
+		o.Block = defaultBlock
+	}
+	if o.ClaimMinIdle <= 0 {
+		o.ClaimMinIdle = defaultClaimMinIdle
+	}
+	if o.ReclaimInterval <= 0 {
+		o.ReclaimInterval = defaultReclaimInterval
+	}
+
+	streams := make([]string, len(o.Topics))
+	for i, t := range o.Topics {
+		streams[i] = pubstream.StreamKey(o.KeyPrefix, t)
+		if _, err := rds.XGroupCreateMkStream(streams[i], o.Group, o.StartID); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return nil, fmt.Errorf("create consumer group on %s: %w", streams[i], err)
+		}
+	}
+	logx.Infow("create redis stream consumer group",
+		logx.Field("group", o.Group), logx.Field("consumer", o.Consumer), logx.Field("streams", streams))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Subscriber{
+		rds:     rds,
+		opts:    o,
+		streams: streams,
+		handler: handler,
+		onError: onError,
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// Start 阻塞消费直到 Stop
+func (s *Subscriber) Start() {
+	defer close(s.done)
+	node, err := redis.CreateBlockingNode(s.rds)
+	if err != nil {
+		logx.Errorw("create redis blocking node failed", logx.Field("error", err))
+		return
+	}
+	defer node.Close()
+
+	// handler 不随 Stop 取消，保证在途消息处理完
+	hctx := subscriber.WithStopping(context.Background(), s.ctx.Done())
+	parts := make(map[string]*partition, len(s.streams))
+	for i, stream := range s.streams {
+		parts[stream] = s.newPartition(hctx, s.opts.Topics[i], stream)
+	}
+	defer func() {
+		for _, p := range parts {
+			p.worker.Close()
+		}
+	}()
+
+	// 先重放本成员已读取但未 ack 的消息（上次退出时在途或 ErrUncommitted 的消息）
+	for _, stream := range s.streams {
+		cursor := "0"
+		for s.ctx.Err() == nil {
+			res, err := s.rds.XReadGroupCtx(s.ctx, node, s.opts.Group, s.opts.Consumer, s.opts.BatchSize, -1, false, stream, cursor)
+			if err != nil {
+				if !errors.Is(err, red.Nil) && s.ctx.Err() == nil {
+					logx.Errorw("read pending stream entries failed", logx.Field("stream", stream), logx.Field("error", err))
+				}
+				break
+			}
+			if len(res) == 0 || len(res[0].Messages) == 0 {
+				break
+			}
+			msgs := res[0].Messages
+			parts[stream].submit(msgs)
+			cursor = msgs[len(msgs)-1].ID
+		}
+	}
+	s.reclaim(node, parts)
+	lastReclaim := time.Now()
+
+	args := make([]string, 0, 2*len(s.streams))
+	args = append(args, s.streams...)
+	for range s.streams {
+		args = append(args, ">")
+	}
+	for s.ctx.Err() == nil {
+		// 与读取在同一循环中执行：阻塞节点只有一个连接
+		if time.Since(lastReclaim) >= s.opts.ReclaimInterval {
+			s.reclaim(node, parts)
+			lastReclaim = time.Now()
+		}
+		res, err := s.rds.XReadGroupCtx(s.ctx, node, s.opts.Group, s.opts.Consumer, s.opts.BatchSize, s.opts.Block, false, args...)
+		if err != nil {
+			if errors.Is(err, red.Nil) || s.ctx.Err() != nil {
+				continue
+			}
+			logx.Errorw("read redis streams failed", logx.Field("error", err))
+			select {
+			case <-s.ctx.Done():
+			case <-time.After(readErrorBackoff):
+			}
+			continue
+		}
+		for _, xs := range res {
+			if p, ok := parts[xs.Stream]; ok {
+				p.submit(xs.Messages)
+			}
+		}
+	}
+}
+
+// reclaim 用 XAUTOCLAIM 把组内闲置超过 ClaimMinIdle 的消息转到本成员并交给对应 partition
+func (s *Subscriber) reclaim(node redis.RedisNode, parts map[string]*partition) {
+	for _, stream := range s.streams {
+		start := "0-0"
+		for s.ctx.Err() == nil {
+			msgs, next, err := node.XAutoClaim(s.ctx, &red.XAutoClaimArgs{
+				Stream:   stream,
+				Group:    s.opts.Group,
+				Consumer: s.opts.Consumer,
+				MinIdle:  s.opts.ClaimMinIdle,
+				Start:    start,
+				Count:    s.opts.BatchSize,
+			}).Result()
+			if err != nil {
+				if s.ctx.Err() == nil {
+					logx.Errorw("claim idle stream entries failed", logx.Field("stream", stream), logx.Field("error", err))
+				}
+				break
+			}
+			if claimed := parts[stream].untracked(msgs); len(claimed) > 0 {
+				logx.Infow("claimed idle stream entries",
+					logx.Field("stream", stream), logx.Field("group", s.opts.Group), logx.Field("count", len(claimed)))
+				parts[stream].submit(claimed)
+			}
+			if next == "0-0" {
+				break
+			}
+			start = next
+		}
+	}
+}
+
+// Stop 停止读取、等待在途消息处理完成并 ack
+func (s *Subscriber) Stop() {
+	s.cancel()
+	<-s.done
+}
+
+// partition 把一个 stream 的消息按读取顺序编号交给 PartitionWorker，commit 时 ack 编号不超过 offset 的条目
+type partition struct {
+	s      *Subscriber
+	topic  string
+	stream string
+	worker *subscriber.PartitionWorker
+
+	mu   sync.Mutex
+	seq  int64
+	ids  []string
+	base int64 // ids[0] 的编号
+}
+
+func (s *Subscriber) newPartition(ctx context.Context, topic, stream string) *partition {
+	p := &partition{s: s, topic: topic, stream: stream}
+	p.worker = subscriber.NewPartitionWorker(ctx, s.opts.Concurrency, s.handler, s.onError, p.ack)
+	return p
+}
+
+func (p *partition) submit(msgs []red.XMessage) {
+	for _, xm := range msgs {
+		p.mu.Lock()
+		offset := p.seq
+		p.seq++
+		p.ids = append(p.ids, xm.ID)
+		p.mu.Unlock()
+		p.worker.Submit(p.message(offset, xm))
+	}
+}
+
+// untracked 去掉本 partition 已在处理的条目（本成员自己闲置的消息也会被 XAUTOCLAIM 返回）与已删除的条目
+func (p *partition) untracked(msgs []red.XMessage) []red.XMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	tracked := make(map[string]bool, len(p.ids))
+	for _, id := range p.ids {
+		tracked[id] = true
+	}
+	out := msgs[:0]
+	for _, xm := range msgs {
+		if !tracked[xm.ID] && len(xm.Values) > 0 {
+			out = append(out, xm)
+		}
+	}
+	return out
+}
+
+func (p *partition) message(offset int64, xm red.XMessage) *subscriber.Message {
+	msg := &subscriber.Message{Topic: p.topic, Offset: offset, Headers: make(map[string]string)}
+	for k, v := range xm.Values {
+		str, _ := v.(string)
+		switch {
+		case k == pubstream.FieldValue:
+			msg.Value = []byte(str)
+		case k == pubstream.FieldKey:
+			msg.Key = []byte(str)
+		case strings.HasPrefix(k, pubstream.FieldHeaderPrefix):
+			msg.Headers[strings.TrimPrefix(k, pubstream.FieldHeaderPrefix)] = str
+		}
+	}
+	// entry ID 形如 <毫秒时间戳>-<序号>
+	if ms, _, ok := strings.Cut(xm.ID, "-"); ok {
+		if n, err := strconv.ParseInt(ms, 10, 64); err == nil {
+			msg.Timestamp = time.UnixMilli(n)
+		}
+	}
+	return msg
+}
+
+func (p *partition) ack(offset int64) {
+	p.mu.Lock()
+	n := int(offset - p.base + 1)
+	if n <= 0 || n > len(p.ids) {
+		p.mu.Unlock()
+		return
+	}
+	ids := p.ids[:n]
+	p.ids = p.ids[n:]
+	p.base = offset + 1
+	p.mu.Unlock()
+
+	// Stop 之后仍要 ack 已完成的消息，不使用 s.ctx
+	if _, err := p.s.rds.XAckCtx(context.Background(), p.stream, p.s.opts.Group, ids...); err != nil {
+		logx.Errorw("ack redis stream entries failed", logx.Field("stream", p.stream), logx.Field("error", err))
+	}
+}
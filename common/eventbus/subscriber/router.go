@@ -0,0 +1,71 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Router 按 EventType 把消息分发到强类型 handler
+type Router struct {
+	handlers map[string]Handler
+	// Fallback 处理未注册的事件类型，为空时记录日志并跳过
+	Fallback Handler
+}
+
+func NewRouter() *Router {
+	return &Router{handlers: make(map[string]Handler)}
+}
+
+// Handle 注册 eventType 的处理函数，payload 按消息的 content-type 通过 codec.Unmarshal[T] 解码。
+// 同一 eventType 重复注册会 panic，避免静默覆盖。
+func Handle[T any](r *Router, eventType string, fn func(ctx context.Context, env *event.Envelope[T], msg *Message) error) {
+	if _, ok := r.handlers[eventType]; ok {
+		panic(fmt.Sprintf("subscriber: handler for %s already registered", eventType))
+	}
+	r.handlers[eventType] = func(ctx context.Context, msg *Message) error {
+		c, err := msg.Codec()
+		if err != nil {
+			return err
+		}
+		var env event.Envelope[T]
+		if err := codec.Unmarshal(c, msg.Value, &env); err != nil {
+			return fmt.Errorf("decode %s: %w", eventType, err)
+		}
+		return fn(ctx, &env, msg)
+	}
+}
+
+// EventTypes 返回已注册的事件类型
+func (r *Router) EventTypes() []string {
+	types := make([]string, 0, len(r.handlers))
+	for t := range r.handlers {
+		types = append(types, t)
+	}
+	return types
+}
+
+// Dispatch 实现 Handler
+func (r *Router) Dispatch(ctx context.Context, msg *Message) error {
+	head, err := msg.Head()
+	if err != nil {
+		return fmt.Errorf("peek event_type: %w", err)
+	}
+	eventType := head.EventType
+	if eventType == "" {
+		return errors.New("peek event_type: event_type is empty")
+	}
+	if h, ok := r.handlers[eventType]; ok {
+		return h(ctx, msg)
+	}
+	if r.Fallback != nil {
+		return r.Fallback(ctx, msg)
+	}
+	logx.WithContext(ctx).Debugw("no handler for event type, skip",
+		logx.Field("event_type", eventType), logx.Field("topic", msg.Topic), logx.Field("offset", msg.Offset))
+	return nil
+}
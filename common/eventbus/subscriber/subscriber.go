@@ -0,0 +1,63 @@
+package subscriber
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+)
+
+// Message 是从 broker 收到的一条原始消息
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+// Codec 按 content-type header 返回消息的编码，缺省为 JSON
+func (m *Message) Codec() (codec.Codec, error) {
+	return codec.FromHeaders(m.Headers)
+}
+
+// Head 按消息的编码解析 Envelope 元数据
+func (m *Message) Head() (*codec.EnvelopeHead, error) {
+	c, err := m.Codec()
+	if err != nil {
+		return nil, err
+	}
+	return c.PeekHead(m.Value)
+}
+
+// Handler 处理一条消息；返回 error 表示处理失败，由 ErrorHandler 决定后续动作
+type Handler func(ctx context.Context, msg *Message) error
+
+// ErrorHandler 在 Handler 失败后调用，消息随后仍会被提交（重试/死信由上层策略实现）
+type ErrorHandler func(ctx context.Context, msg *Message, err error)
+
+// ErrUncommitted 由 handler 返回时消息不提交位点（例如停机时仍在等待重试延迟），之后会被重新投递
+var ErrUncommitted = errors.New("subscriber: message left uncommitted")
+
+type stoppingKey struct{}
+
+// WithStopping 把订阅者的停止信号放进 handler 的 ctx。
+// handler 的 ctx 本身不随停止取消（保证在途消息处理完），需要长时间等待的 handler 应同时监听 Stopping。
+func WithStopping(ctx context.Context, stopping <-chan struct{}) context.Context {
+	return context.WithValue(ctx, stoppingKey{}, stopping)
+}
+
+// Stopping 返回订阅者的停止信号，未设置时返回 nil（永远不会就绪）
+func Stopping(ctx context.Context) <-chan struct{} {
+	ch, _ := ctx.Value(stoppingKey{}).(<-chan struct{})
+	return ch
+}
+
+// Subscriber 定义对业务暴露的统一订阅接口，Start 阻塞直到 Stop（实现 service.Service）
+type Subscriber interface {
+	Start()
+	Stop()
+}
@@ -0,0 +1,83 @@
+// Package tracing 在事件 header 中传递 W3C trace context（traceparent / tracestate）与请求 ID：
+// 发布侧由 EventBusPublisher 自动注入，消费侧用 Middleware 以 header 中的 span 为父 span 开始消费 span。
+package tracing
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const (
+	HeaderTraceparent = "traceparent"
+	HeaderTracestate  = "tracestate"
+	HeaderRequestID   = requestid.Key
+
+	tracerName = "github.com/uwu-octane/antBackend/common/eventbus"
+)
+
+// 固定使用 W3C Trace Context，不依赖全局 propagator 是否已由 trace agent 设置
+var propagator = propagation.TraceContext{}
+
+// Inject 把 ctx 中的 span context 与请求 ID 写入 headers，已存在的键不覆盖（例如 outbox 中落库时记录的值）
+func Inject(ctx context.Context, headers map[string]string) {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if id := requestid.FromContext(ctx); id != "" {
+		carrier[HeaderRequestID] = id
+	}
+	for k, v := range carrier {
+		if _, ok := headers[k]; !ok {
+			headers[k] = v
+		}
+	}
+}
+
+// Headers 返回 ctx 对应的 trace / 请求 ID headers，没有时返回 nil
+func Headers(ctx context.Context) map[string]string {
+	h := make(map[string]string)
+	Inject(ctx, h)
+	if len(h) == 0 {
+		return nil
+	}
+	return h
+}
+
+// Extract 返回带有 headers 中远端 span context 与请求 ID 的 ctx
+func Extract(ctx context.Context, headers map[string]string) context.Context {
+	ctx = propagator.Extract(ctx, propagation.MapCarrier(headers))
+	return requestid.NewContext(ctx, headers[HeaderRequestID])
+}
+
+// Middleware 为每条消息开始一个 consumer span（父 span 来自消息 headers），handler 返回错误时记录到 span
+func Middleware(next subscriber.Handler) subscriber.Handler {
+	tracer := otel.Tracer(tracerName)
+	return func(ctx context.Context, msg *subscriber.Message) error {
+		ctx = Extract(ctx, msg.Headers)
+		attrs := []attribute.KeyValue{
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+		}
+		if head, err := msg.Head(); err == nil {
+			attrs = append(attrs,
+				attribute.String("messaging.message.id", head.EventID),
+				attribute.String("event.type", head.EventType))
+		}
+		ctx, span := tracer.Start(ctx, msg.Topic+" process",
+			oteltrace.WithSpanKind(oteltrace.SpanKindConsumer), oteltrace.WithAttributes(attrs...))
+		defer span.End()
+
+		err := next(ctx, msg)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
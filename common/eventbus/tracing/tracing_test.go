@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func TestInjectExtract(t *testing.T) {
+	rec := newRecorder(t)
+	ctx, span := otel.Tracer("test").Start(context.Background(), "request")
+	defer span.End()
+	ctx = requestid.NewContext(ctx, "req-1")
+
+	headers := Headers(ctx)
+	require.NotNil(t, headers)
+	assert.Contains(t, headers[HeaderTraceparent], span.SpanContext().TraceID().String())
+	assert.Equal(t, "req-1", headers[HeaderRequestID])
+
+	out := Extract(context.Background(), headers)
+	assert.Equal(t, span.SpanContext().TraceID(), oteltrace.SpanContextFromContext(out).TraceID())
+	assert.True(t, oteltrace.SpanContextFromContext(out).IsRemote())
+	assert.Equal(t, "req-1", requestid.FromContext(out))
+	assert.Empty(t, rec.Ended())
+}
+
+func TestInject_KeepsExisting(t *testing.T) {
+	newRecorder(t)
+	ctx, span := otel.Tracer("test").Start(context.Background(), "relay")
+	defer span.End()
+
+	// outbox 中落库的 headers 优先于中继自身的 ctx
+	headers := map[string]string{HeaderTraceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}
+	Inject(requestid.NewContext(ctx, "req-2"), headers)
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", headers[HeaderTraceparent])
+	assert.Equal(t, "req-2", headers[HeaderRequestID])
+}
+
+func TestHeaders_Empty(t *testing.T) {
+	assert.Nil(t, Headers(context.Background()))
+}
+
+func TestMiddleware_ChildSpan(t *testing.T) {
+	rec := newRecorder(t)
+	const traceparent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	msg := &subscriber.Message{
+		Topic:   "dev.user.service.user-events",
+		Offset:  7,
+		Headers: map[string]string{HeaderTraceparent: traceparent, HeaderRequestID: "req-3"},
+		Value:   []byte(`{"event_type":"user.deleted","event_version":1,"event_id":"evt-1"}`),
+	}
+
+	var gotRequestID string
+	var gotTraceID oteltrace.TraceID
+	handler := Middleware(func(ctx context.Context, msg *subscriber.Message) error {
+		gotRequestID = requestid.FromContext(ctx)
+		gotTraceID = oteltrace.SpanContextFromContext(ctx).TraceID()
+		return errors.New("boom")
+	})
+	require.Error(t, handler(context.Background(), msg))
+
+	assert.Equal(t, "req-3", gotRequestID)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", gotTraceID.String())
+
+	spans := rec.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, oteltrace.SpanKindConsumer, spans[0].SpanKind())
+	assert.Equal(t, "b7ad6b7169203331", spans[0].Parent().SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
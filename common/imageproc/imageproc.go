@@ -0,0 +1,131 @@
+// Package imageproc 校验并处理用户上传的图片：按 EXIF 方向摆正、居中裁成正方形、缩放到若干固定边长，
+// 重新编码为 JPEG。重新编码只写像素数据，EXIF（GPS、设备信息等）等元数据不会保留
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // 注册解码器
+	"image/jpeg"
+	_ "image/png" // 注册解码器
+)
+
+const ContentType = "image/jpeg"
+
+var (
+	ErrInvalidImage      = errors.New("imageproc: invalid image")
+	ErrUnsupportedFormat = errors.New("imageproc: unsupported image format")
+	// ErrTooLarge 像素数超过 Options.MaxPixels，在解码前检查，避免解压炸弹占满内存
+	ErrTooLarge = errors.New("imageproc: image has too many pixels")
+)
+
+// Formats 允许的输入格式（image.DecodeConfig 返回的格式名）
+var Formats = map[string]bool{"jpeg": true, "png": true, "gif": true}
+
+type Options struct {
+	MaxPixels int   // 宽 × 高上限
+	Sizes     []int // 输出的正方形边长
+	Quality   int   // JPEG 质量 1-100
+}
+
+// Variant 一种边长的输出
+type Variant struct {
+	Size int
+	Data []byte
+}
+
+// Square 解码 data 并按 opts.Sizes 输出正方形 JPEG，顺序与 Sizes 相同；透明区域铺白底
+func Square(data []byte, opts Options) ([]Variant, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, ErrInvalidImage
+	}
+	if !Formats[format] {
+		return nil, ErrUnsupportedFormat
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > opts.MaxPixels {
+		return nil, ErrTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	img := flatten(src)
+	if format == "jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+	img = cropSquare(img)
+
+	out := make([]Variant, 0, len(opts.Sizes))
+	for _, size := range opts.Sizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resize(img, size), &jpeg.Options{Quality: opts.Quality}); err != nil {
+			return nil, err
+		}
+		out = append(out, Variant{Size: size, Data: buf.Bytes()})
+	}
+	return out, nil
+}
+
+// flatten 把任意图片画到白底的 RGBA 上，之后的像素操作只处理这一种格式
+func flatten(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Over)
+	return dst
+}
+
+func cropSquare(img *image.RGBA) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	side := min(w, h)
+	x0, y0 := (w-side)/2, (h-side)/2
+	return img.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.RGBA)
+}
+
+// resize 缩放正方形 img 到 size×size：每个目标像素取其覆盖的源像素块的平均值（box filter），
+// 放大时块退化为单个像素
+func resize(img *image.RGBA, size int) *image.RGBA {
+	b := img.Bounds()
+	side := b.Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	span := func(i int) (int, int) {
+		lo := i * side / size
+		hi := max((i+1)*side/size, lo+1)
+		return lo, hi
+	}
+	for y := 0; y < size; y++ {
+		y0, y1 := span(y)
+		for x := 0; x < size; x++ {
+			x0, x1 := span(x)
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				off := img.PixOffset(b.Min.X+x0, b.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(img.Pix[off])
+					g += uint32(img.Pix[off+1])
+					bl += uint32(img.Pix[off+2])
+					a += uint32(img.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+			d := dst.PixOffset(x, y)
+			dst.Pix[d] = uint8(r / n)
+			dst.Pix[d+1] = uint8(g / n)
+			dst.Pix[d+2] = uint8(bl / n)
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,121 @@
+package imageproc
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOpts = Options{MaxPixels: 10000, Sizes: []int{8, 32}, Quality: 90}
+
+// halves is a w×h image, red on the left half and blue on the right half
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}))
+	return buf.Bytes()
+}
+
+// withOrientation inserts a big-endian EXIF APP1 segment holding only the Orientation tag after SOI
+func withOrientation(data []byte, o uint16) []byte {
+	app1 := []byte{
+		0xFF, 0xE1, 0x00, 0x22, 'E', 'x', 'i', 'f', 0, 0,
+		'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08,
+		0x00, 0x01, // one entry
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, byte(o >> 8), byte(o), 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, // no next IFD
+	}
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xc000 && g < 0x4000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return b > 0xc000 && r < 0x4000 && g < 0x4000
+}
+
+func decode(t *testing.T, v Variant) image.Image {
+	img, format, err := image.Decode(bytes.NewReader(v.Data))
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, image.Rect(0, 0, v.Size, v.Size), img.Bounds())
+	return img
+}
+
+func TestSquare_CropsAndResizes(t *testing.T) {
+	out, err := Square(encodeJPEG(t, halves(80, 40)), testOpts)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	assert.Equal(t, 8, out[0].Size)
+
+	// the center square keeps the left (red) and right (blue) halves side by side
+	img := decode(t, out[1])
+	assert.True(t, isRed(img.At(4, 16)))
+	assert.True(t, isBlue(img.At(28, 16)))
+}
+
+func TestSquare_AppliesAndStripsExif(t *testing.T) {
+	data := withOrientation(encodeJPEG(t, halves(80, 40)), 6)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	out, err := Square(data, testOpts)
+	require.NoError(t, err)
+	// rotated 90° clockwise the left half ends up on top
+	img := decode(t, out[1])
+	assert.True(t, isRed(img.At(16, 4)))
+	assert.True(t, isBlue(img.At(16, 28)))
+
+	for _, v := range out {
+		assert.NotContains(t, string(v.Data), "Exif")
+		assert.Equal(t, 1, jpegOrientation(v.Data))
+	}
+}
+
+func TestSquare_TransparentPNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 20, 20))))
+
+	out, err := Square(buf.Bytes(), testOpts)
+	require.NoError(t, err)
+	r, g, b, _ := decode(t, out[0]).At(4, 4).RGBA()
+	assert.True(t, r > 0xf000 && g > 0xf000 && b > 0xf000, "transparent pixels become white")
+}
+
+func TestSquare_Rejects(t *testing.T) {
+	_, err := Square([]byte("GIF89a"), testOpts)
+	assert.ErrorIs(t, err, ErrInvalidImage)
+	_, err = Square([]byte("<svg xmlns='http://www.w3.org/2000/svg'/>"), testOpts)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = Square(encodeJPEG(t, halves(200, 100)), testOpts)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	// a truncated body passes DecodeConfig but fails to decode
+	data := encodeJPEG(t, halves(80, 40))
+	_, err = Square(data[:len(data)/2], testOpts)
+	assert.ErrorIs(t, err, ErrInvalidImage)
+}
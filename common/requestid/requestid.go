@@ -0,0 +1,56 @@
+// Package requestid 在 context、HTTP header、gRPC metadata 与事件 header 之间传递网关生成的请求 ID
+package requestid
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// Header 是网关读写的 HTTP header
+	Header = "X-Request-Id"
+	// Key 是 gRPC metadata 与事件 header 中使用的小写键
+	Key = "x-request-id"
+	// LogField 写入日志的字段名
+	LogField = "request_id"
+)
+
+type ctxKey struct{}
+
+// NewContext 把请求 ID 写入 ctx，同时作为 logx 字段附加到之后的日志
+func NewContext(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	ctx = logx.ContextWithFields(ctx, logx.Field(LogField, id))
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext 返回 ctx 中的请求 ID，没有时为空字符串
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// UnaryClientInterceptor 把 ctx 中的请求 ID 写入 outgoing metadata
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if id := FromContext(ctx); id != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, Key, id)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// UnaryServerInterceptor 从 incoming metadata 读取请求 ID 写入 ctx。
+// 各 RPC 服务入口都安装它：网关传入的 X-Request-Id 因此随日志字段（见 NewContext）与发布事件的 header 一起传递。
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(Key); len(ids) > 0 {
+			ctx = NewContext(ctx, ids[0])
+		}
+	}
+	return handler(ctx, req)
+}
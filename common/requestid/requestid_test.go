@@ -0,0 +1,38 @@
+package requestid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestInterceptors(t *testing.T) {
+	ctx := NewContext(context.Background(), "req-1")
+
+	var outgoing metadata.MD
+	err := UnaryClientInterceptor(ctx, "/svc/Method", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			outgoing, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, outgoing.Get(Key))
+
+	// 服务端收到的 metadata 即客户端发出的 metadata
+	incoming := metadata.NewIncomingContext(context.Background(), outgoing)
+	_, err = UnaryServerInterceptor(incoming, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+		assert.Equal(t, "req-1", FromContext(ctx))
+		return nil, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestNewContext_Empty(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, NewContext(ctx, ""))
+	assert.Empty(t, FromContext(ctx))
+}
@@ -43,6 +43,7 @@ func BuildGatewayServer(configFile string) (service.Service, func(), error) {
 			http.NotFound(w, r)
 		})),
 		rest.WithFileServer("/schema", http.Dir("./docs/openapi")),
+		handler.LocalBlobServer(c.Avatar.Store),
 	)
 	cors := middleware.NewCors(
 		middleware.WithAllowCredentials(), // ① 允许带 Cookie
@@ -6,6 +6,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
 
 	"github.com/uwu-octane/antBackend/common/envloader"
 	"github.com/uwu-octane/antBackend/gateway/internal/config"
@@ -27,7 +28,12 @@ func main() {
 	conf.MustLoad(*configFile, &c, conf.UseEnv())
 
 	server := rest.MustNewServer(c.RestConf,
-		rest.WithCors(),
+		rest.WithCustomCors(func(header http.Header) {
+			// 乐观锁：允许前端带 If-Match / If-None-Match，并读取 ETag
+			header.Add("Access-Control-Allow-Headers", "If-Match, If-None-Match")
+			header.Add("Access-Control-Expose-Headers", "ETag")
+		}, nil),
+		handler.LocalBlobServer(c.Avatar.Store),
 	)
 	defer server.Stop()
 
@@ -9,6 +9,7 @@ require (
 	github.com/uwu-octane/antBackend/api v0.0.0
 	github.com/uwu-octane/antBackend/auth v0.0.0
 	github.com/uwu-octane/antBackend/common v0.0.0
+	github.com/uwu-octane/antBackend/order v0.0.0
 	github.com/uwu-octane/antBackend/user v0.0.0
 	github.com/zeromicro/go-zero v1.9.1
 	github.com/zeromicro/zero-contrib/zrpc/registry/consul v0.0.0-20250809040225-5c1d3d09e28c
@@ -19,6 +20,7 @@ replace (
 	github.com/uwu-octane/antBackend/api => ../api
 	github.com/uwu-octane/antBackend/auth => ../auth
 	github.com/uwu-octane/antBackend/common => ../common
+	github.com/uwu-octane/antBackend/order => ../order
 	github.com/uwu-octane/antBackend/user => ../user
 )
 
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/uwu-octane/antBackend/gateway/internal/config"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Entry is one audited request made with an impersonation token
+type Entry struct {
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"request_id,omitempty"`
+	Actor     string    `json:"actor"`
+	Subject   string    `json:"subject"`
+	Jti       string    `json:"jti"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	RemoteIP  string    `json:"remote_ip,omitempty"`
+}
+
+type Logger interface {
+	Record(ctx context.Context, e Entry)
+}
+
+// New returns a file logger when Audit.File is set, otherwise entries go to logx
+func New(c config.AuditConfig) (Logger, error) {
+	if c.File == "" {
+		return logxLogger{}, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(c.File), 0o755); err != nil {
+		return nil, fmt.Errorf("audit: create dir: %w", err)
+	}
+	f, err := os.OpenFile(c.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("audit: open file: %w", err)
+	}
+	return &fileLogger{f: f}, nil
+}
+
+type logxLogger struct{}
+
+func (logxLogger) Record(ctx context.Context, e Entry) {
+	logx.WithContext(ctx).Infow("audit",
+		logx.Field("request_id", e.RequestID),
+		logx.Field("actor", e.Actor),
+		logx.Field("subject", e.Subject),
+		logx.Field("jti", e.Jti),
+		logx.Field("method", e.Method),
+		logx.Field("path", e.Path),
+		logx.Field("status", e.Status),
+		logx.Field("remote_ip", e.RemoteIP),
+	)
+}
+
+// fileLogger appends one JSON line per entry
+type fileLogger struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (l *fileLogger) Record(ctx context.Context, e Entry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		logx.WithContext(ctx).Errorf("audit: marshal entry failed: %v", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.f.Write(append(b, '\n')); err != nil {
+		// never drop an audit entry silently
+		logx.WithContext(ctx).Errorf("audit: write failed: %v, entry=%s", err, b)
+	}
+}
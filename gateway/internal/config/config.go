@@ -6,6 +6,7 @@ package config
 import (
 	"time"
 
+	"github.com/uwu-octane/antBackend/common/blobstore"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -16,11 +17,16 @@ type Config struct {
 	GatewayMode string             `json:"GatewayMode"`
 	AuthRpc     zrpc.RpcClientConf `json:"AuthRpc"`
 	UserRpc     zrpc.RpcClientConf `json:"UserRpc"`
+	OrderRpc    zrpc.RpcClientConf `json:"OrderRpc"`
 	Auth        AuthConfig         `json:"Auth"`
 	Upstreams   []UpstreamConfig   `json:"Upstreams"`
 	Consul      ConsulConf         `json:"Consul"`
 	//JwtAuth   JwtAuthConfig      `json:"JwtAuth"`
 	RateLimit RateLimitConfig `json:"RateLimit"`
+	Audit     AuditConfig     `json:",optional"`
+	// AdminRole is the auth role allowed on the AdminOnly routes
+	AdminRole string       `json:",default=admin"`
+	Avatar    AvatarConfig `json:"Avatar"`
 
 	Cors               []string `json:"Cors"`
 	ApiPrefix          []string `json:"ApiPrefix"`
@@ -37,6 +43,19 @@ type AuthConfig struct {
 	IgnoreRoutes  []string
 }
 
+// AuditConfig controls where requests made with impersonation tokens are recorded
+type AuditConfig struct {
+	File string `json:",optional"` // JSON lines, empty means the regular logx output
+}
+
+// AvatarConfig limits avatar uploads and selects where the resized images are stored
+type AvatarConfig struct {
+	MaxBytes  int64 `json:",default=5242880"`  // file size limit, the route's maxBytes in gateway.api must allow it plus 4 KiB
+	MaxPixels int   `json:",default=25000000"` // width x height, checked before decoding
+	Quality   int   `json:",default=85"`       // JPEG quality of the resized images
+	Store     blobstore.Conf
+}
+
 // type JwtAuthConfig struct {
 // 	AccessSecret  string
 // 	Issuer        string
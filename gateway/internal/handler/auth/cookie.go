@@ -5,9 +5,36 @@ import (
 	"time"
 
 	"github.com/uwu-octane/antBackend/gateway/internal/handler/constvar"
+	"github.com/uwu-octane/antBackend/gateway/internal/response"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
 	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+// writeSessionCookies reads the refresh token from the auth.rpc response header
+// and sets the sid/refresh cookies of a freshly created session.
+func writeSessionCookies(w http.ResponseWriter, svcCtx *svc.ServiceContext, sid string, header metadata.MD) bool {
+	var refresh string
+	if header != nil {
+		vals := header.Get(constvar.HeaderRefreshToken)
+		if len(vals) > 0 {
+			refresh = vals[0]
+		}
+	}
+	if sid == "" || refresh == "" {
+		response.FromError(w, status.Error(codes.Internal, "session id or refresh token is required"))
+		return false
+	}
+	var secure bool
+	if svcCtx.Config.GatewayMode != "DEV" {
+		secure = true
+	}
+	SetAuthCookies(w, sid, refresh, secure)
+	return true
+}
+
 func SetAuthCookies(w http.ResponseWriter, sid string, refresh string, secure bool) {
 	sameSite := http.SameSiteLaxMode
 	if secure {
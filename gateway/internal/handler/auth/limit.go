@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/uwu-octane/antBackend/gateway/internal/response"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/util"
+	"github.com/zeromicro/go-zero/core/limit"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// allowLoginAttempt applies the login rate limit keyed on the normalized identifier,
+// writes the error response and returns false when the caller is over quota.
+func allowLoginAttempt(w http.ResponseWriter, r *http.Request, svcCtx *svc.ServiceContext, identifier string) bool {
+	limiter := svcCtx.LoginLimiter
+	if limiter == nil || !svcCtx.Config.RateLimit.Enable {
+		return true
+	}
+	key := util.MakeLoginLimitKey(svcCtx.Config.RateLimit.By, identifier, util.ClientIP(r))
+	code, err := limiter.TakeCtx(r.Context(), key)
+	if err != nil {
+		logx.WithContext(r.Context()).Errorf("rate limit check failed: %v", err)
+		response.FromError(w, status.Error(codes.Internal, "rate limit check failed"))
+		return false
+	}
+	if code == limit.OverQuota {
+		retryAfter := svcCtx.Config.RateLimit.WindowSeconds
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		response.FromError(w, status.Error(codes.ResourceExhausted, "too many login attempts"))
+		return false
+	}
+	return true
+}
@@ -5,16 +5,12 @@ package auth
 
 import (
 	"net/http"
-	"strconv"
 
-	"github.com/uwu-octane/antBackend/gateway/internal/handler/constvar"
 	"github.com/uwu-octane/antBackend/gateway/internal/logic/auth"
 	"github.com/uwu-octane/antBackend/gateway/internal/response"
 	"github.com/uwu-octane/antBackend/gateway/internal/svc"
 	"github.com/uwu-octane/antBackend/gateway/internal/types"
 	"github.com/uwu-octane/antBackend/gateway/util"
-	"github.com/zeromicro/go-zero/core/limit"
-	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/rest/httpx"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -29,20 +25,8 @@ func LoginHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		}
 
 		// limit login attempts
-		if limiter := svcCtx.LoginLimiter; limiter != nil && svcCtx.Config.RateLimit.Enable {
-			key := util.MakeLoginLimitKey(svcCtx.Config.RateLimit.By, req.Username, util.ClientIP(r))
-			code, err := limiter.TakeCtx(r.Context(), key)
-			if err != nil {
-				logx.WithContext(r.Context()).Errorf("rate limit check failed: %v", err)
-				response.FromError(w, status.Error(codes.Internal, "rate limit check failed"))
-				return
-			}
-			if code == limit.OverQuota {
-				retryAfter := svcCtx.Config.RateLimit.WindowSeconds
-				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
-				response.FromError(w, status.Error(codes.ResourceExhausted, "too many login attempts"))
-				return
-			}
+		if !allowLoginAttempt(w, r, svcCtx, util.LoginIdentifier(req.Identifier, req.Username)) {
+			return
 		}
 		l := auth.NewLoginLogic(r.Context(), svcCtx)
 		resp, header, err := l.Login(&req)
@@ -51,23 +35,9 @@ func LoginHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 			return
 		}
 
-		var refresh string
-		if header != nil {
-			vals := header.Get(constvar.HeaderRefreshToken)
-			if len(vals) > 0 {
-				refresh = vals[0]
-			}
-		}
-		sid := resp.SessionId
-		if sid == "" || refresh == "" {
-			response.FromError(w, status.Error(codes.Internal, "session id or refresh token is required"))
+		if !writeSessionCookies(w, svcCtx, resp.SessionId, header) {
 			return
 		}
-		var secure bool
-		if svcCtx.Config.GatewayMode != "DEV" {
-			secure = true
-		}
-		SetAuthCookies(w, sid, refresh, secure)
 		response.Ok(w, resp)
 	}
 }
@@ -0,0 +1,43 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/uwu-octane/antBackend/gateway/internal/logic/auth"
+	"github.com/uwu-octane/antBackend/gateway/internal/response"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/uwu-octane/antBackend/gateway/util"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func VerifyLoginCodeHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req types.VerifyLoginCodeReq
+		if err := httpx.Parse(r, &req); err != nil {
+			response.FromError(w, status.Error(codes.InvalidArgument, "invalid request body"))
+			return
+		}
+
+		// magic links carry no email, they fall back to the ip bucket
+		if !allowLoginAttempt(w, r, svcCtx, util.LoginIdentifier(req.Email, "")) {
+			return
+		}
+		l := auth.NewVerifyLoginCodeLogic(r.Context(), svcCtx)
+		resp, header, err := l.VerifyLoginCode(&req)
+		if err != nil {
+			response.FromError(w, err)
+			return
+		}
+
+		if !writeSessionCookies(w, svcCtx, resp.SessionId, header) {
+			return
+		}
+		response.Ok(w, resp)
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"io/fs"
+	"net/http"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/common/blobstore"
+	"github.com/zeromicro/go-zero/rest"
+)
+
+// LocalBlobServer serves a local blob store under the path of its BaseURL. Nothing is served
+// for S3 or for a BaseURL with a host, the files are published by another server then.
+func LocalBlobServer(c blobstore.Conf) rest.RunOption {
+	if c.Type != "" && c.Type != blobstore.TypeLocal || !strings.HasPrefix(c.Local.BaseURL, "/") {
+		return func(*rest.Server) {}
+	}
+	return rest.WithFileServer(strings.TrimSuffix(c.Local.BaseURL, "/"), filesOnly{http.Dir(c.Local.Dir)})
+}
+
+// filesOnly hides directories, so the store cannot be listed to enumerate user ids
+type filesOnly struct {
+	fs http.FileSystem
+}
+
+func (f filesOnly) Open(name string) (http.File, error) {
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := file.Stat(); err != nil || info.IsDir() {
+		file.Close()
+		return nil, fs.ErrNotExist
+	}
+	return file, nil
+}
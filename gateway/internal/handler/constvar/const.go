@@ -20,4 +20,5 @@ const (
 	CtxUID      ctxKey = "uid"
 	CtxJTI      ctxKey = "jti"
 	CtxIAT      ctxKey = "iat"
+	CtxActor    ctxKey = "actor" // admin behind an impersonation token
 )
@@ -6,7 +6,9 @@ package handler
 import (
 	"net/http"
 
+	admin "github.com/uwu-octane/antBackend/gateway/internal/handler/admin"
 	auth "github.com/uwu-octane/antBackend/gateway/internal/handler/auth"
+	order "github.com/uwu-octane/antBackend/gateway/internal/handler/order"
 	user "github.com/uwu-octane/antBackend/gateway/internal/handler/user"
 	"github.com/uwu-octane/antBackend/gateway/internal/svc"
 
@@ -16,11 +18,36 @@ import (
 func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 	server.AddRoutes(
 		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/device/approve",
+				Handler: auth.DeviceApproveHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/device/code",
+				Handler: auth.DeviceCodeHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/device/token",
+				Handler: auth.DeviceTokenHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/login",
 				Handler: auth.LoginHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/login/code",
+				Handler: auth.RequestLoginCodeHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/login/code/verify",
+				Handler: auth.VerifyLoginCodeHandler(serverCtx),
+			},
 			{
 				Method:  http.MethodPost,
 				Path:    "/logout",
@@ -57,7 +84,90 @@ func RegisterHandlers(server *rest.Server, serverCtx *svc.ServiceContext) {
 				Path:    "/user/info",
 				Handler: user.GetUserInfoHandler(serverCtx),
 			},
+			{
+				Method:  http.MethodPatch,
+				Path:    "/user/info",
+				Handler: user.UpdateUserInfoHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodDelete,
+				Path:    "/user",
+				Handler: user.DeleteUserHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/users/batch",
+				Handler: user.BatchGetUsersHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/user/avatar",
+				Handler: user.UploadAvatarHandler(serverCtx),
+			},
 		},
 		rest.WithPrefix("/api/v1"),
+		rest.WithMaxBytes(5246976),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/orders",
+				Handler: order.CreateOrderHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/orders",
+				Handler: order.ListOrdersHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodGet,
+				Path:    "/orders/:id",
+				Handler: order.GetOrderHandler(serverCtx),
+			},
+			{
+				Method:  http.MethodPost,
+				Path:    "/orders/:id/cancel",
+				Handler: order.CancelOrderHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		[]rest.Route{
+			{
+				Method:  http.MethodPost,
+				Path:    "/admin/impersonate",
+				Handler: admin.ImpersonateHandler(serverCtx),
+			},
+		},
+		rest.WithPrefix("/api/v1"),
+	)
+
+	server.AddRoutes(
+		rest.WithMiddlewares(
+			[]rest.Middleware{serverCtx.AdminOnly},
+			[]rest.Route{
+				{
+					Method:  http.MethodGet,
+					Path:    "/admin/users",
+					Handler: admin.ListUsersHandler(serverCtx),
+				},
+				{
+					Method:  http.MethodGet,
+					Path:    "/admin/users/search",
+					Handler: admin.SearchUsersHandler(serverCtx),
+				},
+			}...,
+		),
+		rest.WithPrefix("/api/v1"),
 	)
 }
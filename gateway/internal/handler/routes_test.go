@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"math/rand"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/blobstore"
+	"github.com/uwu-octane/antBackend/gateway/internal/config"
+	"github.com/uwu-octane/antBackend/gateway/internal/handler/constvar"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/user/userservice"
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/rest"
+	"google.golang.org/grpc"
+)
+
+const avatarMaxBytes = 5 << 20
+
+// avatarUsers is a UserService stub for the upload flow
+type avatarUsers struct {
+	userservice.UserService
+}
+
+func (avatarUsers) GetUserInfo(context.Context, *userservice.GetUserInfoReq, ...grpc.CallOption) (*userservice.GetUserInfoResp, error) {
+	return &userservice.GetUserInfoResp{}, nil
+}
+
+func (avatarUsers) UpdateUser(_ context.Context, in *userservice.UpdateUserReq, _ ...grpc.CallOption) (*userservice.GetUserInfoResp, error) {
+	return &userservice.GetUserInfoResp{AvatarUrl: in.GetAvatarUrl(), Version: 2}, nil
+}
+
+// startGateway serves the generated routes with the default RestConf, requests are authenticated as "u-1"
+func startGateway(t *testing.T) string {
+	t.Helper()
+	avatars, err := blobstore.NewLocal(blobstore.LocalConf{Dir: t.TempDir(), BaseURL: "/blobs"})
+	require.NoError(t, err)
+	svcCtx := &svc.ServiceContext{
+		Config: config.Config{Avatar: config.AvatarConfig{
+			MaxBytes:  avatarMaxBytes,
+			MaxPixels: 25000000,
+			Quality:   85,
+		}},
+		UserRpc:   avatarUsers{},
+		Avatars:   avatars,
+		AdminOnly: func(next http.HandlerFunc) http.HandlerFunc { return next },
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+
+	var c rest.RestConf
+	require.NoError(t, conf.FillDefault(&c))
+	c.Name, c.Host, c.Port = "gateway-test", "127.0.0.1", port
+	c.Timeout = 30000
+	server := rest.MustNewServer(c)
+	server.Use(func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			next(w, r.WithContext(context.WithValue(r.Context(), constvar.CtxUID, "u-1")))
+		}
+	})
+	RegisterHandlers(server, svcCtx)
+	go server.Start()
+	t.Cleanup(server.Stop)
+
+	addr := fmt.Sprintf("http://127.0.0.1:%d", port)
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return addr
+}
+
+// noisePNG encodes random pixels, which do not compress, so the file is about 4*edge*edge bytes
+func noisePNG(t *testing.T, edge int) []byte {
+	t.Helper()
+	rnd := rand.New(rand.NewSource(int64(edge)))
+	img := image.NewNRGBA(image.Rect(0, 0, edge, edge))
+	for i := range img.Pix {
+		img.Pix[i] = byte(rnd.Intn(256))
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// uploadBody is a multipart body with data in the "file" field
+func uploadBody(t *testing.T, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "avatar.png")
+	require.NoError(t, err)
+	_, err = fw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	return &body, mw.FormDataContentType()
+}
+
+type avatarResp struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	Data struct {
+		AvatarUrl string `json:"avatar_url"`
+		Variants  []struct {
+			Size int `json:"size"`
+		} `json:"variants"`
+		Version int64 `json:"version"`
+	} `json:"data"`
+}
+
+func postAvatar(t *testing.T, addr string, body io.Reader, contentType string, length int64) (*http.Response, avatarResp) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, addr+"/api/v1/user/avatar", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	req.ContentLength = length
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	var out avatarResp
+	raw, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	if len(raw) > 0 {
+		require.NoError(t, json.Unmarshal(raw, &out), string(raw))
+	}
+	return res, out
+}
+
+func TestUploadAvatar_AboveRestMaxBytes(t *testing.T) {
+	addr := startGateway(t)
+	data := noisePNG(t, 900)
+	require.Greater(t, len(data), 3<<20)
+	require.Less(t, len(data), avatarMaxBytes)
+
+	body, contentType := uploadBody(t, data)
+	res, out := postAvatar(t, addr, body, contentType, int64(body.Len()))
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 200, out.Code, out.Msg)
+	assert.Len(t, out.Data.Variants, 4)
+	assert.Contains(t, out.Data.AvatarUrl, "/blobs/avatars/u-1/")
+	assert.EqualValues(t, 2, out.Data.Version)
+}
+
+func TestUploadAvatar_OverLimit(t *testing.T) {
+	addr := startGateway(t)
+	data := noisePNG(t, 1200)
+	require.Greater(t, len(data), avatarMaxBytes)
+
+	t.Run("declared length is rejected by the route", func(t *testing.T) {
+		body, contentType := uploadBody(t, data)
+		res, _ := postAvatar(t, addr, body, contentType, int64(body.Len()))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+
+	t.Run("chunked body is rejected by the handler", func(t *testing.T) {
+		body, contentType := uploadBody(t, data)
+		// -1 sends the body chunked, so only the handler sees its size
+		res, out := postAvatar(t, addr, io.MultiReader(body), contentType, -1)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotEqual(t, 200, out.Code)
+		assert.Equal(t, fmt.Sprintf("file exceeds %d bytes", avatarMaxBytes), out.Msg)
+	})
+}
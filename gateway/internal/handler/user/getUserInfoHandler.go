@@ -9,6 +9,7 @@ import (
 	"github.com/uwu-octane/antBackend/gateway/internal/logic/user"
 	"github.com/uwu-octane/antBackend/gateway/internal/response"
 	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/util"
 )
 
 func GetUserInfoHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
@@ -18,8 +19,14 @@ func GetUserInfoHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
 		resp, err := l.GetUserInfo()
 		if err != nil {
 			response.FromError(w, err)
-		} else {
-			response.Ok(w, resp)
+			return
 		}
+
+		w.Header().Set("ETag", util.VersionETag(resp.Version))
+		if v, ok := util.ParseVersionETag(r.Header.Get("If-None-Match")); ok && v == resp.Version {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		response.Ok(w, resp)
 	}
 }
@@ -0,0 +1,61 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package user
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/uwu-octane/antBackend/gateway/internal/logic/user"
+	"github.com/uwu-octane/antBackend/gateway/internal/response"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// avatarTypes are the sniffed content types accepted before the image is decoded
+var avatarTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true}
+
+func UploadAvatarHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		maxBytes := svcCtx.Config.Avatar.MaxBytes
+		// the multipart envelope adds a few hundred bytes around the file
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes+4096)
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				response.FromError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("file exceeds %d bytes", maxBytes)))
+			} else {
+				response.FromError(w, status.Error(codes.InvalidArgument, "multipart field \"file\" is required"))
+			}
+			return
+		}
+		defer file.Close()
+		data, err := io.ReadAll(io.LimitReader(file, maxBytes+1))
+		if err != nil {
+			response.FromError(w, status.Error(codes.InvalidArgument, "invalid request body"))
+			return
+		}
+		if int64(len(data)) > maxBytes {
+			response.FromError(w, status.Error(codes.InvalidArgument, fmt.Sprintf("file exceeds %d bytes", maxBytes)))
+			return
+		}
+		// the client supplied Content-Type is not trusted, the bytes are
+		if !avatarTypes[http.DetectContentType(data)] {
+			response.FromError(w, status.Error(codes.InvalidArgument, "file must be a JPEG, PNG or GIF image"))
+			return
+		}
+
+		l := user.NewUploadAvatarLogic(r.Context(), svcCtx)
+		resp, err := l.UploadAvatar(data)
+		if err != nil {
+			response.FromError(w, err)
+		} else {
+			response.Ok(w, resp)
+		}
+	}
+}
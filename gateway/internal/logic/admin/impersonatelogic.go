@@ -0,0 +1,59 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package admin
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ImpersonateLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewImpersonateLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ImpersonateLogic {
+	return &ImpersonateLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Impersonate asks auth.rpc for a token of req.UserId, the admin role is checked there
+func (l *ImpersonateLogic) Impersonate(req *types.ImpersonateReq) (resp *types.ImpersonateResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	// an impersonation token must not be used to start another impersonation
+	if _, ok := middleware.ActorFromContext(l.ctx); ok {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+
+	r, err := l.svcCtx.AuthRpc.Impersonate(l.ctx, &authservice.ImpersonateReq{
+		AdminId:      uid,
+		TargetUserId: req.UserId,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.ImpersonateResp{
+		AccessToken: r.GetAccessToken(),
+		ExpiresIn:   r.GetExpiresIn(),
+		TokenType:   r.GetTokenType(),
+		ActorId:     r.GetActorId(),
+		UserId:      r.GetTargetUserId(),
+	}, nil
+}
@@ -0,0 +1,70 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package admin
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/uwu-octane/antBackend/user/userservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListUsersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListUsersLogic {
+	return &ListUsersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// ListUsers runs behind the AdminOnly middleware, the role is checked there
+func (l *ListUsersLogic) ListUsers(req *types.ListUsersReq) (resp *types.ListUsersResp, err error) {
+	r, err := l.svcCtx.UserRpc.ListUsers(l.ctx, &userservice.ListUsersReq{
+		Filter: &userservice.UserFilter{
+			UsernamePrefix: req.UsernamePrefix,
+			EmailPrefix:    req.EmailPrefix,
+			CreatedAfter:   req.CreatedAfter,
+			CreatedBefore:  req.CreatedBefore,
+			Status:         req.Status,
+		},
+		SortBy:    req.SortBy,
+		SortOrder: req.SortOrder,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListUsersResp(r), nil
+}
+
+func toListUsersResp(r *userservice.ListUsersResp) *types.ListUsersResp {
+	resp := &types.ListUsersResp{
+		Users:         make([]types.AdminUserResp, 0, len(r.Users)),
+		NextPageToken: r.NextPageToken,
+	}
+	for _, u := range r.Users {
+		resp.Users = append(resp.Users, types.AdminUserResp{
+			UserId:      u.UserId,
+			Username:    u.Username,
+			Email:       u.Email,
+			DisplayName: u.DisplayName,
+			AvatarUrl:   u.AvatarUrl,
+			Status:      u.Status,
+			Version:     u.Version,
+			CreatedAt:   u.CreatedAt,
+			UpdatedAt:   u.UpdatedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,50 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package admin
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/uwu-octane/antBackend/user/userservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SearchUsersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewSearchUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchUsersLogic {
+	return &SearchUsersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// SearchUsers runs behind the AdminOnly middleware, the role is checked there
+func (l *SearchUsersLogic) SearchUsers(req *types.SearchUsersReq) (resp *types.ListUsersResp, err error) {
+	r, err := l.svcCtx.UserRpc.SearchUsers(l.ctx, &userservice.SearchUsersReq{
+		Query: req.Q,
+		Filter: &userservice.UserFilter{
+			UsernamePrefix: req.UsernamePrefix,
+			EmailPrefix:    req.EmailPrefix,
+			CreatedAfter:   req.CreatedAfter,
+			CreatedBefore:  req.CreatedBefore,
+			Status:         req.Status,
+		},
+		SortBy:    req.SortBy,
+		SortOrder: req.SortOrder,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListUsersResp(r), nil
+}
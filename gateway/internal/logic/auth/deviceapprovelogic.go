@@ -0,0 +1,56 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package auth
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DeviceApproveLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeviceApproveLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeviceApproveLogic {
+	return &DeviceApproveLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeviceApproveLogic) DeviceApprove(req *types.DeviceApproveReq) (resp *types.DeviceApproveResp, err error) {
+	// the approving user always comes from the access token, never from the body
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	// approving would hand the device a real session of the impersonated user
+	if _, ok := middleware.ActorFromContext(l.ctx); ok {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+	r, err := l.svcCtx.AuthRpc.ApproveDevice(l.ctx, &authservice.ApproveDeviceReq{
+		UserCode: req.UserCode,
+		UserId:   uid,
+		Deny:     req.Deny,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.DeviceApproveResp{
+		Ok:       r.GetOk(),
+		ClientId: r.GetClientId(),
+		Scope:    r.GetScope(),
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package auth
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type DeviceCodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeviceCodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeviceCodeLogic {
+	return &DeviceCodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeviceCodeLogic) DeviceCode(req *types.DeviceCodeReq) (resp *types.DeviceCodeResp, err error) {
+	r, err := l.svcCtx.AuthRpc.DeviceAuthorize(l.ctx, &authservice.DeviceAuthorizeReq{
+		ClientId: req.ClientId,
+		Scope:    req.Scope,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.DeviceCodeResp{
+		DeviceCode:              r.GetDeviceCode(),
+		UserCode:                r.GetUserCode(),
+		VerificationUri:         r.GetVerificationUri(),
+		VerificationUriComplete: r.GetVerificationUriComplete(),
+		ExpiresIn:               r.GetExpiresIn(),
+		Interval:                r.GetInterval(),
+	}, nil
+}
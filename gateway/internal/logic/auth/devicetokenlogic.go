@@ -0,0 +1,60 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package auth
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/handler/constvar"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type DeviceTokenLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeviceTokenLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeviceTokenLogic {
+	return &DeviceTokenLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeviceTokenLogic) DeviceToken(req *types.DeviceTokenReq) (resp *types.DeviceTokenResp, err error) {
+	var md metadata.MD
+	r, err := l.svcCtx.AuthRpc.DeviceToken(l.ctx, &authservice.DeviceTokenReq{
+		DeviceCode: req.DeviceCode,
+		ClientId:   req.ClientId,
+	},
+		grpc.Header(&md), // refresh token comes back in the grpc header
+	)
+	if err != nil {
+		return nil, err
+	}
+	var refresh string
+	if vals := md.Get(constvar.HeaderRefreshToken); len(vals) > 0 {
+		refresh = vals[0]
+	}
+	if refresh == "" {
+		return nil, status.Error(codes.Internal, "refresh token is required")
+	}
+	return &types.DeviceTokenResp{
+		AccessToken:  r.GetAccessToken(),
+		RefreshToken: refresh,
+		SessionId:    r.GetSessionId(),
+		ExpiresIn:    r.GetExpiresIn(),
+		TokenType:    r.GetTokenType(),
+	}, nil
+}
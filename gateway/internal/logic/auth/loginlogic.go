@@ -9,6 +9,7 @@ import (
 	"github.com/uwu-octane/antBackend/auth/authservice"
 	"github.com/uwu-octane/antBackend/gateway/internal/svc"
 	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/uwu-octane/antBackend/gateway/util"
 	"github.com/zeromicro/go-zero/core/logx"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -31,8 +32,8 @@ func NewLoginLogic(ctx context.Context, svcCtx *svc.ServiceContext) *LoginLogic
 func (l *LoginLogic) Login(req *types.LoginReq) (resp *types.LoginResp, header metadata.MD, err error) {
 	var md metadata.MD
 	r, err := l.svcCtx.AuthRpc.Login(l.ctx, &authservice.LoginReq{
-		Username: req.Username,
-		Password: req.Password,
+		Identifier: util.LoginIdentifier(req.Identifier, req.Username),
+		Password:   req.Password,
 	},
 		grpc.Header(&md), // get grpc Header
 	)
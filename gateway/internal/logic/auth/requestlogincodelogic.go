@@ -0,0 +1,41 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package auth
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type RequestLoginCodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewRequestLoginCodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *RequestLoginCodeLogic {
+	return &RequestLoginCodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *RequestLoginCodeLogic) RequestLoginCode(req *types.LoginCodeReq) (resp *types.LoginCodeResp, err error) {
+	r, err := l.svcCtx.AuthRpc.RequestLoginCode(l.ctx, &authservice.RequestLoginCodeReq{
+		Email: req.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.LoginCodeResp{
+		Ok:        r.GetOk(),
+		ExpiresIn: r.GetExpiresIn(),
+	}, nil
+}
@@ -0,0 +1,50 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package auth
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type VerifyLoginCodeLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewVerifyLoginCodeLogic(ctx context.Context, svcCtx *svc.ServiceContext) *VerifyLoginCodeLogic {
+	return &VerifyLoginCodeLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *VerifyLoginCodeLogic) VerifyLoginCode(req *types.VerifyLoginCodeReq) (resp *types.LoginResp, header metadata.MD, err error) {
+	var md metadata.MD
+	r, err := l.svcCtx.AuthRpc.VerifyLoginCode(l.ctx, &authservice.VerifyLoginCodeReq{
+		Email:     req.Email,
+		Code:      req.Code,
+		LinkToken: req.Token,
+	},
+		grpc.Header(&md), // refresh token comes back in the grpc header
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &types.LoginResp{
+		AccessToken: r.GetAccessToken(),
+		SessionId:   r.GetSessionId(),
+		ExpiresIn:   r.GetExpiresIn(),
+		TokenType:   r.GetTokenType(),
+	}, md, nil
+}
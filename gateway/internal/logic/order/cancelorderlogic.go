@@ -0,0 +1,50 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package order
+
+import (
+	"context"
+
+	pb "github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CancelOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCancelOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelOrderLogic {
+	return &CancelOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CancelOrderLogic) CancelOrder(req *types.CancelOrderReq) (resp *types.OrderResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = "user_request"
+	}
+	o, err := l.svcCtx.OrderRpc.CancelOrder(l.ctx, &pb.CancelOrderReq{
+		UserId:  uid,
+		OrderId: req.OrderId,
+		Reason:  reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toOrderResp(o), nil
+}
@@ -0,0 +1,46 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package order
+
+import (
+	"context"
+
+	pb "github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CreateOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewCreateOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateOrderLogic {
+	return &CreateOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *CreateOrderLogic) CreateOrder(req *types.CreateOrderReq) (resp *types.OrderResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	o, err := l.svcCtx.OrderRpc.CreateOrder(l.ctx, &pb.CreateOrderReq{
+		UserId:   uid,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toOrderResp(o), nil
+}
@@ -0,0 +1,45 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package order
+
+import (
+	"context"
+
+	pb "github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetOrderLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewGetOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOrderLogic {
+	return &GetOrderLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *GetOrderLogic) GetOrder(req *types.GetOrderReq) (resp *types.OrderResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	o, err := l.svcCtx.OrderRpc.GetOrder(l.ctx, &pb.GetOrderReq{
+		UserId:  uid,
+		OrderId: req.OrderId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toOrderResp(o), nil
+}
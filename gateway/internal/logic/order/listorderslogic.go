@@ -0,0 +1,54 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package order
+
+import (
+	"context"
+
+	pb "github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListOrdersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewListOrdersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOrdersLogic {
+	return &ListOrdersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *ListOrdersLogic) ListOrders(req *types.ListOrdersReq) (resp *types.ListOrdersResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	r, err := l.svcCtx.OrderRpc.ListOrders(l.ctx, &pb.ListOrdersReq{
+		UserId:    uid,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		Status:    req.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp = &types.ListOrdersResp{
+		Orders:        make([]types.OrderResp, 0, len(r.GetOrders())),
+		NextPageToken: r.GetNextPageToken(),
+	}
+	for _, o := range r.GetOrders() {
+		resp.Orders = append(resp.Orders, *toOrderResp(o))
+	}
+	return resp, nil
+}
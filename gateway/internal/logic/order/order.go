@@ -0,0 +1,19 @@
+package order
+
+import (
+	pb "github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+)
+
+func toOrderResp(o *pb.Order) *types.OrderResp {
+	return &types.OrderResp{
+		OrderId:   o.GetOrderId(),
+		UserId:    o.GetUserId(),
+		Amount:    o.GetAmount(),
+		Currency:  o.GetCurrency(),
+		Status:    o.GetStatus(),
+		CreatedAt: o.GetCreatedAt(),
+		UpdatedAt: o.GetUpdatedAt(),
+		Version:   o.GetVersion(),
+	}
+}
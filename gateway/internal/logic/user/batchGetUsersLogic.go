@@ -0,0 +1,59 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package user
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BatchGetUsersLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewBatchGetUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BatchGetUsersLogic {
+	return &BatchGetUsersLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *BatchGetUsersLogic) BatchGetUsers(req *types.BatchGetUsersReq) (resp *types.BatchGetUsersResp, err error) {
+	if uid, ok := middleware.UIDFromContext(l.ctx); !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	batch, err := l.svcCtx.UserRpc.BatchGetUsers(l.ctx, &user.BatchGetUsersReq{
+		UserIds: req.UserIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &types.BatchGetUsersResp{
+		Users:    make([]types.PublicUserResp, 0, len(batch.Users)),
+		NotFound: batch.NotFound,
+	}
+	if resp.NotFound == nil {
+		resp.NotFound = []string{}
+	}
+	for _, u := range batch.Users {
+		resp.Users = append(resp.Users, types.PublicUserResp{
+			UserId:      u.UserId,
+			Username:    u.Username,
+			DisplayName: u.DisplayName,
+			AvatarUrl:   u.AvatarUrl,
+		})
+	}
+	return resp, nil
+}
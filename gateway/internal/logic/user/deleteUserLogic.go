@@ -0,0 +1,50 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package user
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DeleteUserLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewDeleteUserLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteUserLogic {
+	return &DeleteUserLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *DeleteUserLogic) DeleteUser() (resp *types.DeleteUserResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	// deleting an account is never done on behalf of someone else
+	if _, ok := middleware.ActorFromContext(l.ctx); ok {
+		return nil, status.Error(codes.PermissionDenied, "not allowed while impersonating")
+	}
+
+	r, err := l.svcCtx.UserRpc.DeleteUser(l.ctx, &user.DeleteUserReq{
+		UserId: uid,
+		Reason: "user_request",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.DeleteUserResp{Ok: r.GetOk()}, nil
+}
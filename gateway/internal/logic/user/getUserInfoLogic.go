@@ -47,5 +47,6 @@ func (l *GetUserInfoLogic) GetUserInfo() (resp *types.UserInfoResp, err error) {
 		Email:       userResp.Email,
 		DisplayName: userResp.DisplayName,
 		AvatarUrl:   userResp.AvatarUrl,
+		Version:     userResp.Version,
 	}, nil
 }
@@ -0,0 +1,89 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/uwu-octane/antBackend/gateway/util"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type UpdateUserInfoLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUpdateUserInfoLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateUserInfoLogic {
+	return &UpdateUserInfoLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+func (l *UpdateUserInfoLogic) UpdateUserInfo(req *types.UpdateUserInfoReq) (resp *types.UserInfoResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if req.Email == nil && req.DisplayName == nil && req.AvatarUrl == nil {
+		return nil, status.Error(codes.InvalidArgument, "nothing to update")
+	}
+
+	// only the fields present in the body end up in the mask
+	var paths []string
+	if req.Email != nil {
+		paths = append(paths, "email")
+	}
+	if req.DisplayName != nil {
+		paths = append(paths, "display_name")
+	}
+	if req.AvatarUrl != nil {
+		paths = append(paths, "avatar_url")
+	}
+
+	var expectedVersion int64
+	if ifMatch := strings.TrimSpace(req.IfMatch); ifMatch != "" && ifMatch != "*" {
+		v, ok := util.ParseVersionETag(ifMatch)
+		if !ok {
+			return nil, status.Error(codes.FailedPrecondition, "invalid If-Match header")
+		}
+		expectedVersion = v
+	}
+
+	reason := "self_service"
+	if actor, ok := middleware.ActorFromContext(l.ctx); ok {
+		reason = "impersonated_by:" + actor
+	}
+	userResp, err := l.svcCtx.UserRpc.UpdateUser(l.ctx, &user.UpdateUserReq{
+		UserId:          uid,
+		Email:           req.Email,
+		DisplayName:     req.DisplayName,
+		AvatarUrl:       req.AvatarUrl,
+		Reason:          reason,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: paths},
+		ExpectedVersion: expectedVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.UserInfoResp{
+		UserId:      userResp.UserId,
+		Username:    userResp.Username,
+		Email:       userResp.Email,
+		DisplayName: userResp.DisplayName,
+		AvatarUrl:   userResp.AvatarUrl,
+		Version:     userResp.Version,
+	}, nil
+}
@@ -0,0 +1,139 @@
+// Code scaffolded by goctl. Safe to edit.
+// goctl 1.9.1
+
+package user
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/imageproc"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware"
+	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/internal/types"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var (
+	// avatarSizes are the square variants stored for every upload
+	avatarSizes = []int{64, 128, 256, 512}
+	// avatarURLSize is the variant written to users.avatar_url, the others share its directory
+	avatarURLSize = 256
+)
+
+type UploadAvatarLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+func NewUploadAvatarLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UploadAvatarLogic {
+	return &UploadAvatarLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// UploadAvatar stores the resized variants under avatars/<uid>/<upload id>/<size>.jpg and points
+// avatar_url at them through UpdateUser, which emits user.updated. The previous upload is deleted afterwards.
+func (l *UploadAvatarLogic) UploadAvatar(data []byte) (resp *types.UploadAvatarResp, err error) {
+	uid, ok := middleware.UIDFromContext(l.ctx)
+	if !ok || uid == "" {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	store := l.svcCtx.Avatars
+	if store == nil {
+		return nil, status.Error(codes.Unavailable, "avatar uploads are disabled")
+	}
+
+	cfg := l.svcCtx.Config.Avatar
+	variants, err := imageproc.Square(data, imageproc.Options{MaxPixels: cfg.MaxPixels, Sizes: avatarSizes, Quality: cfg.Quality})
+	switch {
+	case errors.Is(err, imageproc.ErrTooLarge):
+		return nil, status.Error(codes.InvalidArgument, "image dimensions are too large")
+	case errors.Is(err, imageproc.ErrUnsupportedFormat), errors.Is(err, imageproc.ErrInvalidImage):
+		return nil, status.Error(codes.InvalidArgument, "file must be a JPEG, PNG or GIF image")
+	case err != nil:
+		return nil, err
+	}
+
+	current, err := l.svcCtx.UserRpc.GetUserInfo(l.ctx, &user.GetUserInfoReq{UserId: uid})
+	if err != nil {
+		return nil, err
+	}
+
+	dir := "avatars/" + uid + "/" + uuid.NewString()
+	resp = &types.UploadAvatarResp{Variants: make([]types.AvatarVariant, 0, len(variants))}
+	var stored []string
+	for _, v := range variants {
+		key := dir + "/" + strconv.Itoa(v.Size) + ".jpg"
+		if err := store.Put(l.ctx, key, v.Data, imageproc.ContentType); err != nil {
+			l.Errorw("store avatar failed", logx.Field("key", key), logx.Field("error", err))
+			l.deleteKeys(stored)
+			return nil, status.Error(codes.Unavailable, "avatar storage unavailable")
+		}
+		stored = append(stored, key)
+		resp.Variants = append(resp.Variants, types.AvatarVariant{Size: v.Size, Url: store.URL(key)})
+		if v.Size == avatarURLSize {
+			resp.AvatarUrl = store.URL(key)
+		}
+	}
+
+	reason := "avatar_upload"
+	if actor, ok := middleware.ActorFromContext(l.ctx); ok {
+		reason = "impersonated_by:" + actor
+	}
+	updated, err := l.svcCtx.UserRpc.UpdateUser(l.ctx, &user.UpdateUserReq{
+		UserId:     uid,
+		AvatarUrl:  &resp.AvatarUrl,
+		Reason:     reason,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"avatar_url"}},
+	})
+	if err != nil {
+		l.deleteKeys(stored)
+		return nil, err
+	}
+	resp.Version = updated.Version
+
+	l.deleteKeys(l.uploadedKeys(uid, current.AvatarUrl))
+	return resp, nil
+}
+
+// uploadedKeys returns the keys of a previous upload of uid given its avatar_url,
+// nil for an empty or foreign url (set through PATCH /user/info)
+func (l *UploadAvatarLogic) uploadedKeys(uid, avatarURL string) []string {
+	base := l.svcCtx.Avatars.URL("")
+	key, ok := strings.CutPrefix(avatarURL, base)
+	if !ok || avatarURL == "" {
+		return nil
+	}
+	dir := path.Dir(key)
+	if path.Dir(dir) != "avatars/"+uid {
+		return nil
+	}
+	keys := make([]string, 0, len(avatarSizes))
+	for _, size := range avatarSizes {
+		keys = append(keys, dir+"/"+strconv.Itoa(size)+".jpg")
+	}
+	return keys
+}
+
+// deleteKeys removes stored objects on a best-effort basis, a leftover only costs storage.
+// It also runs when the request was cancelled.
+func (l *UploadAvatarLogic) deleteKeys(keys []string) {
+	ctx := context.WithoutCancel(l.ctx)
+	for _, key := range keys {
+		if err := l.svcCtx.Avatars.Delete(ctx, key); err != nil {
+			l.Errorw("delete avatar failed", logx.Field("key", key), logx.Field("error", err))
+		}
+	}
+}
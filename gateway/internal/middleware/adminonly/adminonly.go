@@ -0,0 +1,63 @@
+// Package adminonly guards the admin routes. It only depends on the auth client and the context keys set
+// by the Jwt middleware, so svc.NewServiceContext can build it without importing the middleware package.
+package adminonly
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/gateway/internal/handler/constvar"
+	"github.com/uwu-octane/antBackend/gateway/internal/response"
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Middleware lets a request through when the auth role of the JWT subject is adminRole.
+// The role is read from auth.rpc on every request, so a revoked admin is locked out at once.
+// Impersonation tokens are rejected: acting as a user never grants that user's admin rights to the actor.
+type Middleware struct {
+	authRpc   authservice.AuthService
+	adminRole string
+}
+
+func New(authRpc authservice.AuthService, adminRole string) *Middleware {
+	return &Middleware{authRpc: authRpc, adminRole: adminRole}
+}
+
+func (m *Middleware) Handle(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		uid, ok := ctxString(ctx, constvar.CtxUID)
+		if !ok {
+			response.FromError(w, status.Error(codes.Unauthenticated, "unauthenticated"))
+			return
+		}
+		if _, ok := ctxString(ctx, constvar.CtxActor); ok {
+			response.FromError(w, status.Error(codes.PermissionDenied, "not allowed while impersonating"))
+			return
+		}
+
+		resp, err := m.authRpc.GetRole(ctx, &authservice.GetRoleReq{UserId: uid})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				err = status.Error(codes.PermissionDenied, "admin role required")
+			}
+			response.FromError(w, err)
+			return
+		}
+		if resp.GetRole() != m.adminRole {
+			logx.WithContext(ctx).Infow("admin route denied", logx.Field("uid", uid), logx.Field("path", r.URL.Path))
+			response.FromError(w, status.Error(codes.PermissionDenied, "admin role required"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ctxString reads a value the Jwt middleware stored, same as middleware.UIDFromContext / ActorFromContext
+func ctxString(ctx context.Context, key any) (string, bool) {
+	v, ok := ctx.Value(key).(string)
+	return v, ok && v != ""
+}
@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	"github.com/uwu-octane/antBackend/gateway/internal/audit"
 	"github.com/uwu-octane/antBackend/gateway/internal/handler/constvar"
 	"github.com/uwu-octane/antBackend/gateway/internal/svc"
+	"github.com/uwu-octane/antBackend/gateway/util"
 )
 
 type Jwt struct {
@@ -41,8 +44,17 @@ func IATFromContext(ctx context.Context) (int64, bool) {
 	return v, ok
 }
 
+// ActorFromContext returns the admin id when the request is made with an impersonation token
+func ActorFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(constvar.CtxActor).(string)
+	return v, ok && v != ""
+}
+
 type accessCalims struct {
 	TokenType string `json:"token_type"`
+	Act       *struct {
+		Sub string `json:"sub"`
+	} `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -98,7 +110,48 @@ func (m *Jwt) Handle(next http.HandlerFunc) http.HandlerFunc {
 			ctx = context.WithValue(ctx, constvar.CtxIAT, accessClaims.IssuedAt.Unix())
 		}
 
-		next(w, r.WithContext(ctx))
+		if accessClaims.Act == nil {
+			next(w, r.WithContext(ctx))
+			return
+		}
+		if accessClaims.Act.Sub == "" {
+			http.Error(w, "missing actor", http.StatusUnauthorized)
+			return
+		}
+
+		// impersonated request: expose the admin and record it once the response is written
+		ctx = context.WithValue(ctx, constvar.CtxActor, accessClaims.Act.Sub)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r.WithContext(ctx))
+
+		rid := requestid.FromContext(ctx)
+		m.svcCtx.Audit.Record(ctx, audit.Entry{
+			Time:      time.Now(),
+			RequestID: rid,
+			Actor:     accessClaims.Act.Sub,
+			Subject:   accessClaims.Subject,
+			Jti:       accessClaims.ID,
+			Method:    r.Method,
+			Path:      path,
+			Status:    rec.status,
+			RemoteIP:  util.ClientIP(r),
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
 	}
 }
 
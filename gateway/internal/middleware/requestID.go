@@ -1,29 +1,26 @@
 package middleware
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/google/uuid"
+	"github.com/uwu-octane/antBackend/common/requestid"
 )
 
-type ctxKey string
-
-const RequestIDKey ctxKey = "requestID"
-
 type RequestID struct{}
 
 func NewRequestID() *RequestID { return &RequestID{} }
 
+// Handle 读取或生成 X-Request-Id；写入 ctx 后由 rpc 客户端拦截器经 gRPC metadata 传给下游服务
 func (m *RequestID) Handle(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		rid := r.Header.Get("X-Request-Id")
+		rid := r.Header.Get(requestid.Header)
 		if rid == "" {
 			rid = uuid.NewString()
-			r.Header.Set("X-Request-Id", rid)
+			r.Header.Set(requestid.Header, rid)
 		}
-		w.Header().Set("X-Request-Id", rid)
-		ctx := context.WithValue(r.Context(), RequestIDKey, rid)
+		w.Header().Set(requestid.Header, rid)
+		ctx := requestid.NewContext(r.Context(), rid)
 		next(w, r.WithContext(ctx))
 	}
 }
@@ -7,13 +7,19 @@ import (
 	"context"
 
 	"github.com/uwu-octane/antBackend/auth/authservice"
+	"github.com/uwu-octane/antBackend/common/blobstore"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	"github.com/uwu-octane/antBackend/gateway/internal/audit"
 	"github.com/uwu-octane/antBackend/gateway/internal/config"
+	"github.com/uwu-octane/antBackend/gateway/internal/middleware/adminonly"
+	"github.com/uwu-octane/antBackend/order/orderservice"
 	"github.com/uwu-octane/antBackend/user/userservice"
 
 	"github.com/uwu-octane/antBackend/gateway/internal/upstream/consulmanager"
 	"github.com/zeromicro/go-zero/core/limit"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/rest"
 	"github.com/zeromicro/go-zero/zrpc"
 )
 
@@ -21,17 +27,25 @@ type ServiceContext struct {
 	Config        config.Config
 	AuthRpc       authservice.AuthService
 	UserRpc       userservice.UserService
+	OrderRpc      orderservice.OrderService
 	LoginLimiter  *limit.PeriodLimit
 	ConsulManager *consulmanager.Manager
 	Targets       map[string]*consulmanager.Target
+	Audit         audit.Logger
+	// Avatars stores uploaded avatars, nil when the store could not be created
+	Avatars blobstore.Store
+	// AdminOnly guards the admin routes
+	AdminOnly rest.Middleware
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	s := &ServiceContext{
-		Config:  c,
-		AuthRpc: authservice.NewAuthService(zrpc.MustNewClient(c.AuthRpc)),
-		UserRpc: userservice.NewUserService(zrpc.MustNewClient(c.UserRpc)),
+		Config:   c,
+		AuthRpc:  authservice.NewAuthService(zrpc.MustNewClient(c.AuthRpc, zrpc.WithUnaryClientInterceptor(requestid.UnaryClientInterceptor))),
+		UserRpc:  userservice.NewUserService(zrpc.MustNewClient(c.UserRpc, zrpc.WithUnaryClientInterceptor(requestid.UnaryClientInterceptor))),
+		OrderRpc: orderservice.NewOrderService(zrpc.MustNewClient(c.OrderRpc, zrpc.WithUnaryClientInterceptor(requestid.UnaryClientInterceptor))),
 	}
+	s.AdminOnly = adminonly.New(s.AuthRpc, c.AdminRole).Handle
 	if c.RateLimit.Enable {
 		store := redis.MustNewRedis(c.RateLimit.RateLimitRedis.RedisConf)
 		LoginLimiter := limit.NewPeriodLimit(c.RateLimit.WindowSeconds,
@@ -39,6 +53,19 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		s.LoginLimiter = LoginLimiter
 	}
 
+	auditLogger, err := audit.New(c.Audit)
+	if err != nil {
+		logx.Errorw("create audit file failed, fall back to logx", logx.Field("error", err))
+		auditLogger, _ = audit.New(config.AuditConfig{})
+	}
+	s.Audit = auditLogger
+
+	if avatars, err := blobstore.New(c.Avatar.Store); err != nil {
+		logx.Errorw("create avatar store failed, uploads are disabled", logx.Field("error", err))
+	} else {
+		s.Avatars = avatars
+	}
+
 	mgr, targets, err := makeManager(c)
 	if err != nil {
 		logx.Errorw("make manager failed", logx.Field("error", err))
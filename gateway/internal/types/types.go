@@ -6,9 +6,142 @@ package types
 type EmptyResp struct {
 }
 
+type AdminUserResp struct {
+	UserId      string `json:"user_id"`
+	Username    string `json:"username"`
+	Email       string `json:"email"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+	Status      string `json:"status"`
+	Version     int64  `json:"version"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type AvatarVariant struct {
+	Size int    `json:"size"` // edge length in pixels, the image is square
+	Url  string `json:"url"`
+}
+
+type BatchGetUsersReq struct {
+	UserIds []string `json:"user_ids"`
+}
+
+type BatchGetUsersResp struct {
+	Users    []PublicUserResp `json:"users"`
+	NotFound []string         `json:"not_found"`
+}
+
+type CancelOrderReq struct {
+	OrderId string `path:"id"`
+	Reason  string `json:"reason,optional"`
+}
+
+type CreateOrderReq struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type DeleteUserResp struct {
+	Ok bool `json:"ok"`
+}
+
+type DeviceApproveReq struct {
+	UserCode string `json:"user_code"`
+	Deny     bool   `json:"deny,optional"`
+}
+
+type DeviceApproveResp struct {
+	Ok       bool   `json:"ok"`
+	ClientId string `json:"client_id"`
+	Scope    string `json:"scope"`
+}
+
+type DeviceCodeReq struct {
+	ClientId string `json:"client_id"`
+	Scope    string `json:"scope,optional"`
+}
+
+type DeviceCodeResp struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+type DeviceTokenReq struct {
+	DeviceCode string `json:"device_code"`
+	ClientId   string `json:"client_id,optional"`
+}
+
+type DeviceTokenResp struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	SessionId    string `json:"session_id"`
+	ExpiresIn    int64  `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+}
+
+type GetOrderReq struct {
+	OrderId string `path:"id"`
+}
+
+type ImpersonateReq struct {
+	UserId string `json:"user_id"`
+	Reason string `json:"reason,optional"`
+}
+
+type ImpersonateResp struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+	ActorId     string `json:"actor_id"`
+	UserId      string `json:"user_id"`
+}
+
+type ListOrdersReq struct {
+	PageSize  int32  `form:"page_size,optional"`
+	PageToken string `form:"page_token,optional"`
+	Status    string `form:"status,optional"`
+}
+
+type ListOrdersResp struct {
+	Orders        []OrderResp `json:"orders"`
+	NextPageToken string      `json:"next_page_token"`
+}
+
+type ListUsersReq struct {
+	UsernamePrefix string `form:"username_prefix,optional"`
+	EmailPrefix    string `form:"email_prefix,optional"`
+	CreatedAfter   string `form:"created_after,optional"`  // RFC3339, inclusive
+	CreatedBefore  string `form:"created_before,optional"` // RFC3339, exclusive
+	Status         string `form:"status,optional"`         // active | disabled
+	SortBy         string `form:"sort_by,optional"`        // created_at (default) | username
+	SortOrder      string `form:"sort_order,optional"`     // desc (default) | asc
+	PageSize       int32  `form:"page_size,optional"`
+	PageToken      string `form:"page_token,optional"`
+}
+
+type ListUsersResp struct {
+	Users         []AdminUserResp `json:"users"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+type LoginCodeReq struct {
+	Email string `json:"email"`
+}
+
+type LoginCodeResp struct {
+	Ok        bool  `json:"ok"`
+	ExpiresIn int64 `json:"expires_in"`
+}
+
 type LoginReq struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Identifier string `json:"identifier,optional"` // email or username
+	Username   string `json:"username,optional"`   // deprecated: use identifier
+	Password   string `json:"password"`
 }
 
 type LoginResp struct {
@@ -29,10 +162,61 @@ type MeResp struct {
 	Iat int64  `json:"iat"`
 }
 
+type OrderResp struct {
+	OrderId   string `json:"order_id"`
+	UserId    string `json:"user_id"`
+	Amount    int64  `json:"amount"` // minor units, e.g. cents
+	Currency  string `json:"currency"`
+	Status    string `json:"status"` // pending | cancelled
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Version   int64  `json:"version"`
+}
+
+type PublicUserResp struct {
+	UserId      string `json:"user_id"`
+	Username    string `json:"username"`
+	DisplayName string `json:"display_name"`
+	AvatarUrl   string `json:"avatar_url"`
+}
+
+type SearchUsersReq struct {
+	Q              string `form:"q"`
+	UsernamePrefix string `form:"username_prefix,optional"`
+	EmailPrefix    string `form:"email_prefix,optional"`
+	CreatedAfter   string `form:"created_after,optional"`  // RFC3339, inclusive
+	CreatedBefore  string `form:"created_before,optional"` // RFC3339, exclusive
+	Status         string `form:"status,optional"`         // active | disabled
+	SortBy         string `form:"sort_by,optional"`        // created_at (default) | username
+	SortOrder      string `form:"sort_order,optional"`     // desc (default) | asc
+	PageSize       int32  `form:"page_size,optional"`
+	PageToken      string `form:"page_token,optional"`
+}
+
+type UpdateUserInfoReq struct {
+	IfMatch     string  `header:"If-Match,optional"` // ETag from GET /user/info, stale -> 409
+	Email       *string `json:"email,optional"`
+	DisplayName *string `json:"display_name,optional"`
+	AvatarUrl   *string `json:"avatar_url,optional"`
+}
+
+type UploadAvatarResp struct {
+	AvatarUrl string          `json:"avatar_url"`
+	Variants  []AvatarVariant `json:"variants"`
+	Version   int64           `json:"version"`
+}
+
 type UserInfoResp struct {
 	UserId      string `json:"user_id"`
 	Username    string `json:"username"`
 	Email       string `json:"email"`
 	DisplayName string `json:"display_name"`
 	AvatarUrl   string `json:"avatar_url"`
+	Version     int64  `json:"version"` // also sent as ETag
+}
+
+type VerifyLoginCodeReq struct {
+	Email string `json:"email,optional"`
+	Code  string `json:"code,optional"`
+	Token string `json:"token,optional"`
 }
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -25,16 +27,27 @@ func ClientIP(r *http.Request) string {
 	return r.RemoteAddr
 }
 
-func MakeLoginLimitKey(by, username, ip string) string {
-	u := strings.ToUpper(strings.TrimSpace(username))
+// LoginIdentifier returns the normalized login identifier (email or username),
+// falling back to the deprecated username field when identifier is empty.
+// It must stay in sync with the normalization done by auth.rpc.
+func LoginIdentifier(identifier, username string) string {
+	id := strings.TrimSpace(identifier)
+	if id == "" {
+		id = strings.TrimSpace(username)
+	}
+	return strings.ToLower(id)
+}
+
+func MakeLoginLimitKey(by, identifier, ip string) string {
+	u := strings.ToLower(strings.TrimSpace(identifier))
 	i := strings.TrimSpace(ip)
 	switch strings.ToLower(strings.TrimSpace(by)) {
-	case "username":
+	case "username", "identifier":
 		if u == "" {
 			return "u::" + i
 		}
 		return "u:" + u
-	case "ip+username":
+	case "ip+username", "ip+identifier":
 		if u == "" {
 			return "iu:" + i + "::"
 		}
@@ -51,3 +64,22 @@ func ReadCookie(r *http.Request, name string) string {
 	}
 	return c.Value
 }
+
+// VersionETag formats a row version as a strong ETag
+func VersionETag(version int64) string {
+	return fmt.Sprintf(`"v%d"`, version)
+}
+
+// ParseVersionETag reads the version back from an If-Match / If-None-Match value.
+// "*" and an empty header return ok=false, meaning no version to compare against.
+func ParseVersionETag(h string) (int64, bool) {
+	h = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(h), "W/"))
+	if h == "" || h == "*" {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(strings.TrimPrefix(strings.Trim(h, `"`), "v"), 10, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	"github.com/uwu-octane/antBackend/order/internal/config"
+	"github.com/uwu-octane/antBackend/order/internal/server"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/zrpc"
+	"github.com/zeromicro/zero-contrib/zrpc/registry/consul"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+)
+
+// BuildOrderRpcServer 构造 order rpc server 与 outbox 中继，不调用 Start()；cleanup 在 group 停止后关闭发布器
+func BuildOrderRpcServer(configFile string) (service.Service, func(), error) {
+	var c config.Config
+	conf.MustLoad(configFile, &c, conf.UseEnv())
+
+	ctx := svc.NewServiceContext(c)
+	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
+		order.RegisterOrderServiceServer(grpcServer, server.NewOrderServiceServer(ctx))
+
+		if c.Mode == service.DevMode || c.Mode == service.TestMode {
+			reflection.Register(grpcServer)
+		}
+	})
+	s.AddUnaryInterceptors(requestid.UnaryServerInterceptor)
+
+	if err := consul.RegisterService(c.ListenOn, c.Consul); err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		ctx.Close()
+	}
+
+	// outbox relay 与 rpc server 同生命周期
+	group := service.NewServiceGroup()
+	group.Add(s)
+	if !c.Outbox.Disabled && ctx.OrderEventsPusher != nil {
+		group.Add(outbox.NewRelay("order", c.Outbox, ctx.Outbox, outbox.EventBus(ctx.OrderEventsPusher)))
+	}
+	return group, cleanup, nil
+}
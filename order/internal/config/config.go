@@ -0,0 +1,86 @@
+package config
+
+import (
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/zeromicro/go-zero/zrpc"
+	"github.com/zeromicro/zero-contrib/zrpc/registry/consul"
+)
+
+type Config struct {
+	zrpc.RpcServerConf
+	Consul            consul.Conf
+	OrderDatabase     OrderDatabase
+	OrderReadStrategy OrderReadStrategy
+	Orders            OrdersConf
+
+	// order events are relayed from order_outbox only when Kafka.Brokers is set
+	Kafka              KafkaConf         `json:",optional"`
+	KafkaOrderProducer KafkaProducerConf `json:",optional"`
+	// Outbox controls the order_outbox relay: polling, retry backoff and retention of sent rows
+	Outbox outbox.Conf
+}
+
+type OrderDatabase struct {
+	Driver     string
+	MasterDSN  string
+	ReplicaDSN string
+}
+
+type OrderReadStrategy struct {
+	FromReplica                 bool
+	FallbackToMasterOnReadError bool
+}
+
+// OrdersConf limits for order requests
+type OrdersConf struct {
+	DefaultPageSize int `json:",default=20"`
+	MaxPageSize     int `json:",default=100"`
+	// Currencies accepted by CreateOrder, empty accepts any ISO 4217 code
+	Currencies []string `json:",optional"`
+}
+
+type KafkaConf struct {
+	Env     string          `json:",default=dev"`
+	Brokers []string        `json:",optional"`
+	Topics  KafkaTopicsConf `json:",optional"`
+}
+
+const (
+	TopicAutoCreateDev    = "dev"
+	TopicAutoCreateAlways = "always"
+	TopicAutoCreateNever  = "never"
+)
+
+// KafkaTopicsConf creates the order events topic on startup: dev (default) only when Kafka.Env is dev
+type KafkaTopicsConf struct {
+	AutoCreate        string `json:",default=dev,options=dev|always|never"`
+	Partitions        int32  `json:",default=3"`
+	ReplicationFactor int16  `json:",default=1"`
+	RetentionMs       int64  `json:",optional"` // 0 uses the broker default
+}
+
+type KafkaProducerSASL struct {
+	Enable    bool   `json:",optional"`
+	Mechanism string `json:",default=plain"` // plain / scram-sha256 / scram-sha512
+	Username  string `json:",optional"`
+	Password  string `json:",optional"`
+}
+
+type KafkaProducerTLS struct {
+	Enable bool `json:",optional"`
+}
+
+type KafkaProducerConf struct {
+	Acks             string            `json:",default=all"` // all/local
+	Idempotent       bool              `json:",optional"`
+	RetryMax         int               `json:",default=5"`
+	Compression      string            `json:",default=lz4"` // none/snappy/lz4/zstd/gzip
+	FlushBytes       int               `json:",optional"`
+	FlushMessages    int               `json:",optional"`
+	FlushFrequencyMs int               `json:",optional"`
+	MaxMessageBytes  int               `json:",optional"`
+	Codec            string            `json:",default=json,options=json|protobuf|avro"` // event encoding, sent as the content-type header
+	Async            bool              `json:",optional"`                                // sarama AsyncProducer, the relay sends events of different orders in parallel
+	SASL             KafkaProducerSASL `json:",optional"`
+	TLS              KafkaProducerTLS  `json:",optional"`
+}
@@ -0,0 +1,42 @@
+package event
+
+import (
+	"time"
+
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+)
+
+const (
+	// Producer 写入 Envelope.Producer
+	Producer = "order.rpc"
+)
+
+func NewOrderCreatedEvent(producer, traceID string, data eventbus.OrderCreatedEvent) *eventbus.Envelope[eventbus.OrderCreatedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeOrderCreated,
+		1,
+		producer,
+		traceID,
+		data,
+	)
+}
+
+func NewOrderCancelledEvent(orderID, userID, producer, traceID, reason string, cancelledAt time.Time) *eventbus.Envelope[eventbus.OrderCancelledEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeOrderCancelled,
+		1,
+		producer,
+		traceID,
+		eventbus.OrderCancelledEvent{
+			OrderID:     orderID,
+			UserID:      userID,
+			Reason:      reason,
+			CancelledAt: cancelledAt,
+		},
+	)
+}
+
+// KeyForOrder 同一订单的事件进入同一分区，消费者按写入顺序处理
+func KeyForOrder(orderID string) []byte {
+	return []byte(orderID)
+}
@@ -0,0 +1,55 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/order/internal/event"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CancelOrderLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCancelOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CancelOrderLogic {
+	return &CancelOrderLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// CancelOrder is idempotent: cancelling a cancelled order returns it without a second event
+func (l *CancelOrderLogic) CancelOrder(in *order.CancelOrderReq) (*order.Order, error) {
+	if in.GetUserId() == "" || in.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and order_id are required")
+	}
+
+	_, after, err := l.svcCtx.Orders.Cancel(l.ctx, in.GetOrderId(), in.GetUserId(), func(_, after *model.Order) ([]*outbox.Message, error) {
+		return outboxOf(l.ctx, after.Id, event.NewOrderCancelledEvent(
+			after.Id, after.UserId, event.Producer, trace.TraceIDFromContext(l.ctx),
+			strings.TrimSpace(in.GetReason()), after.UpdatedAt,
+		))
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		l.Errorf("failed to cancel order: %v", err)
+		return nil, err
+	}
+
+	return toOrderResp(after), nil
+}
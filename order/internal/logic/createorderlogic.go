@@ -0,0 +1,79 @@
+package logic
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/order/internal/event"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type CreateOrderLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateOrderLogic {
+	return &CreateOrderLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *CreateOrderLogic) CreateOrder(in *order.CreateOrderReq) (*order.Order, error) {
+	if in.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if in.GetAmount() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	currency := strings.ToUpper(strings.TrimSpace(in.GetCurrency()))
+	if !validCurrency(currency, l.svcCtx.Config.Orders.Currencies) {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency %q", in.GetCurrency())
+	}
+
+	o, err := l.svcCtx.Orders.Insert(l.ctx, &model.Order{
+		UserId:   in.GetUserId(),
+		Amount:   in.GetAmount(),
+		Currency: currency,
+	}, func(_, after *model.Order) ([]*outbox.Message, error) {
+		return outboxOf(l.ctx, after.Id, event.NewOrderCreatedEvent(event.Producer, trace.TraceIDFromContext(l.ctx), eventbus.OrderCreatedEvent{
+			OrderID:   after.Id,
+			UserID:    after.UserId,
+			Amount:    after.Amount,
+			Currency:  after.Currency,
+			Status:    after.Status,
+			CreatedAt: after.CreatedAt,
+			UpdatedAt: after.UpdatedAt,
+		}))
+	})
+	if err != nil {
+		l.Errorf("failed to create order: %v", err)
+		return nil, err
+	}
+
+	return toOrderResp(o), nil
+}
+
+// validCurrency accepts an ISO 4217 style code, limited to allowed when it is not empty
+func validCurrency(currency string, allowed []string) bool {
+	if !currencyCode.MatchString(currency) {
+		return false
+	}
+	return len(allowed) == 0 || slices.Contains(allowed, currency)
+}
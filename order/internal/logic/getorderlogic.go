@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GetOrderLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetOrderLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetOrderLogic {
+	return &GetOrderLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *GetOrderLogic) GetOrder(in *order.GetOrderReq) (*order.Order, error) {
+	if in.GetUserId() == "" || in.GetOrderId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and order_id are required")
+	}
+
+	o, err := l.svcCtx.Orders.FindOne(l.ctx, in.GetOrderId(), in.GetUserId())
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		l.Errorf("failed to find order: %v", err)
+		return nil, err
+	}
+
+	return toOrderResp(o), nil
+}
@@ -0,0 +1,78 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListOrdersLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListOrdersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListOrdersLogic {
+	return &ListOrdersLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *ListOrdersLogic) ListOrders(in *order.ListOrdersReq) (*order.ListOrdersResp, error) {
+	if in.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	switch in.GetStatus() {
+	case "", model.StatusPending, model.StatusCancelled:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", in.GetStatus())
+	}
+	beforeID, ok := decodePageToken(in.GetPageToken())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+
+	size := pageSize(int(in.GetPageSize()), l.svcCtx.Config.Orders.DefaultPageSize, l.svcCtx.Config.Orders.MaxPageSize)
+	// one extra row tells whether there is a next page
+	orders, err := l.svcCtx.Orders.List(l.ctx, model.ListFilter{
+		UserId:   in.GetUserId(),
+		Status:   in.GetStatus(),
+		BeforeId: beforeID,
+		Limit:    size + 1,
+	})
+	if err != nil {
+		l.Errorf("failed to list orders: %v", err)
+		return nil, err
+	}
+
+	resp := &order.ListOrdersResp{}
+	if len(orders) > size {
+		orders = orders[:size]
+		resp.NextPageToken = encodePageToken(orders[size-1].Id)
+	}
+	resp.Orders = make([]*order.Order, 0, len(orders))
+	for _, o := range orders {
+		resp.Orders = append(resp.Orders, toOrderResp(o))
+	}
+	return resp, nil
+}
+
+// pageSize applies the default to a non-positive request and caps it at max
+func pageSize(requested, def, max int) int {
+	switch {
+	case requested <= 0:
+		return def
+	case requested > max:
+		return max
+	default:
+		return requested
+	}
+}
@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
+	"github.com/uwu-octane/antBackend/order/internal/event"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+)
+
+// outboxOf encodes an envelope into a single outbox row, the relay publishes it after the write commits.
+// The trace context and request id of ctx are stored as headers, the relay has no request context of its own.
+func outboxOf[T any](ctx context.Context, orderID string, env *eventbus.Envelope[T]) ([]*outbox.Message, error) {
+	payload, err := codec.MarshalEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+	msg := &outbox.Message{
+		EventId:      env.EventID,
+		EventType:    env.EventType,
+		PartitionKey: string(event.KeyForOrder(orderID)),
+		Payload:      payload,
+	}
+	if h := tracing.Headers(ctx); h != nil {
+		if msg.Headers, err = json.Marshal(h); err != nil {
+			return nil, err
+		}
+	}
+	return []*outbox.Message{msg}, nil
+}
+
+func toOrderResp(o *model.Order) *order.Order {
+	return &order.Order{
+		OrderId:   o.Id,
+		UserId:    o.UserId,
+		Amount:    o.Amount,
+		Currency:  o.Currency,
+		Status:    o.Status,
+		CreatedAt: o.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt: o.UpdatedAt.UTC().Format(time.RFC3339),
+		Version:   o.Version,
+	}
+}
+
+// encodePageToken hides the keyset cursor (the last order id of a page) from clients
+func encodePageToken(lastID string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastID))
+}
+
+// decodePageToken returns the order id to continue after, ok is false for a malformed token
+func decodePageToken(token string) (string, bool) {
+	if token == "" {
+		return "", true
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", false
+	}
+	if _, err := ulid.ParseStrict(string(b)); err != nil {
+		return "", false
+	}
+	return string(b), true
+}
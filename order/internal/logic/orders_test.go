@@ -0,0 +1,213 @@
+package logic
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/order/internal/config"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memOrders is an in-memory OrderModel, outbox rows written by the logic are kept in outbox
+type memOrders struct {
+	orders map[string]*model.Order
+	outbox []*outbox.Message
+	seq    uint64
+}
+
+func (m *memOrders) FindOne(_ context.Context, id, userId string) (*model.Order, error) {
+	o, ok := m.orders[id]
+	if !ok || o.UserId != userId {
+		return nil, model.ErrNotFound
+	}
+	cp := *o
+	return &cp, nil
+}
+
+func (m *memOrders) List(_ context.Context, f model.ListFilter) ([]*model.Order, error) {
+	var out []*model.Order
+	for _, o := range m.orders {
+		if o.UserId == f.UserId && (f.Status == "" || o.Status == f.Status) && (f.BeforeId == "" || o.Id < f.BeforeId) {
+			cp := *o
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Id > out[j].Id })
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func (m *memOrders) Insert(_ context.Context, data *model.Order, outbox model.OutboxFunc) (*model.Order, error) {
+	m.seq++
+	now := time.Now()
+	o := *data
+	o.Id = ulid.MustNew(m.seq, nil).String()
+	o.Status = model.StatusPending
+	o.Version = 1
+	o.CreatedAt, o.UpdatedAt = now, now
+	if err := m.write(outbox, nil, &o); err != nil {
+		return nil, err
+	}
+	m.orders[o.Id] = &o
+	cp := o
+	return &cp, nil
+}
+
+func (m *memOrders) Cancel(ctx context.Context, id, userId string, outbox model.OutboxFunc) (*model.Order, *model.Order, error) {
+	before, err := m.FindOne(ctx, id, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if before.Status == model.StatusCancelled {
+		return before, before, nil
+	}
+	after := *before
+	after.Status = model.StatusCancelled
+	after.Version++
+	after.UpdatedAt = time.Now()
+	if err := m.write(outbox, before, &after); err != nil {
+		return nil, nil, err
+	}
+	m.orders[id] = &after
+	return before, &after, nil
+}
+
+func (m *memOrders) write(outbox model.OutboxFunc, before, after *model.Order) error {
+	msgs, err := outbox(before, after)
+	if err != nil {
+		return err
+	}
+	m.outbox = append(m.outbox, msgs...)
+	return nil
+}
+
+func newTestSvc() (*svc.ServiceContext, *memOrders) {
+	orders := &memOrders{orders: make(map[string]*model.Order)}
+	return &svc.ServiceContext{
+		Config: config.Config{Orders: config.OrdersConf{DefaultPageSize: 2, MaxPageSize: 3, Currencies: []string{"EUR"}}},
+		Orders: orders,
+	}, orders
+}
+
+func TestCreateOrder(t *testing.T) {
+	svcCtx, orders := newTestSvc()
+	l := NewCreateOrderLogic(context.Background(), svcCtx)
+
+	for _, in := range []*order.CreateOrderReq{
+		{Amount: 100, Currency: "EUR"},
+		{UserId: "u-1", Amount: 0, Currency: "EUR"},
+		{UserId: "u-1", Amount: 100, Currency: "USD"},
+		{UserId: "u-1", Amount: 100, Currency: "euro"},
+	} {
+		_, err := l.CreateOrder(in)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), in.String())
+	}
+	assert.Empty(t, orders.outbox)
+
+	resp, err := l.CreateOrder(&order.CreateOrderReq{UserId: "u-1", Amount: 1250, Currency: " eur "})
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", resp.Currency)
+	assert.Equal(t, model.StatusPending, resp.Status)
+
+	require.Len(t, orders.outbox, 1)
+	msg := orders.outbox[0]
+	assert.Equal(t, eventbus.EventTypeOrderCreated, msg.EventType)
+	assert.Equal(t, resp.OrderId, msg.PartitionKey)
+	var env eventbus.Envelope[eventbus.OrderCreatedEvent]
+	require.NoError(t, codec.UnmarshalEnvelope(msg.Payload, &env))
+	assert.Equal(t, "u-1", env.Data.UserID)
+	assert.Equal(t, int64(1250), env.Data.Amount)
+}
+
+func TestOrders_ScopedToUser(t *testing.T) {
+	svcCtx, orders := newTestSvc()
+	ctx := context.Background()
+	created, err := NewCreateOrderLogic(ctx, svcCtx).CreateOrder(&order.CreateOrderReq{UserId: "u-1", Amount: 100, Currency: "EUR"})
+	require.NoError(t, err)
+
+	_, err = NewGetOrderLogic(ctx, svcCtx).GetOrder(&order.GetOrderReq{UserId: "u-2", OrderId: created.OrderId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = NewCancelOrderLogic(ctx, svcCtx).CancelOrder(&order.CancelOrderReq{UserId: "u-2", OrderId: created.OrderId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	list, err := NewListOrdersLogic(ctx, svcCtx).ListOrders(&order.ListOrdersReq{UserId: "u-2"})
+	require.NoError(t, err)
+	assert.Empty(t, list.Orders)
+	assert.Len(t, orders.outbox, 1)
+
+	got, err := NewGetOrderLogic(ctx, svcCtx).GetOrder(&order.GetOrderReq{UserId: "u-1", OrderId: created.OrderId})
+	require.NoError(t, err)
+	assert.Equal(t, created.OrderId, got.OrderId)
+}
+
+func TestCancelOrder_Idempotent(t *testing.T) {
+	svcCtx, orders := newTestSvc()
+	ctx := context.Background()
+	created, err := NewCreateOrderLogic(ctx, svcCtx).CreateOrder(&order.CreateOrderReq{UserId: "u-1", Amount: 100, Currency: "EUR"})
+	require.NoError(t, err)
+
+	l := NewCancelOrderLogic(ctx, svcCtx)
+	for range 2 {
+		resp, err := l.CancelOrder(&order.CancelOrderReq{UserId: "u-1", OrderId: created.OrderId, Reason: " changed my mind "})
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusCancelled, resp.Status)
+		assert.Equal(t, int64(2), resp.Version)
+	}
+
+	// the second cancel writes no event
+	require.Len(t, orders.outbox, 2)
+	var env eventbus.Envelope[eventbus.OrderCancelledEvent]
+	require.NoError(t, codec.UnmarshalEnvelope(orders.outbox[1].Payload, &env))
+	assert.Equal(t, eventbus.EventTypeOrderCancelled, env.EventType)
+	assert.Equal(t, created.OrderId, env.Data.OrderID)
+	assert.Equal(t, "changed my mind", env.Data.Reason)
+}
+
+func TestListOrders_Pages(t *testing.T) {
+	svcCtx, _ := newTestSvc()
+	ctx := context.Background()
+	var ids []string
+	for range 5 {
+		o, err := NewCreateOrderLogic(ctx, svcCtx).CreateOrder(&order.CreateOrderReq{UserId: "u-1", Amount: 100, Currency: "EUR"})
+		require.NoError(t, err)
+		ids = append([]string{o.OrderId}, ids...)
+	}
+
+	l := NewListOrdersLogic(ctx, svcCtx)
+	var got []string
+	token := ""
+	for {
+		resp, err := l.ListOrders(&order.ListOrdersReq{UserId: "u-1", PageToken: token})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(resp.Orders), 2, "default page size")
+		for _, o := range resp.Orders {
+			got = append(got, o.OrderId)
+		}
+		if token = resp.NextPageToken; token == "" {
+			break
+		}
+	}
+	assert.Equal(t, ids, got, "newest first, every order once")
+
+	resp, err := l.ListOrders(&order.ListOrdersReq{UserId: "u-1", PageSize: 50})
+	require.NoError(t, err)
+	assert.Len(t, resp.Orders, 3, "capped at MaxPageSize")
+
+	_, err = l.ListOrders(&order.ListOrdersReq{UserId: "u-1", PageToken: "not-a-token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = l.ListOrders(&order.ListOrdersReq{UserId: "u-1", Status: "shipped"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
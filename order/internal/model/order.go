@@ -0,0 +1,163 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	dbutil "github.com/uwu-octane/antBackend/common/db/util"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusCancelled = "cancelled"
+)
+
+var ErrNotFound = sqlx.ErrNotFound
+
+type Order struct {
+	Id        string    `db:"id"`
+	UserId    string    `db:"user_id"`
+	Amount    int64     `db:"amount"`
+	Currency  string    `db:"currency"`
+	Status    string    `db:"status"`
+	Version   int64     `db:"version"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ListFilter selects a page of one user's orders, newest first
+type ListFilter struct {
+	UserId string
+	Status string // empty matches every status
+	// BeforeId is the last id of the previous page, empty starts from the newest order
+	BeforeId string
+	Limit    int
+}
+
+type OrderModel interface {
+	// read only: replica, falls back to master per the read strategy.
+	// Every lookup is scoped to the owner, an order of another user is ErrNotFound.
+	FindOne(ctx context.Context, id, userId string) (*Order, error)
+	List(ctx context.Context, filter ListFilter) ([]*Order, error)
+	// write: master, outbox rows from the OutboxFunc are committed in the same transaction
+	Insert(ctx context.Context, data *Order, outbox OutboxFunc) (*Order, error)
+	// Cancel moves a pending order to cancelled and returns the row before and after the write.
+	// Cancelling a cancelled order changes nothing and outbox is not called.
+	Cancel(ctx context.Context, id, userId string, outbox OutboxFunc) (before *Order, after *Order, err error)
+}
+
+type defaultOrderModel struct {
+	replica  sqlx.SqlConn
+	master   sqlx.SqlConn
+	selector *dbutil.Selector
+}
+
+func NewOrdersModel(replica sqlx.SqlConn, master sqlx.SqlConn, selector *dbutil.Selector) *defaultOrderModel {
+	return &defaultOrderModel{
+		replica:  replica,
+		master:   master,
+		selector: selector,
+	}
+}
+
+const orderFields = "id, user_id, amount, currency, status, version, created_at, updated_at"
+
+func (m *defaultOrderModel) FindOne(ctx context.Context, id, userId string) (*Order, error) {
+	var result Order
+	const query = "SELECT " + orderFields + " FROM orders WHERE id = $1 AND user_id = $2 LIMIT 1"
+	err := m.selector.Do(ctx, func(ctx context.Context, conn sqlx.SqlConn) error {
+		var o Order
+		if err := conn.QueryRowCtx(ctx, &o, query, id, userId); err != nil {
+			return err
+		}
+		result = o
+		return nil
+	})
+	if err != nil {
+		return nil, notFoundIfInvalid(err)
+	}
+	return &result, nil
+}
+
+func (m *defaultOrderModel) List(ctx context.Context, filter ListFilter) ([]*Order, error) {
+	where := []string{"user_id = $1"}
+	args := []any{filter.UserId}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.BeforeId != "" {
+		// ulid ids sort by creation time
+		args = append(args, filter.BeforeId)
+		where = append(where, fmt.Sprintf("id < $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf("SELECT %s FROM orders WHERE %s ORDER BY id DESC LIMIT $%d", orderFields, strings.Join(where, " AND "), len(args))
+
+	var result []*Order
+	err := m.selector.Do(ctx, func(ctx context.Context, conn sqlx.SqlConn) error {
+		var orders []*Order
+		if err := conn.QueryRowsCtx(ctx, &orders, query, args...); err != nil {
+			return err
+		}
+		result = orders
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *defaultOrderModel) Insert(ctx context.Context, data *Order, outbox OutboxFunc) (*Order, error) {
+	var order Order
+	err := m.master.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		const query = "INSERT INTO orders (user_id, amount, currency, status) VALUES ($1, $2, $3, $4) RETURNING " + orderFields
+		if err := session.QueryRowCtx(ctx, &order, query, data.UserId, data.Amount, data.Currency, StatusPending); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, session, outbox, nil, &order)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (m *defaultOrderModel) Cancel(ctx context.Context, id, userId string, outbox OutboxFunc) (*Order, *Order, error) {
+	var before, after Order
+	err := m.master.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		const lockQuery = "SELECT " + orderFields + " FROM orders WHERE id = $1 AND user_id = $2 FOR UPDATE"
+		if err := session.QueryRowCtx(ctx, &before, lockQuery, id, userId); err != nil {
+			return err
+		}
+		if before.Status == StatusCancelled {
+			after = before
+			return nil
+		}
+
+		const query = "UPDATE orders SET status = $2, version = version + 1 WHERE id = $1 RETURNING " + orderFields
+		if err := session.QueryRowCtx(ctx, &after, query, id, StatusCancelled); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, session, outbox, &before, &after)
+	})
+	if err != nil {
+		return nil, nil, notFoundIfInvalid(err)
+	}
+	return &before, &after, nil
+}
+
+// notFoundIfInvalid maps a malformed order id (not a ulid) to ErrNotFound
+func notFoundIfInvalid(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "22P02" {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+const (
+	outboxTable = "order_outbox"
+	// outboxRelayLockKey is the advisory lock that elects a single relaying replica per poll
+	outboxRelayLockKey = "order_outbox_relay"
+)
+
+// OutboxFunc builds the outbox rows for an orders write from the row before and after it.
+// It runs inside the write transaction, returning an error rolls the write back.
+type OutboxFunc func(before, after *Order) ([]*outbox.Message, error)
+
+// NewOutboxModel returns the store the relay reads order_outbox through
+func NewOutboxModel(master sqlx.SqlConn) *outbox.PostgresStore {
+	return outbox.NewPostgresStore(master, outboxTable, outboxRelayLockKey)
+}
+
+func writeOutbox(ctx context.Context, session sqlx.Session, fn OutboxFunc, before, after *Order) error {
+	if fn == nil {
+		return nil
+	}
+	msgs, err := fn(before, after)
+	if err != nil {
+		return err
+	}
+	return outbox.Append(ctx, session, outboxTable, msgs)
+}
@@ -0,0 +1,125 @@
+package svc
+
+import (
+	"context"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
+	kpub "github.com/uwu-octane/antBackend/common/eventbus/publisher/kafka"
+
+	dbutil "github.com/uwu-octane/antBackend/common/db/util"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/order/internal/config"
+	"github.com/uwu-octane/antBackend/order/internal/event"
+	"github.com/uwu-octane/antBackend/order/internal/model"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+// shutdownTimeout 退出时等待异步发布确认的上限
+const shutdownTimeout = 10 * time.Second
+
+type ServiceContext struct {
+	Config            config.Config
+	Master            sqlx.SqlConn
+	Replica           sqlx.SqlConn
+	Orders            model.OrderModel
+	Outbox            outbox.Store
+	OrderEventsPusher *publisher.EventBusPublisher
+}
+
+func NewServiceContext(c config.Config) *ServiceContext {
+	master := sqlx.NewSqlConn(c.OrderDatabase.Driver, c.OrderDatabase.MasterDSN)
+	replica := sqlx.NewSqlConn(c.OrderDatabase.Driver, c.OrderDatabase.ReplicaDSN)
+
+	selector := dbutil.NewSelector(replica, master, c.OrderReadStrategy.FromReplica, c.OrderReadStrategy.FallbackToMasterOnReadError, nil)
+
+	return &ServiceContext{
+		Config:            c,
+		Master:            master,
+		Replica:           replica,
+		Orders:            model.NewOrdersModel(replica, master, selector),
+		Outbox:            model.NewOutboxModel(master),
+		OrderEventsPusher: orderEventsPusher(c),
+	}
+}
+
+// orderEventsPusher 创建订单事件的 Kafka 发布器；未配置 Kafka.Brokers 或创建失败时返回 nil，
+// outbox 中继不启动，事件留在 order_outbox 中
+func orderEventsPusher(c config.Config) *publisher.EventBusPublisher {
+	if len(c.Kafka.Brokers) == 0 {
+		logx.Infow("order events disabled, Kafka.Brokers is empty")
+		return nil
+	}
+	topics := eventbus.BuildTopics(eventbus.Env(c.Kafka.Env))
+	eventCodec, err := codec.ByName(c.KafkaOrderProducer.Codec)
+	if err != nil {
+		logx.Errorw("unknown order events codec", logx.Field("error", err))
+		return nil
+	}
+
+	opts := kpub.ProducerOptions{
+		Brokers:         c.Kafka.Brokers,
+		Acks:            c.KafkaOrderProducer.Acks,
+		Idempotent:      c.KafkaOrderProducer.Idempotent,
+		RetryMax:        c.KafkaOrderProducer.RetryMax,
+		Compression:     c.KafkaOrderProducer.Compression,
+		FlushBytes:      c.KafkaOrderProducer.FlushBytes,
+		FlushMessages:   c.KafkaOrderProducer.FlushMessages,
+		FlushFrequency:  time.Duration(c.KafkaOrderProducer.FlushFrequencyMs) * time.Millisecond,
+		MaxMessageBytes: c.KafkaOrderProducer.MaxMessageBytes,
+		EnableSASL:      c.KafkaOrderProducer.SASL.Enable,
+		SASLMechanism:   c.KafkaOrderProducer.SASL.Mechanism,
+		SASLUsername:    c.KafkaOrderProducer.SASL.Username,
+		SASLPassword:    c.KafkaOrderProducer.SASL.Password,
+		EnableTLS:       c.KafkaOrderProducer.TLS.Enable,
+		ClientID:        event.Producer,
+	}
+	ensureKafkaTopics(c, &opts, topics)
+	var pub publisher.Publisher
+	if c.KafkaOrderProducer.Async {
+		pub, err = kpub.NewAsyncSaramaPublisher(&opts, nil)
+	} else {
+		pub, err = kpub.NewSaramaPublisher(&opts)
+	}
+	if err != nil {
+		logx.Errorw("create kafka order events publisher failed", logx.Field("error", err))
+		return nil
+	}
+	return publisher.NewEventBusPublisher(pub, topics, publisher.WithCodec(eventCodec))
+}
+
+// Close 等待异步发布的事件得到确认后关闭发布器，在 rpc server 与 outbox 中继停止之后调用
+func (s *ServiceContext) Close() {
+	if s.OrderEventsPusher == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.OrderEventsPusher.Close(ctx); err != nil {
+		logx.Errorw("close order events publisher failed", logx.Field("error", err))
+	}
+}
+
+// ensureKafkaTopics 按 Kafka.Topics.AutoCreate 创建订单事件流的 topic；失败只记录日志，发送时由 broker 报错
+func ensureKafkaTopics(c config.Config, opts *kpub.ProducerOptions, topics eventbus.TopicSet) {
+	switch c.Kafka.Topics.AutoCreate {
+	case config.TopicAutoCreateNever:
+		return
+	case config.TopicAutoCreateDev:
+		if topics.Env != eventbus.EnvDev {
+			return
+		}
+	}
+
+	_, err := kpub.EnsureTopics(opts, []string{topics.OrderEvents()}, kpub.TopicSpec{
+		Partitions:        c.Kafka.Topics.Partitions,
+		ReplicationFactor: c.Kafka.Topics.ReplicationFactor,
+		RetentionMs:       c.Kafka.Topics.RetentionMs,
+	})
+	if err != nil {
+		logx.Errorw("ensure kafka order topics failed", logx.Field("error", err))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/uwu-octane/antBackend/api/v1/order"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/requestid"
+	"github.com/uwu-octane/antBackend/order/internal/config"
+	"github.com/uwu-octane/antBackend/order/internal/server"
+	"github.com/uwu-octane/antBackend/order/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/zeromicro/zero-contrib/zrpc/registry/consul"
+)
+
+var configFile = flag.String("f", "etc/order.yaml", "the config file")
+
+func main() {
+	flag.Parse()
+
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+	ctx := svc.NewServiceContext(c)
+
+	s := zrpc.MustNewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
+		order.RegisterOrderServiceServer(grpcServer, server.NewOrderServiceServer(ctx))
+
+		if c.Mode == service.DevMode || c.Mode == service.TestMode {
+			reflection.Register(grpcServer)
+		}
+	})
+	s.AddUnaryInterceptors(requestid.UnaryServerInterceptor)
+
+	if err := consul.RegisterService(c.ListenOn, c.Consul); err != nil {
+		log.Fatal(err)
+	}
+
+	// 先停止 rpc server 与中继，再关闭发布器
+	defer ctx.Close()
+	group := service.NewServiceGroup()
+	defer group.Stop()
+	group.Add(s)
+	if !c.Outbox.Disabled && ctx.OrderEventsPusher != nil {
+		group.Add(outbox.NewRelay("order", c.Outbox, ctx.Outbox, outbox.EventBus(ctx.OrderEventsPusher)))
+	}
+
+	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
+	group.Start()
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 
 	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/requestid"
 	"github.com/uwu-octane/antBackend/user/internal/config"
+	"github.com/uwu-octane/antBackend/user/internal/consumer"
 	"github.com/uwu-octane/antBackend/user/internal/logic"
 	"github.com/uwu-octane/antBackend/user/internal/server"
 	"github.com/uwu-octane/antBackend/user/internal/svc"
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"github.com/zeromicro/zero-contrib/zrpc/registry/consul"
@@ -29,14 +33,30 @@ func BuildUserRpcServer(configFile string) (service.Service, func(), error) {
 			reflection.Register(grpcServer)
 		}
 	})
+	s.AddUnaryInterceptors(requestid.UnaryServerInterceptor)
 
 	if err := consul.RegisterService(c.ListenOn, c.Consul); err != nil {
 		return nil, nil, err
 	}
 
 	cleanup := func() {
-
+		ctx.Close()
 	}
 
-	return s, cleanup, nil
+	// outbox relay、事件消费者与 rpc server 同生命周期
+	group := service.NewServiceGroup()
+	group.Add(s)
+	if !c.Outbox.Disabled && ctx.UserEventsPusher != nil {
+		group.Add(outbox.NewRelay("user", c.Outbox, ctx.Outbox, outbox.EventBus(ctx.UserEventsPusher)))
+	}
+	if c.EventBus.Backend != config.EventBackendKafka || len(c.KqUserEvents.Brokers) > 0 {
+		sub, err := consumer.NewUserEventsConsumer(ctx).NewSubscriber(c.KqUserEvents)
+		if err != nil {
+			// 与 publisher 一致：broker 不可用时降级运行，不阻塞 rpc 启动
+			logx.Errorw("create user events subscriber failed", logx.Field("error", err))
+		} else {
+			group.Add(sub)
+		}
+	}
+	return group, cleanup, nil
 }
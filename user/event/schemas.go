@@ -0,0 +1,42 @@
+package event
+
+import (
+	"encoding/json"
+
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/schema"
+)
+
+// 在 schema.Default 登记用户事件并路由到用户事件流；新增版本时同时登记上一版本到新版本的 upcaster
+func init() {
+	schema.Register[UserRegisteredEventV1](schema.Default, eventbus.EventTypeUserRegistered, 1)
+	schema.Register[UserRegisteredEvent](schema.Default, eventbus.EventTypeUserRegistered, UserRegisteredVersion)
+	schema.Default.RegisterUpcaster(eventbus.EventTypeUserRegistered, 1, upcastUserRegisteredV1)
+
+	schema.Register[UserUpdatedEvent](schema.Default, eventbus.EventTypeUserUpdated, 1)
+	schema.Register[UserDeletedEvent](schema.Default, eventbus.EventTypeUserDeleted, 1)
+
+	for _, t := range []string{
+		eventbus.EventTypeUserRegistered,
+		eventbus.EventTypeUserUpdated,
+		eventbus.EventTypeUserDeleted,
+	} {
+		eventbus.DefaultRoutes.Register(t, eventbus.StreamUserEvents)
+	}
+}
+
+// upcastUserRegisteredV1 v1 没有 username，升级后为空字符串
+func upcastUserRegisteredV1(data json.RawMessage) (json.RawMessage, error) {
+	var v1 UserRegisteredEventV1
+	if err := json.Unmarshal(data, &v1); err != nil {
+		return nil, err
+	}
+	return json.Marshal(UserRegisteredEvent{
+		UserID: v1.UserID,
+		Email:  v1.Email,
+		Profile: UserProfile{
+			DisplayName: v1.DisplayName,
+			AvatarURL:   v1.AvatarURL,
+		},
+	})
+}
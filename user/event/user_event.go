@@ -0,0 +1,99 @@
+// Package event 定义用户服务的事件 payload，并在 init 中登记其 schema 与路由；
+// 需要解码用户事件的工具（cmd/antctl、cmd/asyncapi）只需空导入本包，不必依赖用户服务本身。
+package event
+
+import eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+
+const (
+	// Producer 写入 Envelope.Producer
+	Producer = "user.rpc"
+)
+
+// UserRegisteredEventV1 是 user.registered 的 v1 payload，仅用于读取历史事件（见 schemas.go 的 upcaster）
+type UserRegisteredEventV1 struct {
+	UserID      string `json:"user_id"`
+	Email       string `json:"email" pii:"encrypt"`
+	DisplayName string `json:"display_name" pii:"encrypt"`
+	AvatarURL   string `json:"avatar_url,omitempty" pii:"redact"`
+}
+
+// UserRegisteredEvent 是 user.registered 的最新（v2）payload：新增 username，展示资料归入 profile
+type UserRegisteredEvent struct {
+	UserID   string      `json:"user_id"`
+	Username string      `json:"username"`
+	Email    string      `json:"email" pii:"encrypt"`
+	Profile  UserProfile `json:"profile"`
+}
+
+type UserProfile struct {
+	DisplayName string `json:"display_name" pii:"encrypt"`
+	AvatarURL   string `json:"avatar_url,omitempty" pii:"redact"`
+}
+
+const UserRegisteredVersion = 2
+
+func NewUserRegisteredEvent(userID, producer, traceID, username, email, displayName, avatarURL string) *eventbus.Envelope[UserRegisteredEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeUserRegistered,
+		UserRegisteredVersion,
+		producer,
+		traceID,
+		UserRegisteredEvent{
+			UserID:   userID,
+			Username: username,
+			Email:    email,
+			Profile: UserProfile{
+				DisplayName: displayName,
+				AvatarURL:   avatarURL,
+			},
+		},
+	)
+}
+
+type UserUpdatedFields struct {
+	Email       *string `json:"email,omitempty" pii:"encrypt"`
+	DisplayName *string `json:"display_name,omitempty" pii:"encrypt"`
+	AvatarURL   *string `json:"avatar_url,omitempty" pii:"redact"`
+}
+
+type UserUpdatedEvent struct {
+	UserID  string            `json:"user_id"`
+	Changes UserUpdatedFields `json:"changes"`
+	Reason  string            `json:"reason,omitempty"`
+}
+
+func NewUserUpdatedEvent(userID, producer, traceID string, changes UserUpdatedFields, reason string) *eventbus.Envelope[UserUpdatedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeUserUpdated,
+		1,
+		producer,
+		traceID,
+		UserUpdatedEvent{
+			UserID:  userID,
+			Changes: changes,
+			Reason:  reason,
+		},
+	)
+}
+
+type UserDeletedEvent struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func NewUserDeletedEvent(userID, producer, traceID, reason string) *eventbus.Envelope[UserDeletedEvent] {
+	return eventbus.NewEnvelope(
+		eventbus.EventTypeUserDeleted,
+		1,
+		producer,
+		traceID,
+		UserDeletedEvent{
+			UserID: userID,
+			Reason: reason,
+		},
+	)
+}
+
+func KeyForUser(userID string) []byte {
+	return []byte(userID)
+}
@@ -3,7 +3,10 @@ module github.com/uwu-octane/antBackend/user
 go 1.25.2
 
 require (
+	github.com/alicebob/miniredis/v2 v2.35.0
 	github.com/lib/pq v1.10.9
+	github.com/oklog/ulid v1.3.1
+	github.com/stretchr/testify v1.11.1
 	github.com/uwu-octane/antBackend/api v0.0.0
 	github.com/uwu-octane/antBackend/common v0.0.0-20251111205948-e856e9c512db
 	github.com/zeromicro/go-queue v1.2.2
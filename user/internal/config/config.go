@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
 	"github.com/zeromicro/go-queue/kq"
 	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/zrpc"
@@ -13,10 +14,73 @@ type Config struct {
 	UserDatabase     UserDatabase
 	UserRedis        redis.RedisKeyConf
 	UserReadStrategy UserReadStrategy
+	UserCache        UserCacheConf
+	UserBatch        UserBatchConf
+	UserList         UserListConf
 
+	EventBus          EventBusConf
 	Kafka             KafkaConf
 	KqUserEvents      kq.KqConf
 	KafkaUserProducer KafkaProducerConf
+	// Outbox 控制 user_outbox 中继：轮询、重试退避与已发送行的保留时间
+	Outbox     outbox.Conf
+	EventDedup EventDedupConf
+	EventRetry EventRetryConf
+	EventPII   EventPIIConf
+}
+
+const (
+	EventBackendKafka  = "kafka"
+	EventBackendRedis  = "redis"
+	EventBackendMemory = "memory"
+)
+
+// EventBusConf 选择用户事件的收发后端：kafka（默认）；redis 复用 UserRedis 的 Redis Streams；
+// memory 为进程内总线，事件不落盘，只用于本地开发与测试
+type EventBusConf struct {
+	Backend      string `json:",default=kafka,options=kafka|redis|memory"`
+	StreamPrefix string `json:",default=eventbus:"` // redis 后端 stream key 前缀
+	StreamMaxLen int64  `json:",default=100000"`    // redis 后端每个 stream 保留的近似条数
+	// ClaimMinIdleSeconds redis 后端接管其他副本闲置消息的阈值，需大于 handler 最长处理时间
+	ClaimMinIdleSeconds int `json:",default=300"`
+}
+
+// EventRetryConf 消费失败后的延迟重试层级（例如 1m、10m），用尽后进入 <topic>.dlq
+type EventRetryConf struct {
+	Delays []string `json:",optional"`
+}
+
+// EventDedupConf 消费侧按 EventID 去重（Redis），InFlightTTLSeconds 为 0 表示严格 at-most-once
+type EventDedupConf struct {
+	TTLSeconds         int64 `json:",default=86400"`
+	InFlightTTLSeconds int64 `json:",optional"`
+}
+
+// UserCacheConf 用户资料在 UserRedis 中的读穿缓存：TTL 由 go-zero cache 在 ±5% 内随机抖动，
+// 不存在的用户以占位符缓存 NotFoundTTLSeconds；写入与消费到的用户事件会删除对应缓存
+type UserCacheConf struct {
+	Disabled           bool `json:",optional"`
+	TTLSeconds         int  `json:",default=600"`
+	NotFoundTTLSeconds int  `json:",default=60"`
+}
+
+// UserBatchConf BatchGetUsers 单次请求的 ID 数量上限（去重后）
+type UserBatchConf struct {
+	MaxIds int `json:",default=100"`
+}
+
+// UserListConf 管理端 ListUsers / SearchUsers 的分页大小；SearchMinLength 为搜索词的最少字符数，
+// 短于 3 个字符时 trigram 索引无法生效
+type UserListConf struct {
+	DefaultPageSize int `json:",default=20"`
+	MaxPageSize     int `json:",default=100"`
+	SearchMinLength int `json:",default=3"`
+}
+
+// EventPIIConf 事件 payload 中 pii:"encrypt" 字段的加密 key 文件（见 codec.NewFileKeyProvider），
+// 同时用于消费时解密；为空时明文发布，消费时密文字段保持原样
+type EventPIIConf struct {
+	KeyFile string `json:",optional"`
 }
 
 type UserDatabase struct {
@@ -33,6 +97,22 @@ type UserReadStrategy struct {
 type KafkaConf struct {
 	Env     string
 	Brokers []string
+	Topics  KafkaTopicsConf
+}
+
+const (
+	TopicAutoCreateDev    = "dev"
+	TopicAutoCreateAlways = "always"
+	TopicAutoCreateNever  = "never"
+)
+
+// KafkaTopicsConf 启动时自动创建事件 topic（各 stream 及用户事件的重试/死信 topic）：
+// dev（默认）仅在 Kafka.Env 为 dev 时创建，always 总是创建，never 不创建
+type KafkaTopicsConf struct {
+	AutoCreate        string `json:",default=dev,options=dev|always|never"`
+	Partitions        int32  `json:",default=3"`
+	ReplicationFactor int16  `json:",default=1"`
+	RetentionMs       int64  `json:",optional"` // 0 使用 broker 默认
 }
 
 type KafkaProducerSASL struct {
@@ -55,6 +135,8 @@ type KafkaProducerConf struct {
 	FlushMessages    int
 	FlushFrequencyMs int
 	MaxMessageBytes  int
+	Codec            string `json:",default=json,options=json|protobuf|avro"` // 事件编码，随 content-type header 发送
+	Async            bool   `json:",optional"`                                // sarama AsyncProducer 按 Flush* 批量发送，outbox 中继并行发送不同 key 的事件
 	SASL             KafkaProducerSASL
 	TLS              KafkaProducerTLS
 }
@@ -0,0 +1,158 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uwu-octane/antBackend/common/eventbus/dedup"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/retry"
+	"github.com/uwu-octane/antBackend/common/eventbus/subscriber"
+	ksub "github.com/uwu-octane/antBackend/common/eventbus/subscriber/kafka"
+	substream "github.com/uwu-octane/antBackend/common/eventbus/subscriber/redisstream"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
+	"github.com/uwu-octane/antBackend/user/internal/config"
+	"github.com/uwu-octane/antBackend/user/event"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+	"github.com/zeromicro/go-queue/kq"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// UserEventsConsumer 消费 user.rpc 自己发布的用户事件流（KqUserEvents），
+// 删除对应用户的资料缓存，并记录 outbox -> kafka -> consumer 链路的端到端延迟。
+type UserEventsConsumer struct {
+	svcCtx *svc.ServiceContext
+}
+
+func NewUserEventsConsumer(svcCtx *svc.ServiceContext) *UserEventsConsumer {
+	return &UserEventsConsumer{svcCtx: svcCtx}
+}
+
+// Router 注册按 EventType 的强类型 handler
+func (c *UserEventsConsumer) Router() *subscriber.Router {
+	r := subscriber.NewRouter()
+	subscriber.Handle(r, eventbus.EventTypeUserRegistered, c.onUserRegistered)
+	subscriber.Handle(r, eventbus.EventTypeUserUpdated, c.onUserUpdated)
+	subscriber.Handle(r, eventbus.EventTypeUserDeleted, c.onUserDeleted)
+	return r
+}
+
+// NewSubscriber 按 EventBus.Backend 创建订阅者，消费组与并发（Processors，每个分区/stream）取自 KqUserEvents。
+// 主 topic 由 event.BuildTopics 决定，同一消费组同时订阅各级重试 topic；
+// 每条消息以 headers 中的 traceparent 为父 span 开始消费 span，按 EventID 去重后分发，失败转投重试 topic / DLQ。
+func (c *UserEventsConsumer) NewSubscriber(conf kq.KqConf) (subscriber.Subscriber, error) {
+	cfg := c.svcCtx.Config
+	topic := eventbus.BuildTopics(eventbus.Env(cfg.Kafka.Env)).UserEvents()
+
+	store := dedup.NewRedisStore(c.svcCtx.Redis, dedup.RedisOptions{
+		KeyPrefix:   cfg.UserRedis.Key + "dedup:",
+		TTL:         time.Duration(cfg.EventDedup.TTLSeconds) * time.Second,
+		InFlightTTL: time.Duration(cfg.EventDedup.InFlightTTLSeconds) * time.Second,
+	})
+	handler := tracing.Middleware(dedup.Middleware(store, conf.Group, c.Router().Dispatch))
+	topics := []string{topic}
+
+	if c.svcCtx.UserEventsPusher != nil {
+		delays, err := parseDelays(cfg.EventRetry.Delays)
+		if err != nil {
+			return nil, err
+		}
+		policy := retry.NewPolicy(topic, delays, c.svcCtx.UserEventsPusher.Pub)
+		handler = policy.Wrap(handler)
+		topics = policy.Topics()
+	} else {
+		logx.Infow("user events publisher not available, failed events are only logged")
+	}
+
+	switch cfg.EventBus.Backend {
+	case config.EventBackendMemory:
+		return c.svcCtx.MemoryBus.NewSubscriber(conf.Group, topics, conf.Processors, handler, nil), nil
+	case config.EventBackendRedis:
+		return substream.NewSubscriber(c.svcCtx.Redis, &substream.Options{
+			KeyPrefix:    cfg.EventBus.StreamPrefix,
+			Group:        conf.Group,
+			Topics:       topics,
+			Concurrency:  conf.Processors,
+			ClaimMinIdle: time.Duration(cfg.EventBus.ClaimMinIdleSeconds) * time.Second,
+		}, handler, nil)
+	}
+
+	opts := ksub.ConsumerOptions{
+		Brokers:       conf.Brokers,
+		Group:         conf.Group,
+		Topics:        topics,
+		Offset:        conf.Offset,
+		Concurrency:   conf.Processors,
+		EnableSASL:    conf.Username != "",
+		SASLMechanism: "plain",
+		SASLUsername:  conf.Username,
+		SASLPassword:  conf.Password,
+		EnableTLS:     conf.CaFile != "",
+	}
+	return ksub.NewSaramaSubscriber(&opts, handler, nil)
+}
+
+func parseDelays(values []string) ([]time.Duration, error) {
+	delays := make([]time.Duration, 0, len(values))
+	for _, v := range values {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid EventRetry delay %q", v)
+		}
+		delays = append(delays, d)
+	}
+	return delays, nil
+}
+
+func (c *UserEventsConsumer) onUserRegistered(ctx context.Context, env *eventbus.Envelope[event.UserRegisteredEvent], msg *subscriber.Message) error {
+	// 清除副本延迟期间可能写入的“用户不存在”占位符
+	if err := c.invalidateProfile(ctx, env.Data.UserID); err != nil {
+		return err
+	}
+	// v1 事件已被 schema upcaster 升级为最新结构，历史事件的 username 为空
+	fields := consumedFields(env.EventType, env.EventID, env.Data.UserID, env.OccurredAt, msg)
+	fields = append(fields, logx.Field("username", env.Data.Username))
+	logx.WithContext(ctx).Infow("user event consumed", fields...)
+	return nil
+}
+
+func (c *UserEventsConsumer) onUserUpdated(ctx context.Context, env *eventbus.Envelope[event.UserUpdatedEvent], msg *subscriber.Message) error {
+	if err := c.invalidateProfile(ctx, env.Data.UserID); err != nil {
+		return err
+	}
+	fields := consumedFields(env.EventType, env.EventID, env.Data.UserID, env.OccurredAt, msg)
+	fields = append(fields, logx.Field("reason", env.Data.Reason))
+	logx.WithContext(ctx).Infow("user event consumed", fields...)
+	return nil
+}
+
+func (c *UserEventsConsumer) onUserDeleted(ctx context.Context, env *eventbus.Envelope[event.UserDeletedEvent], msg *subscriber.Message) error {
+	if err := c.invalidateProfile(ctx, env.Data.UserID); err != nil {
+		return err
+	}
+	fields := consumedFields(env.EventType, env.EventID, env.Data.UserID, env.OccurredAt, msg)
+	fields = append(fields, logx.Field("reason", env.Data.Reason))
+	logx.WithContext(ctx).Infow("user event consumed", fields...)
+	return nil
+}
+
+// invalidateProfile 在事件被消费时再次删除资料缓存：写入时已删除一次，
+// 这里覆盖从延迟的只读副本回填了旧资料的缓存；缓存由各副本共享，任一副本消费即可
+func (c *UserEventsConsumer) invalidateProfile(ctx context.Context, userID string) error {
+	if userID == "" {
+		return nil
+	}
+	return c.svcCtx.Users.InvalidateCache(ctx, userID)
+}
+
+func consumedFields(eventType, eventID, userID string, occurredAt time.Time, msg *subscriber.Message) []logx.LogField {
+	return []logx.LogField{
+		logx.Field("event_type", eventType),
+		logx.Field("event_id", eventID),
+		logx.Field("user_id", userID),
+		logx.Field("partition", msg.Partition),
+		logx.Field("offset", msg.Offset),
+		logx.Field("lag_ms", time.Since(occurredAt).Milliseconds()),
+	}
+}
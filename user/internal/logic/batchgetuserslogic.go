@@ -0,0 +1,72 @@
+package logic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type BatchGetUsersLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewBatchGetUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BatchGetUsersLogic {
+	return &BatchGetUsersLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *BatchGetUsersLogic) BatchGetUsers(in *user.BatchGetUsersReq) (*user.BatchGetUsersResp, error) {
+	ids := uniqueIDs(in.GetUserIds())
+	if len(ids) == 0 {
+		return &user.BatchGetUsersResp{}, nil
+	}
+	if max := l.svcCtx.Config.UserBatch.MaxIds; len(ids) > max {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d user_ids per request", max)
+	}
+
+	users, err := l.svcCtx.Users.FindMany(l.ctx, ids)
+	if err != nil {
+		l.Errorf("failed to batch get users: %v", err)
+		return nil, err
+	}
+
+	found := make(map[string]*user.GetUserInfoResp, len(users))
+	for _, u := range users {
+		found[u.Id] = toUserInfoResp(u)
+	}
+	resp := &user.BatchGetUsersResp{Users: make([]*user.GetUserInfoResp, 0, len(found))}
+	for _, id := range ids {
+		if u, ok := found[id]; ok {
+			resp.Users = append(resp.Users, u)
+		} else {
+			resp.NotFound = append(resp.NotFound, id)
+		}
+	}
+	return resp, nil
+}
+
+// uniqueIDs trims the ids and drops empty and repeated ones, keeping the first occurrence
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/config"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// batchUsers is a UserModel.FindMany stub, it answers in reverse id order
+// (FindMany promises no order) and keeps the ids of every call.
+type batchUsers struct {
+	model.UserModel
+	users map[string]*model.User
+	calls [][]string
+	err   error
+}
+
+func (m *batchUsers) FindMany(_ context.Context, ids []string) ([]*model.User, error) {
+	m.calls = append(m.calls, ids)
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []*model.User
+	for i := len(ids) - 1; i >= 0; i-- {
+		if u, ok := m.users[ids[i]]; ok {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func newBatchSvc(maxIds int) (*svc.ServiceContext, *batchUsers, []string) {
+	users := &batchUsers{users: map[string]*model.User{}}
+	var ids []string
+	for i, name := range []string{"alice", "bob", "carol"} {
+		id := ulid.MustNew(uint64(i+1), nil).String()
+		users.users[id] = &model.User{Id: id, Username: name}
+		ids = append(ids, id)
+	}
+	return &svc.ServiceContext{
+		Config: config.Config{UserBatch: config.UserBatchConf{MaxIds: maxIds}},
+		Users:  users,
+	}, users, ids
+}
+
+func usernames(resp *user.BatchGetUsersResp) []string {
+	var out []string
+	for _, u := range resp.GetUsers() {
+		out = append(out, u.GetUsername())
+	}
+	return out
+}
+
+func TestBatchGetUsers(t *testing.T) {
+	svcCtx, _, ids := newBatchSvc(10)
+	alice, bob, carol := ids[0], ids[1], ids[2]
+	unknown := ulid.MustNew(99, nil).String()
+
+	tests := []struct {
+		name         string
+		ids          []string
+		wantUsers    []string
+		wantNotFound []string
+		wantQueried  []string
+	}{
+		{
+			name:        "users come back in request order",
+			ids:         []string{carol, alice, bob},
+			wantUsers:   []string{"carol", "alice", "bob"},
+			wantQueried: []string{carol, alice, bob},
+		},
+		{
+			name:        "repeated and blank ids are dropped",
+			ids:         []string{bob, "", " " + bob + " ", alice, "  ", bob},
+			wantUsers:   []string{"bob", "alice"},
+			wantQueried: []string{bob, alice},
+		},
+		{
+			name:         "unknown and malformed ids go to not_found in request order",
+			ids:          []string{"not-a-ulid", alice, unknown},
+			wantUsers:    []string{"alice"},
+			wantNotFound: []string{"not-a-ulid", unknown},
+			wantQueried:  []string{"not-a-ulid", alice, unknown},
+		},
+		{
+			name: "no ids is an empty response without a lookup",
+			ids:  []string{"", " "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := svcCtx.Users.(*batchUsers)
+			stub.calls = nil
+
+			resp, err := NewBatchGetUsersLogic(context.Background(), svcCtx).BatchGetUsers(&user.BatchGetUsersReq{UserIds: tt.ids})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUsers, usernames(resp))
+			assert.Equal(t, tt.wantNotFound, resp.GetNotFound())
+			if tt.wantQueried == nil {
+				assert.Empty(t, stub.calls)
+			} else {
+				assert.Equal(t, [][]string{tt.wantQueried}, stub.calls, "one lookup with the deduplicated ids")
+			}
+		})
+	}
+}
+
+func TestBatchGetUsers_MaxIds(t *testing.T) {
+	svcCtx, stub, ids := newBatchSvc(2)
+	l := NewBatchGetUsersLogic(context.Background(), svcCtx)
+
+	_, err := l.BatchGetUsers(&user.BatchGetUsersReq{UserIds: ids})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Empty(t, stub.calls)
+
+	// the limit applies after deduplication
+	resp, err := l.BatchGetUsers(&user.BatchGetUsersReq{UserIds: []string{ids[0], ids[1], ids[0], ids[1]}})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetUsers(), 2)
+}
+
+func TestBatchGetUsers_LookupError(t *testing.T) {
+	svcCtx, stub, ids := newBatchSvc(10)
+	stub.err = errors.New("replica down")
+
+	_, err := NewBatchGetUsersLogic(context.Background(), svcCtx).BatchGetUsers(&user.BatchGetUsersReq{UserIds: ids})
+	assert.ErrorIs(t, err, stub.err)
+}
+
+func TestUniqueIDs(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want []string
+	}{
+		{nil, []string{}},
+		{[]string{"a", "b", "a"}, []string{"a", "b"}},
+		{[]string{" a", "a ", ""}, []string{"a"}},
+		{[]string{"b", "a", "b", "c"}, []string{"b", "a", "c"}},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, uniqueIDs(tt.in))
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/user/event"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CreateUserLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateUserLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateUserLogic {
+	return &CreateUserLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *CreateUserLogic) CreateUser(in *user.CreateUserReq) (*user.GetUserInfoResp, error) {
+	username := strings.TrimSpace(in.GetUsername())
+	email := strings.ToLower(strings.TrimSpace(in.GetEmail()))
+	if username == "" || email == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and email are required")
+	}
+
+	u, err := l.svcCtx.Users.Insert(l.ctx, &model.User{
+		Id:          strings.TrimSpace(in.GetUserId()),
+		Username:    username,
+		Email:       nullString(email),
+		DisplayName: nullString(strings.TrimSpace(in.GetDisplayName())),
+		AvatarUrl:   nullString(strings.TrimSpace(in.GetAvatarUrl())),
+	}, func(_, after *model.User) ([]*outbox.Message, error) {
+		return outboxOf(l.ctx, after.Id, event.NewUserRegisteredEvent(
+			after.Id, event.Producer, trace.TraceIDFromContext(l.ctx),
+			after.Username, nullable(after.Email), nullable(after.DisplayName), nullable(after.AvatarUrl),
+		))
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrDuplicate) {
+			return nil, status.Error(codes.AlreadyExists, "username or email already exists")
+		}
+		l.Errorf("failed to create user: %v", err)
+		return nil, err
+	}
+
+	return toUserInfoResp(u), nil
+}
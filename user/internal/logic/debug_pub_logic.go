@@ -4,9 +4,10 @@ import (
 	"context"
 
 	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
-	"github.com/uwu-octane/antBackend/user/internal/event"
+	"github.com/uwu-octane/antBackend/user/event"
 	"github.com/uwu-octane/antBackend/user/internal/svc"
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
 )
 
 type DebugPubLogic struct {
@@ -32,8 +33,9 @@ func (l *DebugPubLogic) Test() error {
 	key := event.KeyForUser(userID)
 	evt := event.NewUserRegisteredEvent(
 		userID,
-		"", // traceID，可选
-		"user-1",
+		event.Producer,
+		trace.TraceIDFromContext(l.ctx),
+		"demo-user",
 		"user@example.com",
 		"DemoUser",
 		"",
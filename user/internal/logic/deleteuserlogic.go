@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/user/event"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type DeleteUserLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewDeleteUserLogic(ctx context.Context, svcCtx *svc.ServiceContext) *DeleteUserLogic {
+	return &DeleteUserLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+func (l *DeleteUserLogic) DeleteUser(in *user.DeleteUserReq) (*user.DeleteUserResp, error) {
+	if in.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	err := l.svcCtx.Users.Delete(l.ctx, in.GetUserId(), func(before, _ *model.User) ([]*outbox.Message, error) {
+		return outboxOf(l.ctx, before.Id, event.NewUserDeletedEvent(
+			before.Id, event.Producer, trace.TraceIDFromContext(l.ctx), in.GetReason(),
+		))
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		l.Errorf("failed to delete user: %v", err)
+		return nil, err
+	}
+
+	return &user.DeleteUserResp{Ok: true}, nil
+}
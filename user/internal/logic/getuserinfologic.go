@@ -3,11 +3,15 @@ package logic
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/model"
 	"github.com/uwu-octane/antBackend/user/internal/svc"
 
 	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type GetUserInfoLogic struct {
@@ -27,6 +31,9 @@ func NewGetUserInfoLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetUs
 func (l *GetUserInfoLogic) GetUserInfo(in *user.GetUserInfoReq) (*user.GetUserInfoResp, error) {
 	u, err := l.svcCtx.Users.FindOne(l.ctx, in.GetUserId())
 	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
 		l.Errorf("failed to find user: %v", err)
 		return nil, err
 	}
@@ -37,6 +44,7 @@ func (l *GetUserInfoLogic) GetUserInfo(in *user.GetUserInfoReq) (*user.GetUserIn
 		Email:       nullable(u.Email),
 		DisplayName: nullable(u.DisplayName),
 		AvatarUrl:   nullable(u.AvatarUrl),
+		Version:     u.Version,
 	}, nil
 }
 
@@ -0,0 +1,44 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ListUsersLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListUsersLogic {
+	return &ListUsersLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// ListUsers pages through all users, the caller is an admin checked by the gateway
+func (l *ListUsersLogic) ListUsers(in *user.ListUsersReq) (*user.ListUsersResp, error) {
+	resp, err := listUsers(l.ctx, l.svcCtx, listRequest{
+		filter:    in.GetFilter(),
+		sortBy:    in.GetSortBy(),
+		sortOrder: in.GetSortOrder(),
+		pageSize:  in.GetPageSize(),
+		pageToken: in.GetPageToken(),
+	})
+	if err != nil {
+		if status.Code(err) != codes.InvalidArgument {
+			l.Errorf("failed to list users: %v", err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type SearchUsersLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSearchUsersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SearchUsersLogic {
+	return &SearchUsersLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// SearchUsers is ListUsers restricted to users whose username, email or display name contains query
+func (l *SearchUsersLogic) SearchUsers(in *user.SearchUsersReq) (*user.ListUsersResp, error) {
+	query := strings.TrimSpace(in.GetQuery())
+	if min := l.svcCtx.Config.UserList.SearchMinLength; utf8.RuneCountInString(query) < min {
+		return nil, status.Errorf(codes.InvalidArgument, "query must have at least %d characters", min)
+	}
+	resp, err := listUsers(l.ctx, l.svcCtx, listRequest{
+		query:     query,
+		filter:    in.GetFilter(),
+		sortBy:    in.GetSortBy(),
+		sortOrder: in.GetSortOrder(),
+		pageSize:  in.GetPageSize(),
+		pageToken: in.GetPageToken(),
+	})
+	if err != nil {
+		if status.Code(err) != codes.InvalidArgument {
+			l.Errorf("failed to search users: %v", err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+package logic
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/user/event"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UpdateUserLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUpdateUserLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateUserLogic {
+	return &UpdateUserLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// UpdateUser writes only the fields selected by update_mask (or present in the request),
+// the UserUpdated event (outbox, same transaction) carries the fields whose stored value actually changed.
+func (l *UpdateUserLogic) UpdateUser(in *user.UpdateUserReq) (*user.GetUserInfoResp, error) {
+	if in.GetUserId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	patch, err := buildUserPatch(in)
+	if err != nil {
+		return nil, err
+	}
+
+	_, after, err := l.svcCtx.Users.Update(l.ctx, in.GetUserId(), patch, in.GetExpectedVersion(), func(before, after *model.User) ([]*outbox.Message, error) {
+		changes, ok := diffUser(before, after)
+		if !ok {
+			return nil, nil
+		}
+		return outboxOf(l.ctx, after.Id, event.NewUserUpdatedEvent(
+			after.Id, event.Producer, trace.TraceIDFromContext(l.ctx), changes, in.GetReason(),
+		))
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			return nil, status.Error(codes.NotFound, "user not found")
+		case errors.Is(err, model.ErrDuplicate):
+			return nil, status.Error(codes.AlreadyExists, "email already exists")
+		case errors.Is(err, model.ErrVersionConflict):
+			return nil, status.Error(codes.Aborted, "user was modified concurrently, reload and retry")
+		}
+		l.Errorf("failed to update user: %v", err)
+		return nil, err
+	}
+
+	return toUserInfoResp(after), nil
+}
+
+const (
+	pathEmail       = "email"
+	pathDisplayName = "display_name"
+	pathAvatarUrl   = "avatar_url"
+)
+
+// buildUserPatch turns the request into a patch. With an update_mask every listed path is written
+// (an unset value clears it), without one only the fields that are set are written.
+func buildUserPatch(in *user.UpdateUserReq) (model.UserPatch, error) {
+	var patch model.UserPatch
+	selected := map[string]bool{
+		pathEmail:       in.Email != nil,
+		pathDisplayName: in.DisplayName != nil,
+		pathAvatarUrl:   in.AvatarUrl != nil,
+	}
+	if paths := in.GetUpdateMask().GetPaths(); len(paths) > 0 {
+		selected = map[string]bool{}
+		for _, p := range paths {
+			switch p {
+			case pathEmail, pathDisplayName, pathAvatarUrl:
+				selected[p] = true
+			default:
+				return patch, status.Errorf(codes.InvalidArgument, "unknown update_mask path %q", p)
+			}
+		}
+	}
+
+	if selected[pathEmail] {
+		email := strings.ToLower(strings.TrimSpace(in.GetEmail()))
+		if email == "" {
+			return patch, status.Error(codes.InvalidArgument, "email cannot be empty")
+		}
+		patch.Email = &email
+	}
+	if selected[pathDisplayName] {
+		v := strings.TrimSpace(in.GetDisplayName())
+		patch.DisplayName = &v
+	}
+	if selected[pathAvatarUrl] {
+		v := strings.TrimSpace(in.GetAvatarUrl())
+		patch.AvatarUrl = &v
+	}
+	return patch, nil
+}
+
+// diffUser fills UserUpdatedFields with the new value of every column that differs
+func diffUser(before, after *model.User) (event.UserUpdatedFields, bool) {
+	var changes event.UserUpdatedFields
+	changed := false
+	if before.Email != after.Email {
+		v := nullable(after.Email)
+		changes.Email, changed = &v, true
+	}
+	if before.DisplayName != after.DisplayName {
+		v := nullable(after.DisplayName)
+		changes.DisplayName, changed = &v, true
+	}
+	if before.AvatarUrl != after.AvatarUrl {
+		v := nullable(after.AvatarUrl)
+		changes.AvatarURL, changed = &v, true
+	}
+	return changes, changed
+}
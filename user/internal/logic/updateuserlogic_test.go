@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/event"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestBuildUserPatch(t *testing.T) {
+	mask := func(paths ...string) *fieldmaskpb.FieldMask { return &fieldmaskpb.FieldMask{Paths: paths} }
+
+	tests := []struct {
+		name string
+		in   *user.UpdateUserReq
+		want model.UserPatch
+		code codes.Code
+	}{
+		{
+			name: "no mask writes set fields only",
+			in:   &user.UpdateUserReq{DisplayName: proto.String(" Alice ")},
+			want: model.UserPatch{DisplayName: proto.String("Alice")},
+		},
+		{
+			name: "no mask and no fields is an empty patch",
+			in:   &user.UpdateUserReq{},
+			want: model.UserPatch{},
+		},
+		{
+			name: "no mask normalizes email",
+			in:   &user.UpdateUserReq{Email: proto.String(" Alice@Example.COM ")},
+			want: model.UserPatch{Email: proto.String("alice@example.com")},
+		},
+		{
+			name: "mask path with unset field clears it",
+			in:   &user.UpdateUserReq{UpdateMask: mask(pathAvatarUrl)},
+			want: model.UserPatch{AvatarUrl: proto.String("")},
+		},
+		{
+			name: "mask limits the written fields",
+			in: &user.UpdateUserReq{
+				DisplayName: proto.String("Alice"),
+				AvatarUrl:   proto.String("https://cdn/a.jpg"),
+				UpdateMask:  mask(pathDisplayName),
+			},
+			want: model.UserPatch{DisplayName: proto.String("Alice")},
+		},
+		{
+			name: "unknown mask path is rejected",
+			in:   &user.UpdateUserReq{DisplayName: proto.String("Alice"), UpdateMask: mask(pathDisplayName, "username")},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "email cannot be cleared through the mask",
+			in:   &user.UpdateUserReq{UpdateMask: mask(pathEmail)},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "blank email is rejected without a mask",
+			in:   &user.UpdateUserReq{Email: proto.String("  ")},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := buildUserPatch(tt.in)
+			if tt.code != codes.OK {
+				assert.Equal(t, tt.code, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, patch)
+		})
+	}
+}
+
+func TestDiffUser(t *testing.T) {
+	str := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+	base := model.User{
+		Id:          "u1",
+		Email:       str("alice@example.com"),
+		DisplayName: str("Alice"),
+		AvatarUrl:   str("https://cdn/a.jpg"),
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(u *model.User)
+		want    event.UserUpdatedFields
+		changed bool
+	}{
+		{
+			name:   "identical rows report nothing",
+			mutate: func(u *model.User) {},
+		},
+		{
+			name:   "non-column fields are ignored",
+			mutate: func(u *model.User) { u.Version, u.UpdatedAt = 2, "later" },
+		},
+		{
+			name:    "only the changed column is reported",
+			mutate:  func(u *model.User) { u.DisplayName = str("Alicia") },
+			want:    event.UserUpdatedFields{DisplayName: proto.String("Alicia")},
+			changed: true,
+		},
+		{
+			name:    "cleared column reports an empty value",
+			mutate:  func(u *model.User) { u.AvatarUrl = sql.NullString{} },
+			want:    event.UserUpdatedFields{AvatarURL: proto.String("")},
+			changed: true,
+		},
+		{
+			name: "every changed column is reported",
+			mutate: func(u *model.User) {
+				u.Email = str("alicia@example.com")
+				u.AvatarUrl = str("https://cdn/b.jpg")
+			},
+			want: event.UserUpdatedFields{
+				Email:     proto.String("alicia@example.com"),
+				AvatarURL: proto.String("https://cdn/b.jpg"),
+			},
+			changed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := base, base
+			tt.mutate(&after)
+			changes, changed := diffUser(&before, &after)
+			assert.Equal(t, tt.changed, changed)
+			assert.Equal(t, tt.want, changes)
+		})
+	}
+}
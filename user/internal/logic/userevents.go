@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/eventbus/tracing"
+	"github.com/uwu-octane/antBackend/user/event"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+)
+
+// outboxMessage encodes an envelope into an outbox row, the relay publishes it after the write commits.
+// The trace context and request id of ctx are stored as headers, the relay has no request context of its own.
+func outboxMessage[T any](ctx context.Context, userID string, env *eventbus.Envelope[T]) (*outbox.Message, error) {
+	payload, err := codec.MarshalEnvelope(env)
+	if err != nil {
+		return nil, err
+	}
+	msg := &outbox.Message{
+		EventId:      env.EventID,
+		EventType:    env.EventType,
+		PartitionKey: string(event.KeyForUser(userID)),
+		Payload:      payload,
+	}
+	if h := tracing.Headers(ctx); h != nil {
+		if msg.Headers, err = json.Marshal(h); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// outboxOf wraps a single envelope as the OutboxFunc result
+func outboxOf[T any](ctx context.Context, userID string, env *eventbus.Envelope[T]) ([]*outbox.Message, error) {
+	msg, err := outboxMessage(ctx, userID, env)
+	if err != nil {
+		return nil, err
+	}
+	return []*outbox.Message{msg}, nil
+}
+
+func toUserInfoResp(u *model.User) *user.GetUserInfoResp {
+	return &user.GetUserInfoResp{
+		UserId:      u.Id,
+		Username:    u.Username,
+		Email:       nullable(u.Email),
+		DisplayName: nullable(u.DisplayName),
+		AvatarUrl:   nullable(u.AvatarUrl),
+		Version:     u.Version,
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
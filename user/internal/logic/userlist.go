@@ -0,0 +1,179 @@
+package logic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errSortChanged = errors.New("page_token was issued for another sort")
+
+// listRequest is the part shared by ListUsersReq and SearchUsersReq
+type listRequest struct {
+	query     string
+	filter    *user.UserFilter
+	sortBy    string
+	sortOrder string
+	pageSize  int32
+	pageToken string
+}
+
+// pageToken is the keyset cursor behind next_page_token, it carries the sort so a token
+// cannot continue a listing in a different order
+type pageToken struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d"`
+	Key    string `json:"k"`
+	Id     string `json:"i"`
+}
+
+// listUsers validates req and returns one page of users plus the token of the next page
+func listUsers(ctx context.Context, svcCtx *svc.ServiceContext, req listRequest) (*user.ListUsersResp, error) {
+	filter, err := toListFilter(req)
+	if err != nil {
+		return nil, err
+	}
+	cfg := svcCtx.Config.UserList
+	size := pageSize(int(req.pageSize), cfg.DefaultPageSize, cfg.MaxPageSize)
+	// one extra row tells whether there is a next page
+	filter.Limit = size + 1
+
+	users, err := svcCtx.Users.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &user.ListUsersResp{}
+	if len(users) > size {
+		users = users[:size]
+		resp.NextPageToken = encodePageToken(filter, users[size-1])
+	}
+	resp.Users = make([]*user.AdminUser, 0, len(users))
+	for _, u := range users {
+		resp.Users = append(resp.Users, toAdminUser(u))
+	}
+	return resp, nil
+}
+
+func toListFilter(req listRequest) (model.ListFilter, error) {
+	f := req.filter
+	filter := model.ListFilter{
+		Query:          strings.TrimSpace(req.query),
+		UsernamePrefix: strings.TrimSpace(f.GetUsernamePrefix()),
+		EmailPrefix:    strings.TrimSpace(f.GetEmailPrefix()),
+		Status:         f.GetStatus(),
+	}
+	switch filter.Status {
+	case "", model.StatusActive, model.StatusDisabled:
+	default:
+		return filter, status.Errorf(codes.InvalidArgument, "unknown status %q", filter.Status)
+	}
+
+	var err error
+	if filter.CreatedAfter, err = parseTime(f.GetCreatedAfter()); err != nil {
+		return filter, status.Error(codes.InvalidArgument, "created_after must be RFC3339")
+	}
+	if filter.CreatedBefore, err = parseTime(f.GetCreatedBefore()); err != nil {
+		return filter, status.Error(codes.InvalidArgument, "created_before must be RFC3339")
+	}
+
+	switch req.sortBy {
+	case "", model.SortByCreatedAt:
+		filter.SortBy = model.SortByCreatedAt
+	case model.SortByUsername:
+		filter.SortBy = model.SortByUsername
+	default:
+		return filter, status.Errorf(codes.InvalidArgument, "unknown sort_by %q", req.sortBy)
+	}
+	switch req.sortOrder {
+	case "", "desc":
+		filter.Desc = true
+	case "asc":
+	default:
+		return filter, status.Errorf(codes.InvalidArgument, "unknown sort_order %q", req.sortOrder)
+	}
+
+	if filter.After, err = decodePageToken(req.pageToken, filter); err != nil {
+		return filter, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	return filter, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func encodePageToken(filter model.ListFilter, last *model.User) string {
+	t := pageToken{SortBy: filter.SortBy, Desc: filter.Desc, Key: last.CreatedAt, Id: last.Id}
+	if filter.SortBy == model.SortByUsername {
+		t.Key = last.Username
+	}
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken returns the cursor to continue after, nil for an empty token
+func decodePageToken(token string, filter model.ListFilter) (*model.ListCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var t pageToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, err
+	}
+	if t.SortBy != filter.SortBy || t.Desc != filter.Desc {
+		return nil, errSortChanged
+	}
+	if _, err := ulid.ParseStrict(t.Id); err != nil {
+		return nil, err
+	}
+	if t.SortBy == model.SortByCreatedAt {
+		if _, err := time.Parse(time.RFC3339Nano, t.Key); err != nil {
+			return nil, err
+		}
+	}
+	return &model.ListCursor{Key: t.Key, Id: t.Id}, nil
+}
+
+func toAdminUser(u *model.User) *user.AdminUser {
+	return &user.AdminUser{
+		UserId:      u.Id,
+		Username:    u.Username,
+		Email:       nullable(u.Email),
+		DisplayName: nullable(u.DisplayName),
+		AvatarUrl:   nullable(u.AvatarUrl),
+		Status:      u.Status,
+		Version:     u.Version,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
+
+// pageSize applies the default to a non-positive request and caps it at max
+func pageSize(requested, def, max int) int {
+	switch {
+	case requested <= 0:
+		return def
+	case requested > max:
+		return max
+	default:
+		return requested
+	}
+}
@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/user/internal/config"
+	"github.com/uwu-octane/antBackend/user/internal/model"
+	"github.com/uwu-octane/antBackend/user/internal/svc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memUsers is an in-memory UserModel.List over users, it keeps the last filter it got
+type memUsers struct {
+	model.UserModel
+	users []*model.User
+	last  model.ListFilter
+}
+
+func (m *memUsers) List(_ context.Context, f model.ListFilter) ([]*model.User, error) {
+	m.last = f
+	key := func(u *model.User) string {
+		if f.SortBy == model.SortByUsername {
+			return u.Username
+		}
+		return u.CreatedAt
+	}
+	less := func(a, b *model.User) bool {
+		if key(a) != key(b) {
+			return key(a) < key(b)
+		}
+		return a.Id < b.Id
+	}
+	var out []*model.User
+	for _, u := range m.users {
+		if f.Query != "" && !strings.Contains(u.Username, f.Query) {
+			continue
+		}
+		if f.After != nil {
+			after := &model.User{Id: f.After.Id, Username: f.After.Key, CreatedAt: f.After.Key}
+			if f.Desc && !less(u, after) || !f.Desc && !less(after, u) {
+				continue
+			}
+		}
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) != f.Desc })
+	if len(out) > f.Limit {
+		out = out[:f.Limit]
+	}
+	return out, nil
+}
+
+func newListSvc() (*svc.ServiceContext, *memUsers) {
+	users := &memUsers{}
+	for i, name := range []string{"carol", "alice", "dave", "bob", "erin"} {
+		users.users = append(users.users, &model.User{
+			Id:        ulid.MustNew(uint64(i+1), nil).String(),
+			Username:  name,
+			Status:    model.StatusActive,
+			CreatedAt: fmt.Sprintf("2026-01-%02dT00:00:00Z", i+1),
+		})
+	}
+	return &svc.ServiceContext{
+		Config: config.Config{UserList: config.UserListConf{DefaultPageSize: 2, MaxPageSize: 3, SearchMinLength: 3}},
+		Users:  users,
+	}, users
+}
+
+func TestListUsers_Pages(t *testing.T) {
+	svcCtx, _ := newListSvc()
+	l := NewListUsersLogic(context.Background(), svcCtx)
+
+	collect := func(sortBy, order string) []string {
+		var got []string
+		token := ""
+		for {
+			resp, err := l.ListUsers(&user.ListUsersReq{SortBy: sortBy, SortOrder: order, PageToken: token})
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(resp.Users), 2)
+			for _, u := range resp.Users {
+				got = append(got, u.Username)
+			}
+			if token = resp.NextPageToken; token == "" {
+				return got
+			}
+		}
+	}
+	assert.Equal(t, []string{"erin", "bob", "dave", "alice", "carol"}, collect("", ""), "newest first by default")
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave", "erin"}, collect("username", "asc"))
+
+	// a token only continues the sort it was issued for
+	resp, err := l.ListUsers(&user.ListUsersReq{SortBy: "username"})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.NextPageToken)
+	_, err = l.ListUsers(&user.ListUsersReq{PageToken: resp.NextPageToken})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListUsers_InvalidArgs(t *testing.T) {
+	svcCtx, users := newListSvc()
+	ctx := context.Background()
+
+	for _, in := range []*user.ListUsersReq{
+		{SortBy: "email"},
+		{SortOrder: "up"},
+		{PageToken: "not-a-token"},
+		{Filter: &user.UserFilter{Status: "banned"}},
+		{Filter: &user.UserFilter{CreatedAfter: "yesterday"}},
+	} {
+		_, err := NewListUsersLogic(ctx, svcCtx).ListUsers(in)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), in.String())
+	}
+
+	_, err := NewListUsersLogic(ctx, svcCtx).ListUsers(&user.ListUsersReq{
+		Filter:   &user.UserFilter{UsernamePrefix: " al ", CreatedAfter: "2026-01-02T00:00:00Z", Status: model.StatusActive},
+		PageSize: 50,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "al", users.last.UsernamePrefix)
+	assert.Equal(t, 2026, users.last.CreatedAfter.Year())
+	assert.Equal(t, 4, users.last.Limit, "capped at MaxPageSize plus the look-ahead row")
+
+	_, err = NewSearchUsersLogic(ctx, svcCtx).SearchUsers(&user.SearchUsersReq{Query: " ro "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "query shorter than SearchMinLength")
+	resp, err := NewSearchUsersLogic(ctx, svcCtx).SearchUsers(&user.SearchUsersReq{Query: "aro"})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 1)
+	assert.Equal(t, "carol", resp.Users[0].Username)
+}
@@ -3,17 +3,42 @@ package model
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/lib/pq"
+	"github.com/oklog/ulid"
 	dbutil "github.com/uwu-octane/antBackend/common/db/util"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
 
+var (
+	ErrNotFound  = sqlx.ErrNotFound
+	ErrDuplicate = errors.New("user: username or email already exists")
+	// ErrVersionConflict means the row was written since the caller read it
+	ErrVersionConflict = errors.New("user: version conflict")
+)
+
+const (
+	StatusActive   = "active"
+	StatusDisabled = "disabled"
+)
+
+const (
+	SortByCreatedAt = "created_at"
+	SortByUsername  = "username"
+)
+
 type User struct {
 	Id          string         `db:"id"`
 	Username    string         `db:"username"`
 	Email       sql.NullString `db:"email"`
 	DisplayName sql.NullString `db:"display_name"`
 	AvatarUrl   sql.NullString `db:"avatar_url"`
+	Status      string         `db:"status"`
+	Version     int64          `db:"version"`
 	CreatedAt   string         `db:"created_at"`
 	UpdatedAt   string         `db:"updated_at"`
 }
@@ -21,8 +46,48 @@ type User struct {
 type UserModel interface {
 	// read only: replica
 	FindOne(ctx context.Context, id string) (*User, error)
-	// write: master
-	//todo implement insert, update, delete
+	// FindMany returns the users found among ids in one query, in no particular order.
+	// Ids that are not valid ulids are skipped, they cannot exist.
+	FindMany(ctx context.Context, ids []string) ([]*User, error)
+	// List returns one page of users matching filter, ordered by filter.SortBy with id as tie-breaker
+	List(ctx context.Context, filter ListFilter) ([]*User, error)
+	// write: master, outbox rows from the OutboxFunc are committed in the same transaction
+	Insert(ctx context.Context, data *User, outbox OutboxFunc) (*User, error)
+	// Update applies the non-nil fields of patch and returns the row before and after the write.
+	// A non-zero expectedVersion must match the stored version, otherwise ErrVersionConflict.
+	// outbox is only called when a column actually changed.
+	Update(ctx context.Context, id string, patch UserPatch, expectedVersion int64, outbox OutboxFunc) (before *User, after *User, err error)
+	Delete(ctx context.Context, id string, outbox OutboxFunc) error
+	// InvalidateCache drops cached profiles of ids, a no-op without a cache
+	InvalidateCache(ctx context.Context, ids ...string) error
+}
+
+// UserPatch holds the columns to change, nil means keep
+type UserPatch struct {
+	Email       *string
+	DisplayName *string
+	AvatarUrl   *string
+}
+
+// ListFilter selects a page of users for the admin listing, zero fields match everything
+type ListFilter struct {
+	Query          string // case-insensitive substring of username, email or display_name
+	UsernamePrefix string // case-insensitive
+	EmailPrefix    string // case-insensitive
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	Status         string
+	SortBy         string // SortByCreatedAt or SortByUsername
+	Desc           bool
+	// After is the last row of the previous page, nil starts from the first row
+	After *ListCursor
+	Limit int
+}
+
+// ListCursor is the position of a row in the sort order: the sort column value and the id
+type ListCursor struct {
+	Key string // created_at as RFC3339 or username, depending on ListFilter.SortBy
+	Id  string
 }
 
 type defaultUserModel struct {
@@ -39,7 +104,7 @@ func NewUsersModel(replica sqlx.SqlConn, master sqlx.SqlConn, selector *dbutil.S
 	}
 }
 
-const userFields = "id, username, email, display_name, avatar_url, created_at, updated_at"
+const userFields = "id, username, email, display_name, avatar_url, status, version, created_at, updated_at"
 
 func (m *defaultUserModel) FindOneWithCallBack(ctx context.Context, id string) (*User, error) {
 	var result User
@@ -70,3 +135,184 @@ func (m *defaultUserModel) FindOne(ctx context.Context, id string) (*User, error
 	}
 	return &user, nil
 }
+
+func (m *defaultUserModel) FindMany(ctx context.Context, ids []string) ([]*User, error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := ulid.ParseStrict(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, nil
+	}
+	var users []*User
+	const query = "SELECT " + userFields + " FROM users WHERE id = ANY($1)"
+	if err := m.replica.QueryRowsCtx(ctx, &users, query, pq.Array(valid)); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (m *defaultUserModel) List(ctx context.Context, filter ListFilter) ([]*User, error) {
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.Query != "" {
+		p := arg("%" + escapeLike(filter.Query) + "%")
+		where = append(where, fmt.Sprintf("(username ILIKE %[1]s OR email ILIKE %[1]s OR display_name ILIKE %[1]s)", p))
+	}
+	if filter.UsernamePrefix != "" {
+		where = append(where, "username ILIKE "+arg(escapeLike(filter.UsernamePrefix)+"%"))
+	}
+	if filter.EmailPrefix != "" {
+		where = append(where, "email ILIKE "+arg(escapeLike(filter.EmailPrefix)+"%"))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		where = append(where, "created_at >= "+arg(filter.CreatedAfter))
+	}
+	if !filter.CreatedBefore.IsZero() {
+		where = append(where, "created_at < "+arg(filter.CreatedBefore))
+	}
+	if filter.Status != "" {
+		where = append(where, "status = "+arg(filter.Status))
+	}
+
+	column := "created_at"
+	if filter.SortBy == SortByUsername {
+		column = "username"
+	}
+	op, dir := ">", "ASC"
+	if filter.Desc {
+		op, dir = "<", "DESC"
+	}
+	if filter.After != nil {
+		key := arg(filter.After.Key)
+		if column == "created_at" {
+			key += "::timestamptz"
+		}
+		where = append(where, fmt.Sprintf("(%s, id) %s (%s, %s)", column, op, key, arg(filter.After.Id)))
+	}
+
+	query := "SELECT " + userFields + " FROM users"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s LIMIT %[3]s", column, dir, arg(filter.Limit))
+
+	var result []*User
+	err := m.selector.Do(ctx, func(ctx context.Context, conn sqlx.SqlConn) error {
+		var users []*User
+		if err := conn.QueryRowsCtx(ctx, &users, query, args...); err != nil {
+			return err
+		}
+		result = users
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (m *defaultUserModel) Insert(ctx context.Context, data *User, outbox OutboxFunc) (*User, error) {
+	var user User
+	err := m.master.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var err error
+		if data.Id == "" {
+			const query = "INSERT INTO users (username, email, display_name, avatar_url) VALUES ($1, $2, $3, $4) RETURNING " + userFields
+			err = session.QueryRowCtx(ctx, &user, query, data.Username, data.Email, data.DisplayName, data.AvatarUrl)
+		} else {
+			const query = "INSERT INTO users (id, username, email, display_name, avatar_url) VALUES ($1, $2, $3, $4, $5) RETURNING " + userFields
+			err = session.QueryRowCtx(ctx, &user, query, data.Id, data.Username, data.Email, data.DisplayName, data.AvatarUrl)
+		}
+		if err != nil {
+			return err
+		}
+		return writeOutbox(ctx, session, outbox, nil, &user)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicate
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (m *defaultUserModel) Update(ctx context.Context, id string, patch UserPatch, expectedVersion int64, outbox OutboxFunc) (*User, *User, error) {
+	var before, after User
+	err := m.master.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		const lockQuery = "SELECT " + userFields + " FROM users WHERE id = $1 FOR UPDATE"
+		if err := session.QueryRowCtx(ctx, &before, lockQuery, id); err != nil {
+			return err
+		}
+		if expectedVersion != 0 && before.Version != expectedVersion {
+			return ErrVersionConflict
+		}
+
+		var sets []string
+		var args []any
+		add := func(column string, value *string, current sql.NullString) {
+			if value == nil || nullIfEmpty(*value) == current {
+				return
+			}
+			args = append(args, nullIfEmpty(*value))
+			sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+		}
+		add("email", patch.Email, before.Email)
+		add("display_name", patch.DisplayName, before.DisplayName)
+		add("avatar_url", patch.AvatarUrl, before.AvatarUrl)
+		if len(sets) == 0 {
+			after = before
+			return nil
+		}
+
+		sets = append(sets, "version = version + 1")
+		args = append(args, id)
+		query := fmt.Sprintf("UPDATE users SET %s WHERE id = $%d RETURNING %s", strings.Join(sets, ", "), len(args), userFields)
+		if err := session.QueryRowCtx(ctx, &after, query, args...); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, session, outbox, &before, &after)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, nil, ErrDuplicate
+		}
+		return nil, nil, err
+	}
+	return &before, &after, nil
+}
+
+func (m *defaultUserModel) Delete(ctx context.Context, id string, outbox OutboxFunc) error {
+	return m.master.TransactCtx(ctx, func(ctx context.Context, session sqlx.Session) error {
+		var before User
+		const query = "DELETE FROM users WHERE id = $1 RETURNING " + userFields
+		if err := session.QueryRowCtx(ctx, &before, query, id); err != nil {
+			return err
+		}
+		return writeOutbox(ctx, session, outbox, &before, nil)
+	})
+}
+
+func (m *defaultUserModel) InvalidateCache(context.Context, ...string) error {
+	return nil
+}
+
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// escapeLike quotes the LIKE wildcards in s, backslash is the default escape character
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+)
+
+// cachedUserModel is a read-through Redis cache in front of FindOne.
+// Missing users are cached as a placeholder for the not-found expiry, concurrent misses of one id
+// share a single replica query, and expiries vary by ±5% so entries written together do not expire together.
+// Writes drop the entry after commit; the consumed user events drop it again on whichever replica
+// handles them, which also clears entries a lagging read replica refilled with the old row.
+type cachedUserModel struct {
+	UserModel
+	cache     cache.Cache
+	rds       *redis.Redis // FindMany reads the entries of cache with a single MGET
+	keyPrefix string
+}
+
+func NewCachedUserModel(m UserModel, c cache.Cache, rds *redis.Redis, keyPrefix string) *cachedUserModel {
+	return &cachedUserModel{
+		UserModel: m,
+		cache:     c,
+		rds:       rds,
+		keyPrefix: keyPrefix,
+	}
+}
+
+func (m *cachedUserModel) key(id string) string {
+	return m.keyPrefix + id
+}
+
+// FindOne fails fast on Redis errors instead of sending every read to the database
+func (m *cachedUserModel) FindOne(ctx context.Context, id string) (*User, error) {
+	var user User
+	err := m.cache.TakeCtx(ctx, &user, m.key(id), func(v any) error {
+		u, err := m.UserModel.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+		*v.(*User) = *u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindMany reads every id with one MGET and loads the misses with one FindMany of the wrapped model.
+// Cached not-found placeholders count as misses, the batch query tells again which ids are missing.
+// A Redis error falls back to the database: unlike FindOne the batch is a single query either way.
+func (m *cachedUserModel) FindMany(ctx context.Context, ids []string) ([]*User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = m.key(id)
+	}
+	vals, err := m.rds.MgetCtx(ctx, keys...)
+	if err != nil {
+		logx.WithContext(ctx).Errorw("user profile cache mget failed", logx.Field("error", err))
+		vals = make([]string, len(ids))
+	}
+
+	users := make([]*User, 0, len(ids))
+	var misses []string
+	for i, v := range vals {
+		var u User
+		if v == "" || json.Unmarshal([]byte(v), &u) != nil {
+			misses = append(misses, ids[i])
+			continue
+		}
+		users = append(users, &u)
+	}
+	if len(misses) == 0 {
+		return users, nil
+	}
+
+	loaded, err := m.UserModel.FindMany(ctx, misses)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range loaded {
+		if err := m.cache.SetCtx(ctx, m.key(u.Id), u); err != nil {
+			logx.WithContext(ctx).Errorw("user profile cache set failed", logx.Field("error", err))
+		}
+	}
+	return append(users, loaded...), nil
+}
+
+func (m *cachedUserModel) Insert(ctx context.Context, data *User, outbox OutboxFunc) (*User, error) {
+	user, err := m.UserModel.Insert(ctx, data, outbox)
+	if err != nil {
+		return nil, err
+	}
+	// drops a cached not-found for a reused id
+	return user, m.InvalidateCache(ctx, user.Id)
+}
+
+func (m *cachedUserModel) Update(ctx context.Context, id string, patch UserPatch, expectedVersion int64, outbox OutboxFunc) (*User, *User, error) {
+	before, after, err := m.UserModel.Update(ctx, id, patch, expectedVersion, outbox)
+	if err != nil {
+		return nil, nil, err
+	}
+	if after.Version != before.Version {
+		if err := m.InvalidateCache(ctx, id); err != nil {
+			return nil, nil, err
+		}
+	}
+	return before, after, nil
+}
+
+func (m *cachedUserModel) Delete(ctx context.Context, id string, outbox OutboxFunc) error {
+	if err := m.UserModel.Delete(ctx, id, outbox); err != nil {
+		return err
+	}
+	return m.InvalidateCache(ctx, id)
+}
+
+// InvalidateCache drops the cached profiles, a failed delete is retried in the background by the cache
+func (m *cachedUserModel) InvalidateCache(ctx context.Context, ids ...string) error {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, m.key(id))
+	}
+	return m.cache.DelCtx(ctx, keys...)
+}
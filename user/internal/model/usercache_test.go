@@ -0,0 +1,197 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
+	"github.com/zeromicro/go-zero/core/syncx"
+)
+
+// countingUsers is an in-memory UserModel counting FindOne calls, release gates FindOne when set.
+// FindMany records the ids of every call in batches.
+type countingUsers struct {
+	UserModel
+	mu      sync.Mutex
+	users   map[string]*User
+	finds   atomic.Int32
+	batches [][]string
+	release chan struct{}
+}
+
+func (m *countingUsers) FindOne(_ context.Context, id string) (*User, error) {
+	m.finds.Add(1)
+	if m.release != nil {
+		<-m.release
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.users[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *u
+	return &cp, nil
+}
+
+func (m *countingUsers) FindMany(_ context.Context, ids []string) ([]*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, ids)
+	var out []*User
+	for _, id := range ids {
+		if u, ok := m.users[id]; ok {
+			cp := *u
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (m *countingUsers) Insert(_ context.Context, data *User, _ OutboxFunc) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *data
+	m.users[data.Id] = &cp
+	return &cp, nil
+}
+
+func (m *countingUsers) Update(_ context.Context, id string, patch UserPatch, _ int64, _ OutboxFunc) (*User, *User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	before := *m.users[id]
+	after := before
+	if patch.DisplayName != nil && nullIfEmpty(*patch.DisplayName) != before.DisplayName {
+		after.DisplayName = nullIfEmpty(*patch.DisplayName)
+		after.Version++
+	}
+	m.users[id] = &after
+	return &before, &after, nil
+}
+
+func newCachedUsers(t *testing.T) (*cachedUserModel, *countingUsers, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rds := redis.New(mr.Addr())
+	node := cache.NewNode(rds, syncx.NewSingleFlight(), cache.NewStat("test"), ErrNotFound,
+		cache.WithExpiry(time.Minute), cache.WithNotFoundExpiry(10*time.Second))
+	base := &countingUsers{users: map[string]*User{
+		"u-1": {Id: "u-1", Username: "ant", DisplayName: sql.NullString{String: "Ant", Valid: true}, Version: 1},
+	}}
+	return NewCachedUserModel(base, node, rds, "user:profile:"), base, mr
+}
+
+func TestCachedUserModel_ReadThrough(t *testing.T) {
+	m, base, mr := newCachedUsers(t)
+	ctx := context.Background()
+
+	for range 3 {
+		u, err := m.FindOne(ctx, "u-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Ant", u.DisplayName.String)
+	}
+	assert.Equal(t, int32(1), base.finds.Load())
+	ttl := mr.TTL("user:profile:u-1")
+	assert.True(t, ttl >= 57*time.Second && ttl <= 63*time.Second, "ttl %s", ttl)
+
+	// missing users are cached as a placeholder with the shorter expiry
+	for range 2 {
+		_, err := m.FindOne(ctx, "u-404")
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, int32(2), base.finds.Load())
+	assert.Less(t, mr.TTL("user:profile:u-404"), 15*time.Second)
+
+	// creating the user drops the placeholder
+	_, err := m.Insert(ctx, &User{Id: "u-404", Username: "late"}, nil)
+	require.NoError(t, err)
+	u, err := m.FindOne(ctx, "u-404")
+	require.NoError(t, err)
+	assert.Equal(t, "late", u.Username)
+}
+
+func TestCachedUserModel_Invalidation(t *testing.T) {
+	m, base, mr := newCachedUsers(t)
+	ctx := context.Background()
+	_, err := m.FindOne(ctx, "u-1")
+	require.NoError(t, err)
+
+	// a no-op update keeps the cache
+	same := "Ant"
+	_, _, err = m.Update(ctx, "u-1", UserPatch{DisplayName: &same}, 0, nil)
+	require.NoError(t, err)
+	assert.True(t, mr.Exists("user:profile:u-1"))
+
+	name := "Octane"
+	_, _, err = m.Update(ctx, "u-1", UserPatch{DisplayName: &name}, 0, nil)
+	require.NoError(t, err)
+	assert.False(t, mr.Exists("user:profile:u-1"))
+	u, err := m.FindOne(ctx, "u-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Octane", u.DisplayName.String)
+
+	require.NoError(t, m.InvalidateCache(ctx, "u-1"))
+	assert.False(t, mr.Exists("user:profile:u-1"))
+	assert.Equal(t, int32(2), base.finds.Load())
+}
+
+func TestCachedUserModel_CollapsesConcurrentMisses(t *testing.T) {
+	m, base, _ := newCachedUsers(t)
+	base.release = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, err := m.FindOne(context.Background(), "u-1")
+			assert.NoError(t, err)
+			assert.Equal(t, "ant", u.Username)
+		}()
+	}
+	// let the readers pile up behind the first query
+	time.Sleep(50 * time.Millisecond)
+	close(base.release)
+	wg.Wait()
+	assert.Equal(t, int32(1), base.finds.Load())
+}
+
+func TestCachedUserModel_FindMany(t *testing.T) {
+	m, base, mr := newCachedUsers(t)
+	ctx := context.Background()
+	base.users["u-2"] = &User{Id: "u-2", Username: "octane", Version: 1}
+
+	// u-404 has a cached not-found placeholder
+	_, err := m.FindOne(ctx, "u-404")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	users, err := m.FindMany(ctx, []string{"u-1", "u-2", "u-404"})
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	require.Len(t, base.batches, 1)
+	assert.Equal(t, []string{"u-1", "u-2", "u-404"}, base.batches[0])
+	assert.True(t, mr.Exists("user:profile:u-2"))
+
+	// every found user is served from the cache now, only the missing one is queried
+	users, err = m.FindMany(ctx, []string{"u-2", "u-1", "u-404"})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, "Ant", users[1].DisplayName.String)
+	require.Len(t, base.batches, 2)
+	assert.Equal(t, []string{"u-404"}, base.batches[1])
+
+	// a Redis outage falls back to the database
+	mr.SetError("down")
+	users, err = m.FindMany(ctx, []string{"u-1"})
+	mr.SetError("")
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Len(t, base.batches, 3)
+}
@@ -32,3 +32,33 @@ func (s *UserServiceServer) GetUserInfo(ctx context.Context, in *user.GetUserInf
 	l := logic.NewGetUserInfoLogic(ctx, s.svcCtx)
 	return l.GetUserInfo(in)
 }
+
+func (s *UserServiceServer) CreateUser(ctx context.Context, in *user.CreateUserReq) (*user.GetUserInfoResp, error) {
+	l := logic.NewCreateUserLogic(ctx, s.svcCtx)
+	return l.CreateUser(in)
+}
+
+func (s *UserServiceServer) UpdateUser(ctx context.Context, in *user.UpdateUserReq) (*user.GetUserInfoResp, error) {
+	l := logic.NewUpdateUserLogic(ctx, s.svcCtx)
+	return l.UpdateUser(in)
+}
+
+func (s *UserServiceServer) DeleteUser(ctx context.Context, in *user.DeleteUserReq) (*user.DeleteUserResp, error) {
+	l := logic.NewDeleteUserLogic(ctx, s.svcCtx)
+	return l.DeleteUser(in)
+}
+
+func (s *UserServiceServer) BatchGetUsers(ctx context.Context, in *user.BatchGetUsersReq) (*user.BatchGetUsersResp, error) {
+	l := logic.NewBatchGetUsersLogic(ctx, s.svcCtx)
+	return l.BatchGetUsers(in)
+}
+
+func (s *UserServiceServer) ListUsers(ctx context.Context, in *user.ListUsersReq) (*user.ListUsersResp, error) {
+	l := logic.NewListUsersLogic(ctx, s.svcCtx)
+	return l.ListUsers(in)
+}
+
+func (s *UserServiceServer) SearchUsers(ctx context.Context, in *user.SearchUsersReq) (*user.ListUsersResp, error) {
+	l := logic.NewSearchUsersLogic(ctx, s.svcCtx)
+	return l.SearchUsers(in)
+}
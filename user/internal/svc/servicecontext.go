@@ -1,26 +1,41 @@
 package svc
 
 import (
+	"context"
 	"time"
 
+	"github.com/uwu-octane/antBackend/common/eventbus/codec"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
 	"github.com/uwu-octane/antBackend/common/eventbus/publisher"
 	kpub "github.com/uwu-octane/antBackend/common/eventbus/publisher/kafka"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/memory"
+	"github.com/uwu-octane/antBackend/common/eventbus/publisher/redisstream"
 
 	dbutil "github.com/uwu-octane/antBackend/common/db/util"
 	eventbus "github.com/uwu-octane/antBackend/common/eventbus/event"
 	"github.com/uwu-octane/antBackend/user/internal/config"
-	event "github.com/uwu-octane/antBackend/user/internal/event"
+	"github.com/uwu-octane/antBackend/user/event"
 	"github.com/uwu-octane/antBackend/user/internal/model"
 	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/stores/cache"
+	"github.com/zeromicro/go-zero/core/stores/redis"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/core/syncx"
 )
 
+// shutdownTimeout 退出时等待异步发布确认的上限
+const shutdownTimeout = 10 * time.Second
+
 type ServiceContext struct {
 	Config           config.Config
 	Master           sqlx.SqlConn
 	Replica          sqlx.SqlConn
 	Users            model.UserModel
+	Outbox           outbox.Store
+	Redis            *redis.Redis
 	UserEventsPusher *publisher.EventBusPublisher
+	// MemoryBus 仅在 EventBus.Backend 为 memory 时非空，订阅者从这里消费
+	MemoryBus *memory.Bus
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -28,21 +43,59 @@ func NewServiceContext(c config.Config) *ServiceContext {
 	replica := sqlx.NewSqlConn(c.UserDatabase.Driver, c.UserDatabase.ReplicaDSN)
 
 	selector := dbutil.NewSelector(replica, master, c.UserReadStrategy.FromReplica, c.UserReadStrategy.FallbackToMasterOnReadError, nil)
-	users := model.NewUsersModel(replica, master, selector)
+	var users model.UserModel = model.NewUsersModel(replica, master, selector)
 
-	userEventsPusher := kafkaUserEventsPusher(c)
+	rds := redis.MustNewRedis(c.UserRedis.RedisConf)
+	if !c.UserCache.Disabled {
+		profiles := cache.NewNode(rds, syncx.NewSingleFlight(), cache.NewStat("user.profile"), model.ErrNotFound,
+			cache.WithExpiry(time.Duration(c.UserCache.TTLSeconds)*time.Second),
+			cache.WithNotFoundExpiry(time.Duration(c.UserCache.NotFoundTTLSeconds)*time.Second),
+		)
+		users = model.NewCachedUserModel(users, profiles, rds, c.UserRedis.Key+"profile:")
+	}
+	var bus *memory.Bus
+	if c.EventBus.Backend == config.EventBackendMemory {
+		bus = memory.NewBus()
+	}
 
 	return &ServiceContext{
 		Config:           c,
 		Master:           master,
 		Replica:          replica,
 		Users:            users,
-		UserEventsPusher: userEventsPusher,
+		Outbox:           model.NewOutboxModel(master),
+		Redis:            rds,
+		UserEventsPusher: userEventsPusher(c, rds, bus),
+		MemoryBus:        bus,
 	}
 }
 
-func kafkaUserEventsPusher(c config.Config) *publisher.EventBusPublisher {
-	topics := eventbus.BuildTopics(eventbus.Env(c.Kafka.Env), event.TopicSuffixUserEvents)
+// userEventsPusher 按 EventBus.Backend 创建用户事件发布器；返回 nil 时 outbox 中继不启动，事件留在 user_outbox 中
+func userEventsPusher(c config.Config, rds *redis.Redis, bus *memory.Bus) *publisher.EventBusPublisher {
+	topics := eventbus.BuildTopics(eventbus.Env(c.Kafka.Env))
+	eventCodec, err := codec.ByName(c.KafkaUserProducer.Codec)
+	if err != nil {
+		logx.Errorw("unknown user events codec", logx.Field("error", err))
+		return nil
+	}
+	// key 加载失败时不发布，事件留在 user_outbox 中，避免 PII 以明文写入 Kafka
+	if err := loadEventKeys(c.EventPII.KeyFile); err != nil {
+		logx.Errorw("load event pii keys failed", logx.Field("error", err))
+		return nil
+	}
+
+	switch c.EventBus.Backend {
+	case config.EventBackendMemory:
+		logx.Infow("user events use the in-memory bus, events are lost on restart")
+		return publisher.NewEventBusPublisher(bus, topics, publisher.WithCodec(eventCodec))
+	case config.EventBackendRedis:
+		pub := redisstream.NewPublisher(rds, redisstream.Options{
+			KeyPrefix: c.EventBus.StreamPrefix,
+			MaxLen:    c.EventBus.StreamMaxLen,
+		})
+		return publisher.NewEventBusPublisher(pub, topics, publisher.WithCodec(eventCodec))
+	}
+
 	opts := kpub.ProducerOptions{
 		Brokers:         c.Kafka.Brokers,
 		Acks:            c.KafkaUserProducer.Acks,
@@ -58,11 +111,75 @@ func kafkaUserEventsPusher(c config.Config) *publisher.EventBusPublisher {
 		SASLUsername:    c.KafkaUserProducer.SASL.Username,
 		SASLPassword:    c.KafkaUserProducer.SASL.Password,
 		EnableTLS:       c.KafkaUserProducer.TLS.Enable,
+		ClientID:        event.Producer,
+	}
+	ensureKafkaTopics(c, &opts, topics)
+	var pub publisher.Publisher
+	if c.KafkaUserProducer.Async {
+		pub, err = kpub.NewAsyncSaramaPublisher(&opts, nil)
+	} else {
+		pub, err = kpub.NewSaramaPublisher(&opts)
 	}
-	pub, err := kpub.NewSaramaPublisher(&opts)
 	if err != nil {
 		logx.Errorw("create kafka user events publisher failed", logx.Field("error", err))
 		return nil
 	}
-	return publisher.NewEventBusPublisher(pub, topics)
+	return publisher.NewEventBusPublisher(pub, topics, publisher.WithCodec(eventCodec))
+}
+
+// Close 等待异步发布的事件得到确认后关闭发布器，在 rpc server、outbox 中继与消费者停止之后调用
+func (s *ServiceContext) Close() {
+	if s.UserEventsPusher == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.UserEventsPusher.Close(ctx); err != nil {
+		logx.Errorw("close user events publisher failed", logx.Field("error", err))
+	}
+}
+
+// loadEventKeys 设置进程内事件 PII 字段加解密使用的 key，keyFile 为空时不加密
+func loadEventKeys(keyFile string) error {
+	if keyFile == "" {
+		return nil
+	}
+	keys, err := codec.NewFileKeyProvider(keyFile)
+	if err != nil {
+		return err
+	}
+	codec.SetKeyProvider(keys)
+	return nil
+}
+
+// ensureKafkaTopics 按 Kafka.Topics.AutoCreate 创建已登记路由的各 stream 以及用户事件的重试/死信 topic；
+// 失败只记录日志，发送时由 broker 报错
+func ensureKafkaTopics(c config.Config, opts *kpub.ProducerOptions, topics eventbus.TopicSet) {
+	switch c.Kafka.Topics.AutoCreate {
+	case config.TopicAutoCreateNever:
+		return
+	case config.TopicAutoCreateDev:
+		if topics.Env != eventbus.EnvDev {
+			return
+		}
+	}
+
+	names := topics.Topics(eventbus.DefaultRoutes.Streams()...)
+	userEvents := topics.UserEvents()
+	for _, v := range c.EventRetry.Delays {
+		// 非法配置由消费者启动时报错
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			names = append(names, eventbus.RetryTopic(userEvents, d))
+		}
+	}
+	names = append(names, eventbus.DLQTopic(userEvents))
+
+	_, err := kpub.EnsureTopics(opts, names, kpub.TopicSpec{
+		Partitions:        c.Kafka.Topics.Partitions,
+		ReplicationFactor: c.Kafka.Topics.ReplicationFactor,
+		RetentionMs:       c.Kafka.Topics.RetentionMs,
+	})
+	if err != nil {
+		logx.Errorw("ensure kafka event topics failed", logx.Field("error", err))
+	}
 }
@@ -6,11 +6,15 @@ import (
 	"log"
 
 	"github.com/uwu-octane/antBackend/api/v1/user"
+	"github.com/uwu-octane/antBackend/common/eventbus/outbox"
+	"github.com/uwu-octane/antBackend/common/requestid"
 	"github.com/uwu-octane/antBackend/user/internal/config"
+	"github.com/uwu-octane/antBackend/user/internal/consumer"
 	"github.com/uwu-octane/antBackend/user/internal/server"
 	"github.com/uwu-octane/antBackend/user/internal/svc"
 
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"google.golang.org/grpc"
@@ -36,12 +40,30 @@ func main() {
 			reflection.Register(grpcServer)
 		}
 	})
+	s.AddUnaryInterceptors(requestid.UnaryServerInterceptor)
 
 	if err := consul.RegisterService(c.ListenOn, c.Consul); err != nil {
 		log.Fatal(err)
 	}
-	defer s.Stop()
+
+	// 先停止 rpc server、中继与消费者，再关闭发布器
+	defer ctx.Close()
+	group := service.NewServiceGroup()
+	defer group.Stop()
+	group.Add(s)
+	if !c.Outbox.Disabled && ctx.UserEventsPusher != nil {
+		group.Add(outbox.NewRelay("user", c.Outbox, ctx.Outbox, outbox.EventBus(ctx.UserEventsPusher)))
+	}
+	if c.EventBus.Backend != config.EventBackendKafka || len(c.KqUserEvents.Brokers) > 0 {
+		sub, err := consumer.NewUserEventsConsumer(ctx).NewSubscriber(c.KqUserEvents)
+		if err != nil {
+			// 与 publisher 一致：broker 不可用时降级运行，不阻塞 rpc 启动
+			logx.Errorw("create user events subscriber failed", logx.Field("error", err))
+		} else {
+			group.Add(sub)
+		}
+	}
 
 	fmt.Printf("Starting rpc server at %s...\n", c.ListenOn)
-	s.Start()
+	group.Start()
 }
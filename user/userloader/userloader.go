@@ -0,0 +1,38 @@
+// Package userloader batches concurrent profile lookups of fan-out callers into BatchGetUsers calls
+package userloader
+
+import (
+	"context"
+
+	"github.com/uwu-octane/antBackend/common/dataloader"
+	"github.com/uwu-octane/antBackend/user/userservice"
+)
+
+// ErrNotFound is returned by Load for an id the user service does not know
+var ErrNotFound = dataloader.ErrNotFound
+
+type Loader struct {
+	loader *dataloader.Loader[string, *userservice.GetUserInfoResp]
+}
+
+// New returns a Loader over cli. The max batch size must not exceed the server's UserBatch.MaxIds,
+// the default of both is 100.
+func New(cli userservice.UserService, opts ...dataloader.Option) *Loader {
+	fetch := func(ctx context.Context, ids []string) (map[string]*userservice.GetUserInfoResp, error) {
+		resp, err := cli.BatchGetUsers(ctx, &userservice.BatchGetUsersReq{UserIds: ids})
+		if err != nil {
+			return nil, err
+		}
+		users := make(map[string]*userservice.GetUserInfoResp, len(resp.Users))
+		for _, u := range resp.Users {
+			users[u.UserId] = u
+		}
+		return users, nil
+	}
+	return &Loader{loader: dataloader.New(fetch, opts...)}
+}
+
+// Load returns the profile of id, lookups issued within the batch window share one BatchGetUsers call
+func (l *Loader) Load(ctx context.Context, id string) (*userservice.GetUserInfoResp, error) {
+	return l.loader.Load(ctx, id)
+}
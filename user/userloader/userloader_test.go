@@ -0,0 +1,66 @@
+package userloader
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uwu-octane/antBackend/common/dataloader"
+	"github.com/uwu-octane/antBackend/user/userservice"
+	"google.golang.org/grpc"
+)
+
+// fakeUsers serves BatchGetUsers from users and records the requested ids of every call
+type fakeUsers struct {
+	userservice.UserService
+	mu    sync.Mutex
+	calls [][]string
+	users map[string]*userservice.GetUserInfoResp
+}
+
+func (f *fakeUsers) BatchGetUsers(_ context.Context, in *userservice.BatchGetUsersReq, _ ...grpc.CallOption) (*userservice.BatchGetUsersResp, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, in.UserIds)
+	f.mu.Unlock()
+	resp := &userservice.BatchGetUsersResp{}
+	for _, id := range in.UserIds {
+		if u, ok := f.users[id]; ok {
+			resp.Users = append(resp.Users, u)
+		} else {
+			resp.NotFound = append(resp.NotFound, id)
+		}
+	}
+	return resp, nil
+}
+
+func TestLoader_Load(t *testing.T) {
+	cli := &fakeUsers{users: map[string]*userservice.GetUserInfoResp{
+		"u-1": {UserId: "u-1", Username: "alice"},
+		"u-2": {UserId: "u-2", Username: "bob"},
+	}}
+	l := New(cli, dataloader.WithWait(20*time.Millisecond))
+
+	ids := []string{"u-1", "u-2", "u-3"}
+	got := make([]*userservice.GetUserInfoResp, len(ids))
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i], errs[i] = l.Load(context.Background(), id)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, cli.calls, 1)
+	assert.ElementsMatch(t, ids, cli.calls[0])
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	assert.Equal(t, "alice", got[0].Username)
+	assert.Equal(t, "bob", got[1].Username)
+	assert.ErrorIs(t, errs[2], ErrNotFound)
+}
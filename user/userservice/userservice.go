@@ -14,14 +14,32 @@ import (
 )
 
 type (
-	GetUserInfoReq  = user.GetUserInfoReq
-	GetUserInfoResp = user.GetUserInfoResp
-	PingReq         = user.PingReq
-	PingResp        = user.PingResp
+	AdminUser         = user.AdminUser
+	BatchGetUsersReq  = user.BatchGetUsersReq
+	BatchGetUsersResp = user.BatchGetUsersResp
+	CreateUserReq     = user.CreateUserReq
+	DeleteUserReq     = user.DeleteUserReq
+	DeleteUserResp    = user.DeleteUserResp
+	GetUserInfoReq    = user.GetUserInfoReq
+	GetUserInfoResp   = user.GetUserInfoResp
+	ListUsersReq      = user.ListUsersReq
+	ListUsersResp     = user.ListUsersResp
+	PingReq           = user.PingReq
+	PingResp          = user.PingResp
+	SearchUsersReq    = user.SearchUsersReq
+	UpdateUserReq     = user.UpdateUserReq
+	UserFilter        = user.UserFilter
 
 	UserService interface {
 		Ping(ctx context.Context, in *PingReq, opts ...grpc.CallOption) (*PingResp, error)
 		GetUserInfo(ctx context.Context, in *GetUserInfoReq, opts ...grpc.CallOption) (*GetUserInfoResp, error)
+		CreateUser(ctx context.Context, in *CreateUserReq, opts ...grpc.CallOption) (*GetUserInfoResp, error)
+		UpdateUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*GetUserInfoResp, error)
+		DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*DeleteUserResp, error)
+		BatchGetUsers(ctx context.Context, in *BatchGetUsersReq, opts ...grpc.CallOption) (*BatchGetUsersResp, error)
+		// admin listing, the gateway checks the caller's role
+		ListUsers(ctx context.Context, in *ListUsersReq, opts ...grpc.CallOption) (*ListUsersResp, error)
+		SearchUsers(ctx context.Context, in *SearchUsersReq, opts ...grpc.CallOption) (*ListUsersResp, error)
 	}
 
 	defaultUserService struct {
@@ -44,3 +62,34 @@ func (m *defaultUserService) GetUserInfo(ctx context.Context, in *GetUserInfoReq
 	client := user.NewUserServiceClient(m.cli.Conn())
 	return client.GetUserInfo(ctx, in, opts...)
 }
+
+func (m *defaultUserService) CreateUser(ctx context.Context, in *CreateUserReq, opts ...grpc.CallOption) (*GetUserInfoResp, error) {
+	client := user.NewUserServiceClient(m.cli.Conn())
+	return client.CreateUser(ctx, in, opts...)
+}
+
+func (m *defaultUserService) UpdateUser(ctx context.Context, in *UpdateUserReq, opts ...grpc.CallOption) (*GetUserInfoResp, error) {
+	client := user.NewUserServiceClient(m.cli.Conn())
+	return client.UpdateUser(ctx, in, opts...)
+}
+
+func (m *defaultUserService) DeleteUser(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*DeleteUserResp, error) {
+	client := user.NewUserServiceClient(m.cli.Conn())
+	return client.DeleteUser(ctx, in, opts...)
+}
+
+func (m *defaultUserService) BatchGetUsers(ctx context.Context, in *BatchGetUsersReq, opts ...grpc.CallOption) (*BatchGetUsersResp, error) {
+	client := user.NewUserServiceClient(m.cli.Conn())
+	return client.BatchGetUsers(ctx, in, opts...)
+}
+
+// admin listing, the gateway checks the caller's role
+func (m *defaultUserService) ListUsers(ctx context.Context, in *ListUsersReq, opts ...grpc.CallOption) (*ListUsersResp, error) {
+	client := user.NewUserServiceClient(m.cli.Conn())
+	return client.ListUsers(ctx, in, opts...)
+}
+
+func (m *defaultUserService) SearchUsers(ctx context.Context, in *SearchUsersReq, opts ...grpc.CallOption) (*ListUsersResp, error) {
+	client := user.NewUserServiceClient(m.cli.Conn())
+	return client.SearchUsers(ctx, in, opts...)
+}